@@ -1,834 +1,1416 @@
-// Copyright 2010 The Go Authors. All rights reserved.
-// Use of this source code is governed by a BSD-style
-// license that can be found in the LICENSE file.
+// Code generated by leg -emit-builder. DO NOT EDIT.
 
 package main
 
 import (
-    "runtime"
+	"log"
+	"runtime"
 )
 
 func main() {
-    runtime.GOMAXPROCS(2)
-    t := New(true, true)
-
-    /*package main
-      type Leg Peg {
-       *Tree
-      }*/
-    t.AddPackage("main")
-    t.AddLeg("Leg")
-    t.AddState(`
+	runtime.GOMAXPROCS(2)
+	t := New(true, true)
+
+	t.AddPackage("main")
+	t.AddLeg("Leg")
+	t.AddState(`
  *Tree
 `)
-    t.AddYYSType("int")
-
-    /* Grammar         <- - 'package' - Identifier      { p.AddPackage(buffer[begin:end]) }
-       'type' - 'YYSTYPE' - Identifier { p.AddYYSType(buffer[begin:end]) } 
-       'type' - Identifier         { p.AddLeg(buffer[begin:end]) }
-       'Peg' - Action              { p.AddState(buffer[begin:end]) }
-       ( Declaration | Definition)+ Trailer? EndOfFile */
-    t.AddRule("Grammar")
-    t.AddName("-")
-    t.AddCharacter(`p`)
-    t.AddCharacter(`a`)
-    t.AddSequence()
-    t.AddCharacter(`c`)
-    t.AddSequence()
-    t.AddCharacter(`k`)
-    t.AddSequence()
-    t.AddCharacter(`a`)
-    t.AddSequence()
-    t.AddCharacter(`g`)
-    t.AddSequence()
-    t.AddCharacter(`e`)
-    t.AddSequence()
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddName("Identifier")
-    t.AddSequence()
-    t.AddAction(" p.AddPackage(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddCharacter(`Y`)
-    t.AddCharacter(`Y`)
-    t.AddSequence()
-    t.AddCharacter(`S`)
-    t.AddSequence()
-    t.AddCharacter(`T`)
-    t.AddSequence()
-    t.AddCharacter(`Y`)
-    t.AddSequence()
-    t.AddCharacter(`P`)
-    t.AddSequence()
-    t.AddCharacter(`E`)
-    t.AddSequence()
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddName("Identifier")
-    t.AddSequence()
-    t.AddAction(" p.AddYYSType(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddCharacter(`t`)
-    t.AddCharacter(`y`)
-    t.AddSequence()
-    t.AddCharacter(`p`)
-    t.AddSequence()
-    t.AddCharacter(`e`)
-    t.AddSequence()
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddName("Identifier")
-    t.AddSequence()
-    t.AddAction(" p.AddLeg(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddCharacter(`P`)
-    t.AddCharacter(`e`)
-    t.AddSequence()
-    t.AddCharacter(`g`)
-    t.AddSequence()
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddName("Action")
-    t.AddSequence()
-    t.AddAction(" p.AddState(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddName("Declaration")
-    t.AddName("Definition")
-    t.AddAlternate()
-    t.AddPlus()
-    t.AddSequence()
-    t.AddName("Trailer")
-    t.AddQuery()
-    t.AddSequence()
-    t.AddName("EndOfFile")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Declaration = '%{' < ( !'%}' . )* >  {  p.AddDeclaration(buffer[begin:end])  }* RPERCENT */
-    t.AddRule("Declaration")
-    t.AddCharacter("%")
-    t.AddCharacter("{")
-    t.AddSequence()
-    t.AddPush()
-    t.AddCharacter("%")
-    t.AddCharacter("}")
-    t.AddSequence()
-    t.AddPush()
-    t.AddPeekNot()
-    t.AddDot()
-    t.AddSequence()
-    t.AddStar()
-    t.AddPush()
-    t.AddSequence()
-    t.AddName("RPERCENT")
-    t.AddSequence()
-    t.AddAction("  p.AddDeclaration(buffer[begin:end])  ")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* trailer =       '%%' < .* > { p.AddTrailer(buffer[begin:end]) }*/
-    t.AddRule("Trailer")
-    t.AddCharacter("%")
-    t.AddCharacter("%")
-    t.AddSequence()
-    t.AddDot()
-    t.AddStar()
-    t.AddPush()
-    t.AddAction(" p.AddTrailer(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Definition      <- Identifier                   { p.AddRule(buffer[begin:end]) }
-       Equal Expression         { p.AddExpression() }*/
-    t.AddRule("Definition")
-    t.AddName("Identifier")
-    t.AddAction(" p.AddRule(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddName("Equal")
-    t.AddSequence()
-    t.AddName("Expression")
-    t.AddSequence()
-    t.AddAction(" p.AddExpression() ")
-    t.AddSequence()
-    // t.AddName("Identifier")
-    // t.AddName("Equal")
-    // t.AddSequence()
-    // t.AddDot()
-    // t.AddPeekNot()
-    // t.AddAlternate()
-    // t.AddPeekFor()
-    // t.AddSequence()
-    t.AddExpression()
-
-    /* Expression      <- Sequence (Bar Sequence     { p.AddAlternate() }
-               )* (Bar           { p.AddNil(); p.AddAlternate() }
-                  )?
-    / { p.AddNil() } */
-    t.AddRule("Expression")
-    t.AddName("Sequence")
-    t.AddName("Bar")
-    t.AddName("Sequence")
-    t.AddSequence()
-    t.AddAction(" p.AddAlternate() ")
-    t.AddSequence()
-    t.AddStar()
-    t.AddSequence()
-    t.AddName("Bar")
-    t.AddAction(" p.AddNil(); p.AddAlternate() ")
-    t.AddSequence()
-    t.AddQuery()
-    t.AddSequence()
-    t.AddAction(" p.AddNil() ")
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Sequence        <- Prefix (Prefix               { p.AddSequence() }
-       )* */
-    t.AddRule("Sequence")
-    t.AddName("Prefix")
-    t.AddName("Prefix")
-    t.AddAction(" p.AddSequence() ")
-    t.AddSequence()
-    t.AddStar()
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Prefix          <- And Action                   { p.AddPredicate(buffer[begin:end]) }
-       / And Suffix                   { p.AddPeekFor() }
-       / Not Suffix                   { p.AddPeekNot() }
-       /     Suffix */
-    t.AddRule("Prefix")
-    t.AddName("And")
-    t.AddName("Action")
-    t.AddSequence()
-    t.AddAction(" p.AddPredicate(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddName("And")
-    t.AddName("Suffix")
-    t.AddSequence()
-    t.AddAction(" p.AddPeekFor() ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("Not")
-    t.AddName("Suffix")
-    t.AddSequence()
-    t.AddAction(" p.AddPeekNot() ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("Suffix")
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Suffix          <- Primary (Question            { p.AddQuery() }
-       / Star             { p.AddStar() }
-       / Plus             { p.AddPlus() }
-     )? */
-    t.AddRule("Suffix")
-    t.AddName("Primary")
-    t.AddName("Question")
-    t.AddAction(" p.AddQuery() ")
-    t.AddSequence()
-    t.AddName("Star")
-    t.AddAction(" p.AddStar() ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("Plus")
-    t.AddAction(" p.AddPlus() ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddQuery()
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Primary         = Identifier { p.AddVariable(buffer[begin:end]) } Colon Identifier !EQUAL { p.AddName(buffer[begin:end]) }
-       / Identifier !Equal        { p.AddName(buffer[begin:end]) }
-       / Open Expression Close
-       / Literal
-       / Class
-       / Dot                          { p.AddDot() }
-       / Action                       { p.AddAction(buffer[begin:end]) }
-       / Begin Expression End         { p.AddPush() }*/
-    t.AddRule("Primary")
-    t.AddName("Identifier")
-    t.AddAction(" p.AddVariable(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddName("Colon")
-    t.AddSequence()
-    t.AddName("Identifier")
-    t.AddSequence()
-    t.AddName("Equal")
-    t.AddPeekNot()
-    t.AddSequence()
-    t.AddAction(" p.AddName(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddName("Identifier")
-    t.AddName("Equal")
-    t.AddPeekNot()
-    t.AddSequence()
-    t.AddAction(" p.AddName(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("Open")
-    t.AddName("Expression")
-    t.AddSequence()
-    t.AddName("Close")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("Literal")
-    t.AddAlternate()
-    t.AddName("Class")
-    t.AddAlternate()
-    t.AddName("Dot")
-    t.AddAction(" p.AddDot() ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("Action")
-    t.AddAction(" p.AddAction(buffer[begin:end]) ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("Begin")
-    t.AddName("Expression")
-    t.AddSequence()
-    t.AddName("End")
-    t.AddSequence()
-    t.AddAction(" p.AddPush() ")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Identifier  = < [-a-zA-Z_][-a-zA-Z_0-9]* > - */
-    t.AddRule("Identifier")
-
-    t.AddCharacter(`-`)
-    t.AddCharacter(`a`)
-    t.AddCharacter(`z`)
-    t.AddDoubleRange()
-    t.AddAlternate()
-    t.AddCharacter(`_`)
-    t.AddAlternate()
-    t.AddCharacter(`-`)
-    t.AddCharacter(`a`)
-    t.AddCharacter(`z`)
-    t.AddDoubleRange()
-    t.AddAlternate()
-    t.AddCharacter(`0`)
-    t.AddCharacter(`9`)
-    t.AddRange()
-    t.AddAlternate()
-    t.AddCharacter(`_`)
-    t.AddAlternate()
-    t.AddStar()
-    t.AddSequence()
-    t.AddPush()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Literal         <- ['] (!['] Char)? (!['] Char          { p.AddSequence() }
-                                           )* ['] -
-                         / ["] (!["] DoubleChar)? (!["] DoubleChar          { p.AddSequence() }
-                                                  )* ["] - */
-    t.AddRule("Literal")
-    t.AddCharacter(`'`)
-    t.AddCharacter(`'`)
-    t.AddPeekNot()
-    t.AddName("Char")
-    t.AddSequence()
-    t.AddQuery()
-    t.AddSequence()
-    t.AddCharacter(`'`)
-    t.AddPeekNot()
-    t.AddName("Char")
-    t.AddSequence()
-    t.AddAction(` p.AddSequence() `)
-    t.AddSequence()
-    t.AddStar()
-    t.AddSequence()
-    t.AddCharacter(`'`)
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddCharacter(`"`)
-    t.AddCharacter(`"`)
-    t.AddPeekNot()
-    t.AddName("DoubleChar")
-    t.AddSequence()
-    t.AddQuery()
-    t.AddSequence()
-    t.AddCharacter(`"`)
-    t.AddPeekNot()
-    t.AddName("DoubleChar")
-    t.AddSequence()
-    t.AddAction(` p.AddSequence() `)
-    t.AddSequence()
-    t.AddStar()
-    t.AddSequence()
-    t.AddCharacter(`"`)
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Class  <- ( '[[' ( '^' DoubleRanges              { p.AddPeekNot(); p.AddDot(); p.AddSequence() }
-                            / DoubleRanges )?
-                       ']]'
-                     / '[' ( '^' Ranges                     { p.AddPeekNot(); p.AddDot(); p.AddSequence() }
-                           / Ranges )?
-                       ']' )
-                     - */
-    t.AddRule("Class")
-    t.AddCharacter(`[`)
-    t.AddCharacter(`[`)
-    t.AddSequence()
-    t.AddCharacter(`^`)
-    t.AddName("DoubleRanges")
-    t.AddSequence()
-    t.AddAction(` p.AddPeekNot(); p.AddDot(); p.AddSequence() `)
-    t.AddSequence()
-    t.AddName("DoubleRanges")
-    t.AddAlternate()
-    t.AddQuery()
-    t.AddSequence()
-    t.AddCharacter(`]`)
-    t.AddCharacter(`]`)
-    t.AddSequence()
-    t.AddSequence()
-    t.AddCharacter(`[`)
-    t.AddCharacter(`^`)
-    t.AddName("Ranges")
-    t.AddSequence()
-    t.AddAction(` p.AddPeekNot(); p.AddDot(); p.AddSequence() `)
-    t.AddSequence()
-    t.AddName("Ranges")
-    t.AddAlternate()
-    t.AddQuery()
-    t.AddSequence()
-    t.AddCharacter(`]`)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Ranges          <- !']' Range (!']' Range  { p.AddAlternate() }
-                                     )* */
-    t.AddRule("Ranges")
-    t.AddCharacter(`]`)
-    t.AddPeekNot()
-    t.AddName("Range")
-    t.AddSequence()
-    t.AddCharacter(`]`)
-    t.AddPeekNot()
-    t.AddName("Range")
-    t.AddSequence()
-    t.AddAction(" p.AddAlternate() ")
-    t.AddSequence()
-    t.AddStar()
-    t.AddSequence()
-    t.AddExpression()
-
-    /* DoubleRanges          <- !']]' DoubleRange (!']]' DoubleRange  { p.AddAlternate() }
-                                                  )* */
-    t.AddRule("DoubleRanges")
-    t.AddCharacter(`]`)
-    t.AddCharacter(`]`)
-    t.AddSequence()
-    t.AddPeekNot()
-    t.AddName("DoubleRange")
-    t.AddSequence()
-    t.AddCharacter(`]`)
-    t.AddCharacter(`]`)
-    t.AddSequence()
-    t.AddPeekNot()
-    t.AddName("DoubleRange")
-    t.AddSequence()
-    t.AddAction(" p.AddAlternate() ")
-    t.AddSequence()
-    t.AddStar()
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Range           <- Char '-' Char { p.AddRange() }
-                            / Char */
-    t.AddRule("Range")
-    t.AddName("Char")
-    t.AddCharacter(`-`)
-    t.AddSequence()
-    t.AddName("Char")
-    t.AddSequence()
-    t.AddAction(" p.AddRange() ")
-    t.AddSequence()
-    t.AddName("Char")
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* DoubleRange      <- Char '-' Char { p.AddDoubleRange() }
-                             / DoubleChar */
-    t.AddRule("DoubleRange")
-    t.AddName("Char")
-    t.AddCharacter(`-`)
-    t.AddSequence()
-    t.AddName("Char")
-    t.AddSequence()
-    t.AddAction(" p.AddDoubleRange() ")
-    t.AddSequence()
-    t.AddName("DoubleChar")
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Char            <- Escape
-                            / !'\\' <.>                  { p.AddCharacter(buffer[begin:end]) } */
-    t.AddRule("Char")
-    t.AddName("Escape")
-    t.AddCharacter("\\")
-    t.AddPeekNot()
-    t.AddDot()
-    t.AddPush()
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter(buffer[begin:end]) `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* DoubleChar      <- Escape
-                            / <[a-zA-Z]>                 { p.AddDoubleCharacter(buffer[begin:end]) }
-                            / !'\\' <.>                  { p.AddCharacter(buffer[begin:end]) } */
-    t.AddRule("DoubleChar")
-    t.AddName("Escape")
-    t.AddCharacter(`a`)
-    t.AddCharacter(`z`)
-    t.AddRange()
-    t.AddCharacter(`A`)
-    t.AddCharacter(`Z`)
-    t.AddRange()
-    t.AddAlternate()
-    t.AddPush()
-    t.AddAction(` p.AddDoubleCharacter(buffer[begin:end]) `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddPeekNot()
-    t.AddDot()
-    t.AddPush()
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter(buffer[begin:end]) `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Escape            <- "\\a"                      { p.AddCharacter("\a") }   # bell
-                          / "\\b"                      { p.AddCharacter("\b") }   # bs
-                              / "\\e"                      { p.AddCharacter("\x1B") } # esc
-                              / "\\f"                      { p.AddCharacter("\f") }   # ff
-                              / "\\n"                      { p.AddCharacter("\n") }   # nl
-                              / "\\r"                      { p.AddCharacter("\r") }   # cr
-                              / "\\t"                      { p.AddCharacter("\t") }   # ht
-                              / "\\v"                      { p.AddCharacter("\v") }   # vt
-                              / "\\'"                      { p.AddCharacter("'") }
-                              / '\\"'                      { p.AddCharacter("\"") }
-                              / '\\['                      { p.AddCharacter("[") }
-                              / '\\]'                      { p.AddCharacter("]") }
-                              / '\\-'                      { p.AddCharacter("-") }
-                              / '\\' <[0-3][0-7][0-7]>     { p.AddOctalCharacter(buffer[begin:end]) }
-                              / '\\' <[0-7][0-7]?>         { p.AddOctalCharacter(buffer[begin:end]) }
-                              / '\\\\'                     { p.AddCharacter("\\") } */
-    t.AddRule("Escape")
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`a`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\a") `)
-    t.AddSequence()
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`b`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\b") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`e`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\x1B") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`f`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\f") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`n`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\n") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`r`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\r") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`t`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\t") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddDoubleCharacter(`v`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\v") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter(`'`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("'") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter(`"`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\"") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter(`[`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("[") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter(`]`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("]") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter(`-`)
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("-") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter(`0`)
-    t.AddCharacter(`3`)
-    t.AddRange()
-    t.AddCharacter(`0`)
-    t.AddCharacter(`7`)
-    t.AddRange()
-    t.AddSequence()
-    t.AddCharacter(`0`)
-    t.AddCharacter(`7`)
-    t.AddRange()
-    t.AddSequence()
-    t.AddPush()
-    t.AddSequence()
-    t.AddAction(` p.AddOctalCharacter(buffer[begin:end]) `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter(`0`)
-    t.AddCharacter(`7`)
-    t.AddRange()
-    t.AddCharacter(`0`)
-    t.AddCharacter(`7`)
-    t.AddRange()
-    t.AddQuery()
-    t.AddSequence()
-    t.AddPush()
-    t.AddSequence()
-    t.AddAction(` p.AddOctalCharacter(buffer[begin:end]) `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddCharacter("\\")
-    t.AddCharacter("\\")
-    t.AddSequence()
-    t.AddAction(` p.AddCharacter("\\") `)
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Action          <- '{' < Braces* > '}' - */
-    t.AddRule("Action")
-    t.AddCharacter(`{`)
-    t.AddName("Braces")
-    t.AddStar()
-    t.AddPush()
-    t.AddSequence()
-    t.AddCharacter(`}`)
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Braces <-        '{' Braces* '}' /   !'}' . */
-    t.AddRule("Braces")
-    t.AddCharacter(`{`)
-    t.AddName("Braces")
-    t.AddStar()
-    t.AddSequence()
-    t.AddCharacter(`}`)
-    t.AddSequence()
-    t.AddCharacter(`}`)
-    t.AddPeekNot()
-    t.AddDot()
-    t.AddSequence()
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* Equal       <- '=' - */
-    t.AddRule("Equal")
-    t.AddCharacter(`=`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Colon      <- ':' - */
-    t.AddRule("Colon")
-    t.AddCharacter(`:`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Bar           <- '|' - */
-    t.AddRule("Bar")
-    t.AddCharacter(`|`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* And             <- '&' - */
-    t.AddRule("And")
-    t.AddCharacter(`&`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Not             <- '!' - */
-    t.AddRule("Not")
-    t.AddCharacter(`!`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Question        <- '?' - */
-    t.AddRule("Question")
-    t.AddCharacter(`?`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Star            <- '*' - */
-    t.AddRule("Star")
-    t.AddCharacter(`*`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Plus            <- '+' - */
-    t.AddRule("Plus")
-    t.AddCharacter(`+`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Open            <- '(' - */
-    t.AddRule("Open")
-    t.AddCharacter(`(`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Close           <- ')' - */
-    t.AddRule("Close")
-    t.AddCharacter(`)`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Dot             <- '.' - */
-    t.AddRule("Dot")
-    t.AddCharacter(`.`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* RPERCENT =      '%}' - */
-    t.AddRule("RPERCENT")
-    t.AddCharacter("%")
-    t.AddCharacter("}")
-    t.AddSequence()
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* -         <- (Space / Comment)* */
-    t.AddRule("-")
-    t.AddName("Space")
-    t.AddName("Comment")
-    t.AddAlternate()
-    t.AddStar()
-    t.AddExpression()
-
-    /* Comment         <- '#' (!EndOfLine .)* EndOfLine */
-    t.AddRule("Comment")
-    t.AddCharacter(`#`)
-    t.AddName("EndOfLine")
-    t.AddPeekNot()
-    t.AddDot()
-    t.AddSequence()
-    t.AddStar()
-    t.AddSequence()
-    t.AddName("EndOfLine")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* Space           <- ' ' / '\t' / EndOfLine */
-    t.AddRule("Space")
-    t.AddCharacter(` `)
-    t.AddCharacter("\t")
-    t.AddAlternate()
-    t.AddName("EndOfLine")
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* EndOfLine       <- '\r\n' / '\n' / '\r' */
-    t.AddRule("EndOfLine")
-    t.AddCharacter("\r")
-    t.AddCharacter("\n")
-    t.AddSequence()
-    t.AddCharacter("\n")
-    t.AddAlternate()
-    t.AddCharacter("\r")
-    t.AddAlternate()
-    t.AddExpression()
-
-    /* EndOfFile       <- !. */
-    t.AddRule("EndOfFile")
-    t.AddDot()
-    t.AddPeekNot()
-    t.AddExpression()
-
-    /* Begin           <- '<' - */
-    t.AddRule("Begin")
-    t.AddCharacter(`<`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    /* End             <- '>' - */
-    t.AddRule("End")
-    t.AddCharacter(`>`)
-    t.AddName("-")
-    t.AddSequence()
-    t.AddExpression()
-
-    t.Compile("bootstrap.leg.go")
+	t.AddYYSType("int")
+
+	t.AddHighlight("comment")
+	t.AddHighlightRule("Comment")
+	t.AddHighlight("string")
+	t.AddHighlightRule("Literal")
+	t.AddHighlightRule("Class")
+	t.AddHighlight("action")
+	t.AddHighlightRule("Action")
+	t.AddHighlight("identifier")
+	t.AddHighlightRule("Identifier")
+	t.AddHighlightRule("Call")
+	t.AddHighlight("operator")
+	t.AddHighlightRule("Equal")
+	t.AddHighlightRule("Colon")
+	t.AddHighlightRule("Bar")
+	t.AddHighlightRule("And")
+	t.AddHighlightRule("Not")
+	t.AddHighlightRule("Question")
+	t.AddHighlightRule("Star")
+	t.AddHighlightRule("Plus")
+	t.AddHighlightRule("Repeat")
+	t.AddHighlightRule("Open")
+	t.AddHighlightRule("Close")
+	t.AddHighlightRule("Comma")
+	t.AddHighlightRule("Dot")
+	t.AddHighlightRule("Begin")
+	t.AddHighlightRule("End")
+	t.AddHighlight("space")
+	t.AddHighlightRule("_")
+
+	/* Grammar <- (_ ('p' 'a' 'c' 'k' 'a' 'g' 'e' _ Identifier { p.AddPackage(buffer[begin:end]) } ('Y' 'Y' 'S' 'T' 'Y' 'P' 'E') _ Identifier { p.AddYYSType(buffer[begin:end]) } ('t' 'y' 'p' 'e') _ Identifier { p.AddLeg(buffer[begin:end]) } ('P' 'e' 'g') _ Action { p.AddState(buffer[begin:end]) })? (Import / Declaration / Highlight / Definition)+ Trailer? EndOfFile) */
+	t.AtPosition("leg.leg", 31, 1)
+	t.AddRule("Grammar")
+	t.AddName("_")
+	t.AddCharacter(`p`)
+	t.AddCharacter(`a`)
+	t.AddSequence()
+	t.AddCharacter(`c`)
+	t.AddSequence()
+	t.AddCharacter(`k`)
+	t.AddSequence()
+	t.AddCharacter(`a`)
+	t.AddSequence()
+	t.AddCharacter(`g`)
+	t.AddSequence()
+	t.AddCharacter(`e`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 31, 50)
+	t.AddAction(` p.AddPackage(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddCharacter(`Y`)
+	t.AddCharacter(`Y`)
+	t.AddSequence()
+	t.AddCharacter(`S`)
+	t.AddSequence()
+	t.AddCharacter(`T`)
+	t.AddSequence()
+	t.AddCharacter(`Y`)
+	t.AddSequence()
+	t.AddCharacter(`P`)
+	t.AddSequence()
+	t.AddCharacter(`E`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 32, 54)
+	t.AddAction(` p.AddYYSType(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddCharacter(`t`)
+	t.AddCharacter(`y`)
+	t.AddSequence()
+	t.AddCharacter(`p`)
+	t.AddSequence()
+	t.AddCharacter(`e`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 33, 61)
+	t.AddAction(` p.AddLeg(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddCharacter(`P`)
+	t.AddCharacter(`e`)
+	t.AddSequence()
+	t.AddCharacter(`g`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddName("Action")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 34, 61)
+	t.AddAction(` p.AddState(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddName("Import")
+	t.AddName("Declaration")
+	t.AddAlternate()
+	t.AddName("Highlight")
+	t.AddAlternate()
+	t.AddName("Definition")
+	t.AddAlternate()
+	t.AddPlus()
+	t.AddSequence()
+	t.AddName("Trailer")
+	t.AddQuery()
+	t.AddSequence()
+	t.AddName("EndOfFile")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Import <- ('%' 'i' 'm' 'p' 'o' 'r' 't' _ (Identifier { p.AddImportPrefix(buffer[begin:end]) })? '"' <(!'"' .)+> '"' _ { p.AddImport(buffer[begin:end]) }) */
+	t.AtPosition("leg.leg", 37, 1)
+	t.AddRule("Import")
+	t.AddCharacter(`%`)
+	t.AddCharacter(`i`)
+	t.AddSequence()
+	t.AddCharacter(`m`)
+	t.AddSequence()
+	t.AddCharacter(`p`)
+	t.AddSequence()
+	t.AddCharacter(`o`)
+	t.AddSequence()
+	t.AddCharacter(`r`)
+	t.AddSequence()
+	t.AddCharacter(`t`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AtPosition("leg.leg", 37, 52)
+	t.AddAction(` p.AddImportPrefix(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddSequence()
+	t.AddPlus()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 38, 57)
+	t.AddAction(` p.AddImport(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Declaration <- ('%' '{' <(!('%' '}') .)*> RPERCENT {  p.At(begin); p.AddDeclaration(buffer[begin:end])  }) */
+	t.AtPosition("leg.leg", 40, 1)
+	t.AddRule("Declaration")
+	t.AddCharacter(`%`)
+	t.AddCharacter(`{`)
+	t.AddSequence()
+	t.AddCharacter(`%`)
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddSequence()
+	t.AddStar()
+	t.AddPush()
+	t.AddSequence()
+	t.AddName("RPERCENT")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 40, 48)
+	t.AddAction(`  p.At(begin); p.AddDeclaration(buffer[begin:end])  `)
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Trailer <- ('%' '%' <.*> { p.At(begin); p.AddTrailer(buffer[begin:end]) }) */
+	t.AtPosition("leg.leg", 41, 1)
+	t.AddRule("Trailer")
+	t.AddCharacter(`%`)
+	t.AddCharacter(`%`)
+	t.AddSequence()
+	t.AddDot()
+	t.AddStar()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 41, 30)
+	t.AddAction(` p.At(begin); p.AddTrailer(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Highlight <- ('%' 'h' 'i' 'g' 'h' 'l' 'i' 'g' 'h' 't' _ Identifier { p.AddHighlight(buffer[begin:end]) } (Identifier !Equal { p.At(begin); p.AddHighlightRule(buffer[begin:end]) })+) */
+	t.AtPosition("leg.leg", 42, 1)
+	t.AddRule("Highlight")
+	t.AddCharacter(`%`)
+	t.AddCharacter(`h`)
+	t.AddSequence()
+	t.AddCharacter(`i`)
+	t.AddSequence()
+	t.AddCharacter(`g`)
+	t.AddSequence()
+	t.AddCharacter(`h`)
+	t.AddSequence()
+	t.AddCharacter(`l`)
+	t.AddSequence()
+	t.AddCharacter(`i`)
+	t.AddSequence()
+	t.AddCharacter(`g`)
+	t.AddSequence()
+	t.AddCharacter(`h`)
+	t.AddSequence()
+	t.AddCharacter(`t`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 42, 52)
+	t.AddAction(` p.AddHighlight(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddName("Equal")
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 43, 52)
+	t.AddAction(` p.At(begin); p.AddHighlightRule(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddPlus()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Definition <- (((Call { p.At(begin); p.AddRule(buffer[begin:end]) } Parameter (Comma Parameter)* Close) / (Identifier { p.At(begin); p.AddRule(buffer[begin:end]) })) Equal Expression { p.AddExpression() }) */
+	t.AtPosition("leg.leg", 46, 1)
+	t.AddRule("Definition")
+	t.AddName("Call")
+	t.AtPosition("leg.leg", 46, 35)
+	t.AddAction(` p.At(begin); p.AddRule(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Parameter")
+	t.AddSequence()
+	t.AddName("Comma")
+	t.AddName("Parameter")
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddName("Close")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AtPosition("leg.leg", 48, 35)
+	t.AddAction(` p.At(begin); p.AddRule(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Equal")
+	t.AddSequence()
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 49, 37)
+	t.AddAction(` p.AddExpression() `)
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Parameter <- (Identifier { p.AddParameter(buffer[begin:end]) }) */
+	t.AtPosition("leg.leg", 50, 1)
+	t.AddRule("Parameter")
+	t.AddName("Identifier")
+	t.AtPosition("leg.leg", 50, 31)
+	t.AddAction(` p.AddParameter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Expression <- ((Sequence (Bar Sequence { p.AddAlternate() })* (Bar { p.AddNil(); p.AddAlternate() })?) / { p.AddNil() }) */
+	t.AtPosition("leg.leg", 51, 1)
+	t.AddRule("Expression")
+	t.AddName("Sequence")
+	t.AddName("Bar")
+	t.AddName("Sequence")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 51, 39)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddName("Bar")
+	t.AtPosition("leg.leg", 52, 30)
+	t.AddAction(` p.AddNil(); p.AddAlternate() `)
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 54, 28)
+	t.AddAction(` p.AddNil() `)
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Sequence <- (Prefix (Prefix { p.AddSequence() })*) */
+	t.AtPosition("leg.leg", 55, 1)
+	t.AddRule("Sequence")
+	t.AddName("Prefix")
+	t.AddName("Prefix")
+	t.AtPosition("leg.leg", 55, 31)
+	t.AddAction(` p.AddSequence() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Prefix <- ((And Action { p.At(begin); p.AddPredicate(buffer[begin:end]) }) / (And Suffix { p.AddPeekFor() }) / (Not Suffix { p.AddPeekNot() }) / Suffix) */
+	t.AtPosition("leg.leg", 57, 1)
+	t.AddRule("Prefix")
+	t.AddName("And")
+	t.AddName("Action")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 57, 29)
+	t.AddAction(` p.At(begin); p.AddPredicate(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("And")
+	t.AddName("Suffix")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 58, 24)
+	t.AddAction(` p.AddPeekFor() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Not")
+	t.AddName("Suffix")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 59, 24)
+	t.AddAction(` p.AddPeekNot() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Suffix")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Suffix <- (Primary ((Question { p.AddQuery() }) / (Star { p.AddStar() }) / (Plus { p.AddPlus() }) / (Repeat { p.Span(begin-1, end+1); p.AddRepeat(buffer[begin:end]) }))?) */
+	t.AtPosition("leg.leg", 61, 1)
+	t.AddRule("Suffix")
+	t.AddName("Primary")
+	t.AddName("Question")
+	t.AtPosition("leg.leg", 61, 49)
+	t.AddAction(` p.AddQuery() `)
+	t.AddSequence()
+	t.AddName("Star")
+	t.AtPosition("leg.leg", 62, 50)
+	t.AddAction(` p.AddStar() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Plus")
+	t.AtPosition("leg.leg", 63, 50)
+	t.AddAction(` p.AddPlus() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Repeat")
+	t.AtPosition("leg.leg", 64, 50)
+	t.AddAction(` p.Span(begin-1, end+1); p.AddRepeat(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Primary <- ((Identifier { p.At(begin); p.AddVariable(buffer[begin:end]) } Colon Identifier !Equal { p.At(begin); p.AddName(buffer[begin:end]) }) / (Identifier { p.At(begin); p.AddVariable(buffer[begin:end]) } Colon Begin Expression End { p.AddPush(); p.AddLabel() }) / (Equal Identifier { p.At(begin); p.AddBackReference(buffer[begin:end]) }) / (Call { p.At(begin); p.AddCall(buffer[begin:end]) } Expression { p.AddArgument() } (Comma Expression { p.AddArgument() })* Close !Equal) / (!Call Identifier !Equal { p.At(begin); p.AddName(buffer[begin:end]) }) / (Open Expression Close) / Literal / Class / (Dot { p.AddDot() }) / (Action { p.At(begin); p.AddAction(buffer[begin:end]) }) / (Begin Expression End { p.AddPush() })) */
+	t.AtPosition("leg.leg", 66, 1)
+	t.AddRule("Primary")
+	t.AddName("Identifier")
+	t.AtPosition("leg.leg", 66, 45)
+	t.AddAction(` p.At(begin); p.AddVariable(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Colon")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AddName("Equal")
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 67, 46)
+	t.AddAction(` p.At(begin); p.AddName(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AtPosition("leg.leg", 68, 46)
+	t.AddAction(` p.At(begin); p.AddVariable(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Colon")
+	t.AddSequence()
+	t.AddName("Begin")
+	t.AddSequence()
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AddName("End")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 69, 50)
+	t.AddAction(` p.AddPush(); p.AddLabel() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Equal")
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 70, 46)
+	t.AddAction(` p.At(begin); p.AddBackReference(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Call")
+	t.AtPosition("leg.leg", 71, 46)
+	t.AddAction(` p.At(begin); p.AddCall(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 72, 46)
+	t.AddAction(` p.AddArgument() `)
+	t.AddSequence()
+	t.AddName("Comma")
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 73, 46)
+	t.AddAction(` p.AddArgument() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddName("Close")
+	t.AddSequence()
+	t.AddName("Equal")
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Call")
+	t.AddPeekNot()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AddName("Equal")
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 75, 46)
+	t.AddAction(` p.At(begin); p.AddName(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Open")
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AddName("Close")
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Literal")
+	t.AddAlternate()
+	t.AddName("Class")
+	t.AddAlternate()
+	t.AddName("Dot")
+	t.AtPosition("leg.leg", 79, 50)
+	t.AddAction(` p.AddDot() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Action")
+	t.AtPosition("leg.leg", 80, 50)
+	t.AddAction(` p.At(begin); p.AddAction(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Begin")
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AddName("End")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 81, 50)
+	t.AddAction(` p.AddPush() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Identifier <- (<(('-' / [a-z] / [A-Z] / '_') ('-' / [a-z] / [A-Z] / '_' / [0-9])*)> _) */
+	t.AtPosition("leg.leg", 85, 1)
+	t.AddRule("Identifier")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddCharacter(`-`)
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddPush()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Call <- (<(('-' / [a-z] / [A-Z] / '_') ('-' / [a-z] / [A-Z] / '_' / [0-9])*)> Open) */
+	t.AtPosition("leg.leg", 86, 1)
+	t.AddRule("Call")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddCharacter(`-`)
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddPush()
+	t.AddName("Open")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Literal <- (('\'' (!'\'' Char)? (!'\'' Char { p.AddSequence() })* '\'' _) / ('"' (!'"' DoubleChar)? (!'"' DoubleChar { p.AddSequence() })* '"' _)) */
+	t.AtPosition("leg.leg", 87, 1)
+	t.AddRule("Literal")
+	t.AddCharacter(`'`)
+	t.AddCharacter(`'`)
+	t.AddPeekNot()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`'`)
+	t.AddPeekNot()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 87, 57)
+	t.AddAction(` p.AddSequence() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`'`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddCharacter(`"`)
+	t.AddPeekNot()
+	t.AddName("DoubleChar")
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddPeekNot()
+	t.AddName("DoubleChar")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 89, 52)
+	t.AddAction(` p.AddSequence() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Class <- (<(('[' '[' (('^' DoubleRanges { p.AddPeekNot(); p.AddDot(); p.AddSequence() }) / DoubleRanges)? (']' ']')) / ('[' (('^' ClassSet { p.AddClassComplement() }) / ClassSet)? ']'))> { p.AddClassText(buffer[begin:end]) } _) */
+	t.AtPosition("leg.leg", 91, 1)
+	t.AddRule("Class")
+	t.AddCharacter(`[`)
+	t.AddCharacter(`[`)
+	t.AddSequence()
+	t.AddCharacter(`^`)
+	t.AddName("DoubleRanges")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 91, 53)
+	t.AddAction(` p.AddPeekNot(); p.AddDot(); p.AddSequence() `)
+	t.AddSequence()
+	t.AddName("DoubleRanges")
+	t.AddAlternate()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddCharacter(`[`)
+	t.AddCharacter(`^`)
+	t.AddName("ClassSet")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 94, 60)
+	t.AddAction(` p.AddClassComplement() `)
+	t.AddSequence()
+	t.AddName("ClassSet")
+	t.AddAlternate()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddPush()
+	t.AtPosition("leg.leg", 96, 42)
+	t.AddAction(` p.AddClassText(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* ClassSet <- (Ranges (('-' '-' Operands { p.AddClassDifference() }) / ('&' '&' Operands { p.AddClassIntersection() }))*) */
+	t.AtPosition("leg.leg", 98, 1)
+	t.AddRule("ClassSet")
+	t.AddName("Ranges")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 98, 39)
+	t.AddAction(` p.AddClassDifference() `)
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 99, 45)
+	t.AddAction(` p.AddClassIntersection() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Ranges <- (!']' Range (!']' !('-' '-') !('&' '&') Range { p.AddAlternate() })*) */
+	t.AtPosition("leg.leg", 101, 1)
+	t.AddRule("Ranges")
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddName("Range")
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddName("Range")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 101, 50)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Operands <- (!']' Operand (!']' !('-' '-') !('&' '&') Operand { p.AddAlternate() })*) */
+	t.AtPosition("leg.leg", 103, 1)
+	t.AddRule("Operands")
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddName("Operand")
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddName("Operand")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 103, 54)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Operand <- (('[' (('^' NestedSet { p.AddClassComplement() }) / NestedSet) ']') / Range) */
+	t.AtPosition("leg.leg", 105, 1)
+	t.AddRule("Operand")
+	t.AddCharacter(`[`)
+	t.AddCharacter(`^`)
+	t.AddName("NestedSet")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 105, 42)
+	t.AddAction(` p.AddClassComplement() `)
+	t.AddSequence()
+	t.AddName("NestedSet")
+	t.AddAlternate()
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddName("Range")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* NestedSet <- (Operands (('-' '-' Operands { p.AddClassDifference() }) / ('&' '&' Operands { p.AddClassIntersection() }))*) */
+	t.AtPosition("leg.leg", 108, 1)
+	t.AddRule("NestedSet")
+	t.AddName("Operands")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 108, 41)
+	t.AddAction(` p.AddClassDifference() `)
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 109, 47)
+	t.AddAction(` p.AddClassIntersection() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* DoubleRanges <- (!(']' ']') DoubleRange (!(']' ']') DoubleRange { p.AddAlternate() })*) */
+	t.AtPosition("leg.leg", 111, 1)
+	t.AddRule("DoubleRanges")
+	t.AddCharacter(`]`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddName("DoubleRange")
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddName("DoubleRange")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 111, 56)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Range <- (('\\' 'p' '{' <([a-z] / [A-Z] / '_')+> '}' { p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]) }) / ('\\' 'P' '{' <([a-z] / [A-Z] / '_')+> '}' { p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]); p.AddClassComplement() }) / (Char !('-' '-') '-' Char { p.AddRange() }) / Char) */
+	t.AtPosition("leg.leg", 113, 1)
+	t.AddRule("Range")
+	t.AddCharacter(`\`)
+	t.AddCharacter(`p`)
+	t.AddSequence()
+	t.AddCharacter(`{`)
+	t.AddSequence()
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddPlus()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 113, 39)
+	t.AddAction(` p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`P`)
+	t.AddSequence()
+	t.AddCharacter(`{`)
+	t.AddSequence()
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddPlus()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 114, 48)
+	t.AddAction(` p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]); p.AddClassComplement() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Char")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 115, 48)
+	t.AddAction(` p.AddRange() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Char")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* DoubleRange <- ((Char '-' Char { p.AddDoubleRange() }) / DoubleChar) */
+	t.AtPosition("leg.leg", 117, 1)
+	t.AddRule("DoubleRange")
+	t.AddName("Char")
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AtPosition("leg.leg", 117, 43)
+	t.AddAction(` p.AddDoubleRange() `)
+	t.AddSequence()
+	t.AddName("DoubleChar")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Char <- (Escape / (!'\\' <.> { p.At(begin); p.AddCharacter(buffer[begin:end]) })) */
+	t.AtPosition("leg.leg", 119, 1)
+	t.AddRule("Char")
+	t.AddName("Escape")
+	t.AddCharacter(`\`)
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 120, 48)
+	t.AddAction(` p.At(begin); p.AddCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* DoubleChar <- (Escape / (<([a-z] / [A-Z])> { p.At(begin); p.AddDoubleCharacter(buffer[begin:end]) }) / (!'\\' <.> { p.At(begin); p.AddCharacter(buffer[begin:end]) })) */
+	t.AtPosition("leg.leg", 121, 1)
+	t.AddRule("DoubleChar")
+	t.AddName("Escape")
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddPush()
+	t.AtPosition("leg.leg", 122, 36)
+	t.AddAction(` p.At(begin); p.AddDoubleCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 123, 48)
+	t.AddAction(` p.At(begin); p.AddCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Hex <- ([0-9] / [a-f] / [A-F]) */
+	t.AtPosition("leg.leg", 124, 1)
+	t.AddRule("Hex")
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddCharacter(`a`)
+	t.AddCharacter(`f`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`F`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Escape <- (('\\' ('a' / 'A') { p.AddCharacter("\a") }) / ('\\' ('b' / 'B') { p.AddCharacter("\b") }) / ('\\' ('e' / 'E') { p.AddCharacter("\x1B") }) / ('\\' ('f' / 'F') { p.AddCharacter("\f") }) / ('\\' ('n' / 'N') { p.AddCharacter("\n") }) / ('\\' ('r' / 'R') { p.AddCharacter("\r") }) / ('\\' ('t' / 'T') { p.AddCharacter("\t") }) / ('\\' ('v' / 'V') { p.AddCharacter("\v") }) / ('\\' '\'' { p.AddCharacter("'") }) / ('\\' '"' { p.AddCharacter("\"") }) / ('\\' '[' { p.AddCharacter("[") }) / ('\\' ']' { p.AddCharacter("]") }) / ('\\' '-' { p.AddCharacter("-") }) / ('\\' 'x' <(Hex Hex)> { p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' 'u' '{' <Hex+> '}' { p.Span(begin-3, end+1); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' 'u' <(Hex Hex Hex Hex)> { p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' 'U' <(Hex Hex Hex Hex Hex Hex Hex Hex)> { p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' <([0-3] [0-7] [0-7])> { p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }) / ('\\' <([0-7] [0-7]?)> { p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }) / ('\\' '\\' { p.AddCharacter("\\") }) / ('\\' <.> { p.Span(begin-1, end); p.AddInvalidEscape(buffer[begin:end]) })) */
+	t.AtPosition("leg.leg", 125, 1)
+	t.AddRule("Escape")
+	t.AddCharacter(`\`)
+	t.AddCharacter(`a`)
+	t.AddCharacter(`A`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 125, 47)
+	t.AddAction(` p.AddCharacter("\a") `)
+	t.AddSequence()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`b`)
+	t.AddCharacter(`B`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 126, 48)
+	t.AddAction(` p.AddCharacter("\b") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`e`)
+	t.AddCharacter(`E`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 127, 48)
+	t.AddAction(` p.AddCharacter("\x1B") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`f`)
+	t.AddCharacter(`F`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 128, 48)
+	t.AddAction(` p.AddCharacter("\f") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`n`)
+	t.AddCharacter(`N`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 129, 48)
+	t.AddAction(` p.AddCharacter("\n") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`r`)
+	t.AddCharacter(`R`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 130, 48)
+	t.AddAction(` p.AddCharacter("\r") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`t`)
+	t.AddCharacter(`T`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 131, 48)
+	t.AddAction(` p.AddCharacter("\t") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`v`)
+	t.AddCharacter(`V`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 132, 48)
+	t.AddAction(` p.AddCharacter("\v") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`'`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 133, 36)
+	t.AddAction(` p.AddCharacter("'") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`"`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 134, 36)
+	t.AddAction(` p.AddCharacter("\"") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`[`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 135, 48)
+	t.AddAction(` p.AddCharacter("[") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 136, 48)
+	t.AddAction(` p.AddCharacter("]") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 137, 48)
+	t.AddAction(` p.AddCharacter("-") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`x`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 138, 49)
+	t.AddAction(` p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`u`)
+	t.AddSequence()
+	t.AddCharacter(`{`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddPlus()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 139, 48)
+	t.AddAction(` p.Span(begin-3, end+1); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`u`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 140, 49)
+	t.AddAction(` p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`U`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 142, 48)
+	t.AddAction(` p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`0`)
+	t.AddCharacter(`3`)
+	t.AddRange()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddSequence()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 143, 48)
+	t.AddAction(` p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 144, 48)
+	t.AddAction(` p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`\`)
+	t.AddSequence()
+	t.AtPosition("leg.leg", 145, 48)
+	t.AddAction(` p.AddCharacter("\\") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddDot()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("leg.leg", 146, 47)
+	t.AddAction(` p.Span(begin-1, end); p.AddInvalidEscape(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Action <- ('{' <Braces*> '}' _) */
+	t.AtPosition("leg.leg", 147, 1)
+	t.AddRule("Action")
+	t.AddCharacter(`{`)
+	t.AddName("Braces")
+	t.AddStar()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Braces <- (('{' Braces* '}') / (!'}' .)) */
+	t.AtPosition("leg.leg", 148, 1)
+	t.AddRule("Braces")
+	t.AddCharacter(`{`)
+	t.AddName("Braces")
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Equal <- ('=' _) */
+	t.AtPosition("leg.leg", 149, 1)
+	t.AddRule("Equal")
+	t.AddCharacter(`=`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Colon <- (':' _) */
+	t.AtPosition("leg.leg", 150, 1)
+	t.AddRule("Colon")
+	t.AddCharacter(`:`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Bar <- ('|' _) */
+	t.AtPosition("leg.leg", 151, 1)
+	t.AddRule("Bar")
+	t.AddCharacter(`|`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* And <- ('&' _) */
+	t.AtPosition("leg.leg", 152, 1)
+	t.AddRule("And")
+	t.AddCharacter(`&`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Not <- ('!' _) */
+	t.AtPosition("leg.leg", 153, 1)
+	t.AddRule("Not")
+	t.AddCharacter(`!`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Question <- ('?' _) */
+	t.AtPosition("leg.leg", 154, 1)
+	t.AddRule("Question")
+	t.AddCharacter(`?`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Star <- ('*' _) */
+	t.AtPosition("leg.leg", 155, 1)
+	t.AddRule("Star")
+	t.AddCharacter(`*`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Plus <- ('+' _) */
+	t.AtPosition("leg.leg", 156, 1)
+	t.AddRule("Plus")
+	t.AddCharacter(`+`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Repeat <- ('{' <((' ' / '\t')* [0-9]+ (' ' / '\t')* (',' (' ' / '\t')* [0-9]* (' ' / '\t')*)?)> '}' _) */
+	t.AtPosition("leg.leg", 157, 1)
+	t.AddRule("Repeat")
+	t.AddCharacter(`{`)
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddPlus()
+	t.AddSequence()
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`,`)
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Open <- ('(' _) */
+	t.AtPosition("leg.leg", 158, 1)
+	t.AddRule("Open")
+	t.AddCharacter(`(`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Close <- (')' _) */
+	t.AtPosition("leg.leg", 159, 1)
+	t.AddRule("Close")
+	t.AddCharacter(`)`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Comma <- (',' _) */
+	t.AtPosition("leg.leg", 160, 1)
+	t.AddRule("Comma")
+	t.AddCharacter(`,`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Dot <- ('.' _) */
+	t.AtPosition("leg.leg", 161, 1)
+	t.AddRule("Dot")
+	t.AddCharacter(`.`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* RPERCENT <- ('%' '}' _) */
+	t.AtPosition("leg.leg", 162, 1)
+	t.AddRule("RPERCENT")
+	t.AddCharacter(`%`)
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* _ <- (Space / Comment)* */
+	t.AtPosition("leg.leg", 163, 2)
+	t.AddRule("_")
+	t.AddName("Space")
+	t.AddName("Comment")
+	t.AddAlternate()
+	t.AddStar()
+	t.AddExpression()
+
+	/* Comment <- ('#' (!EndOfLine .)* EndOfLine) */
+	t.AtPosition("leg.leg", 164, 1)
+	t.AddRule("Comment")
+	t.AddCharacter(`#`)
+	t.AddName("EndOfLine")
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddName("EndOfLine")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Space <- (' ' / '\t' / EndOfLine) */
+	t.AtPosition("leg.leg", 165, 1)
+	t.AddRule("Space")
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddName("EndOfLine")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* EndOfLine <- (('\r' '\n') / '\n' / '\r') */
+	t.AtPosition("leg.leg", 166, 1)
+	t.AddRule("EndOfLine")
+	t.AddCharacter("\r")
+	t.AddCharacter("\n")
+	t.AddSequence()
+	t.AddCharacter("\n")
+	t.AddAlternate()
+	t.AddCharacter("\r")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* EndOfFile <- !. */
+	t.AtPosition("leg.leg", 167, 1)
+	t.AddRule("EndOfFile")
+	t.AddDot()
+	t.AddPeekNot()
+	t.AddExpression()
+
+	/* Begin <- ('<' _) */
+	t.AtPosition("leg.leg", 168, 1)
+	t.AddRule("Begin")
+	t.AddCharacter(`<`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* End <- ('>' _) */
+	t.AtPosition("leg.leg", 169, 1)
+	t.AddRule("End")
+	t.AddCharacter(`>`)
+	t.AddName("_")
+	t.AddSequence()
+	t.AddExpression()
+
+	if err := t.Compile("bootstrap.leg.go"); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,1103 @@
+// Code generated by leg -emit-builder. DO NOT EDIT.
+
+package main
+
+import (
+	"log"
+	"runtime"
+)
+
+func main() {
+	runtime.GOMAXPROCS(2)
+	t := New(true, true)
+
+	t.AddPackage("main")
+	t.AddLeg("Peg")
+	t.AddState(`
+ *Tree
+`)
+
+	/* Grammar <- (Spacing ('p' 'a' 'c' 'k' 'a' 'g' 'e') Spacing Identifier { p.AddPackage(buffer[begin:end]) } ('t' 'y' 'p' 'e') Spacing Identifier { p.AddLeg(buffer[begin:end]) } ('P' 'e' 'g') Spacing Action { p.AddState(buffer[begin:end]) } Definition+ EndOfFile) */
+	t.AtPosition("peg.peg", 20, 1)
+	t.AddRule("Grammar")
+	t.AddName("Spacing")
+	t.AddCharacter(`p`)
+	t.AddCharacter(`a`)
+	t.AddSequence()
+	t.AddCharacter(`c`)
+	t.AddSequence()
+	t.AddCharacter(`k`)
+	t.AddSequence()
+	t.AddCharacter(`a`)
+	t.AddSequence()
+	t.AddCharacter(`g`)
+	t.AddSequence()
+	t.AddCharacter(`e`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 20, 63)
+	t.AddAction(` p.AddPackage(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddCharacter(`t`)
+	t.AddCharacter(`y`)
+	t.AddSequence()
+	t.AddCharacter(`p`)
+	t.AddSequence()
+	t.AddCharacter(`e`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 21, 63)
+	t.AddAction(` p.AddLeg(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddCharacter(`P`)
+	t.AddCharacter(`e`)
+	t.AddSequence()
+	t.AddCharacter(`g`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddName("Action")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 22, 63)
+	t.AddAction(` p.AddState(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Definition")
+	t.AddPlus()
+	t.AddSequence()
+	t.AddName("EndOfFile")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Definition <- (Identifier { p.At(begin); p.AddRule(buffer[begin:end]) } LeftArrow Expression { p.AddExpression() } &((Identifier LeftArrow) / !.)) */
+	t.AtPosition("peg.peg", 24, 1)
+	t.AddRule("Definition")
+	t.AddName("Identifier")
+	t.AtPosition("peg.peg", 24, 50)
+	t.AddAction(` p.At(begin); p.AddRule(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("LeftArrow")
+	t.AddSequence()
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 25, 50)
+	t.AddAction(` p.AddExpression() `)
+	t.AddSequence()
+	t.AddName("Identifier")
+	t.AddName("LeftArrow")
+	t.AddSequence()
+	t.AddDot()
+	t.AddPeekNot()
+	t.AddAlternate()
+	t.AddPeekFor()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Expression <- ((Sequence (Slash Sequence { p.AddAlternate() })* (Slash { p.AddNil(); p.AddAlternate() })?) / { p.AddNil() }) */
+	t.AtPosition("peg.peg", 26, 1)
+	t.AddRule("Expression")
+	t.AddName("Sequence")
+	t.AddName("Slash")
+	t.AddName("Sequence")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 26, 50)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddName("Slash")
+	t.AtPosition("peg.peg", 27, 50)
+	t.AddAction(` p.AddNil(); p.AddAlternate() `)
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 29, 50)
+	t.AddAction(` p.AddNil() `)
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Sequence <- (Prefix (Prefix { p.AddSequence() })*) */
+	t.AtPosition("peg.peg", 30, 1)
+	t.AddRule("Sequence")
+	t.AddName("Prefix")
+	t.AddName("Prefix")
+	t.AtPosition("peg.peg", 30, 50)
+	t.AddAction(` p.AddSequence() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Prefix <- ((And Action { p.At(begin); p.AddPredicate(buffer[begin:end]) }) / (And Suffix { p.AddPeekFor() }) / (Not Suffix { p.AddPeekNot() }) / Suffix) */
+	t.AtPosition("peg.peg", 32, 1)
+	t.AddRule("Prefix")
+	t.AddName("And")
+	t.AddName("Action")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 32, 50)
+	t.AddAction(` p.At(begin); p.AddPredicate(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("And")
+	t.AddName("Suffix")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 33, 50)
+	t.AddAction(` p.AddPeekFor() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Not")
+	t.AddName("Suffix")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 34, 50)
+	t.AddAction(` p.AddPeekNot() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Suffix")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Suffix <- (Primary ((Question { p.AddQuery() }) / (Star { p.AddStar() }) / (Plus { p.AddPlus() }) / (Repeat { p.Span(begin-1, end+1); p.AddRepeat(buffer[begin:end]) }))?) */
+	t.AtPosition("peg.peg", 36, 1)
+	t.AddRule("Suffix")
+	t.AddName("Primary")
+	t.AddName("Question")
+	t.AtPosition("peg.peg", 36, 50)
+	t.AddAction(` p.AddQuery() `)
+	t.AddSequence()
+	t.AddName("Star")
+	t.AtPosition("peg.peg", 37, 50)
+	t.AddAction(` p.AddStar() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Plus")
+	t.AtPosition("peg.peg", 38, 50)
+	t.AddAction(` p.AddPlus() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Repeat")
+	t.AtPosition("peg.peg", 39, 50)
+	t.AddAction(` p.Span(begin-1, end+1); p.AddRepeat(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Primary <- ((Identifier !LeftArrow { p.At(begin); p.AddName(buffer[begin:end]) }) / (Open Expression Close) / Literal / Class / (Dot { p.AddDot() }) / (Action { p.At(begin); p.AddAction(buffer[begin:end]) }) / (Begin Expression End { p.AddPush() })) */
+	t.AtPosition("peg.peg", 41, 1)
+	t.AddRule("Primary")
+	t.AddName("Identifier")
+	t.AddName("LeftArrow")
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 41, 50)
+	t.AddAction(` p.At(begin); p.AddName(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Open")
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AddName("Close")
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Literal")
+	t.AddAlternate()
+	t.AddName("Class")
+	t.AddAlternate()
+	t.AddName("Dot")
+	t.AtPosition("peg.peg", 45, 50)
+	t.AddAction(` p.AddDot() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Action")
+	t.AtPosition("peg.peg", 46, 50)
+	t.AddAction(` p.At(begin); p.AddAction(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Begin")
+	t.AddName("Expression")
+	t.AddSequence()
+	t.AddName("End")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 47, 50)
+	t.AddAction(` p.AddPush() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Identifier <- (<(IdentStart IdentCont*)> Spacing) */
+	t.AtPosition("peg.peg", 50, 1)
+	t.AddRule("Identifier")
+	t.AddName("IdentStart")
+	t.AddName("IdentCont")
+	t.AddStar()
+	t.AddSequence()
+	t.AddPush()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* IdentStart <- ([a-z] / [A-Z] / '_') */
+	t.AtPosition("peg.peg", 51, 1)
+	t.AddRule("IdentStart")
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* IdentCont <- (IdentStart / [0-9]) */
+	t.AtPosition("peg.peg", 52, 1)
+	t.AddRule("IdentCont")
+	t.AddName("IdentStart")
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Literal <- (('\'' (!'\'' Char)? (!'\'' Char { p.AddSequence() })* '\'' Spacing) / ('"' (!'"' DoubleChar)? (!'"' DoubleChar { p.AddSequence() })* '"' Spacing)) */
+	t.AtPosition("peg.peg", 53, 1)
+	t.AddRule("Literal")
+	t.AddCharacter(`'`)
+	t.AddCharacter(`'`)
+	t.AddPeekNot()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`'`)
+	t.AddPeekNot()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 53, 64)
+	t.AddAction(` p.AddSequence() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`'`)
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddCharacter(`"`)
+	t.AddPeekNot()
+	t.AddName("DoubleChar")
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddPeekNot()
+	t.AddName("DoubleChar")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 55, 64)
+	t.AddAction(` p.AddSequence() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`"`)
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Class <- (<(('[' '[' ((('^' / '~') DoubleRanges { p.AddPeekNot(); p.AddDot(); p.AddSequence() }) / DoubleRanges)? (']' ']')) / ('[' ((('^' / '~') ClassSet { p.AddClassComplement() }) / ClassSet)? ']'))> { p.AddClassText(buffer[begin:end]) } Spacing) */
+	t.AtPosition("peg.peg", 57, 1)
+	t.AddRule("Class")
+	t.AddCharacter(`[`)
+	t.AddCharacter(`[`)
+	t.AddSequence()
+	t.AddCharacter(`^`)
+	t.AddCharacter(`~`)
+	t.AddAlternate()
+	t.AddName("DoubleRanges")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 57, 65)
+	t.AddAction(` p.AddPeekNot(); p.AddDot(); p.AddSequence() `)
+	t.AddSequence()
+	t.AddName("DoubleRanges")
+	t.AddAlternate()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddSequence()
+	t.AddCharacter(`[`)
+	t.AddCharacter(`^`)
+	t.AddCharacter(`~`)
+	t.AddAlternate()
+	t.AddName("ClassSet")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 60, 63)
+	t.AddAction(` p.AddClassComplement() `)
+	t.AddSequence()
+	t.AddName("ClassSet")
+	t.AddAlternate()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddPush()
+	t.AtPosition("peg.peg", 62, 42)
+	t.AddAction(` p.AddClassText(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* ClassSet <- (Ranges (('-' '-' Operands { p.AddClassDifference() }) / ('&' '&' Operands { p.AddClassIntersection() }))*) */
+	t.AtPosition("peg.peg", 64, 1)
+	t.AddRule("ClassSet")
+	t.AddName("Ranges")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 64, 46)
+	t.AddAction(` p.AddClassDifference() `)
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 65, 45)
+	t.AddAction(` p.AddClassIntersection() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Ranges <- (!']' Range (!']' !('-' '-') !('&' '&') Range { p.AddAlternate() })*) */
+	t.AtPosition("peg.peg", 67, 1)
+	t.AddRule("Ranges")
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddName("Range")
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddName("Range")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 67, 57)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Operands <- (!']' Operand (!']' !('-' '-') !('&' '&') Operand { p.AddAlternate() })*) */
+	t.AtPosition("peg.peg", 69, 1)
+	t.AddRule("Operands")
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddName("Operand")
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddPeekNot()
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddName("Operand")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 69, 61)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Operand <- (('[' ((('^' / '~') NestedSet { p.AddClassComplement() }) / NestedSet) ']') / Range) */
+	t.AtPosition("peg.peg", 71, 1)
+	t.AddRule("Operand")
+	t.AddCharacter(`[`)
+	t.AddCharacter(`^`)
+	t.AddCharacter(`~`)
+	t.AddAlternate()
+	t.AddName("NestedSet")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 71, 57)
+	t.AddAction(` p.AddClassComplement() `)
+	t.AddSequence()
+	t.AddName("NestedSet")
+	t.AddAlternate()
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddName("Range")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* NestedSet <- (Operands (('-' '-' Operands { p.AddClassDifference() }) / ('&' '&' Operands { p.AddClassIntersection() }))*) */
+	t.AtPosition("peg.peg", 74, 1)
+	t.AddRule("NestedSet")
+	t.AddName("Operands")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 74, 48)
+	t.AddAction(` p.AddClassDifference() `)
+	t.AddSequence()
+	t.AddCharacter(`&`)
+	t.AddCharacter(`&`)
+	t.AddSequence()
+	t.AddName("Operands")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 75, 47)
+	t.AddAction(` p.AddClassIntersection() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* DoubleRanges <- (!(']' ']') DoubleRange (!(']' ']') DoubleRange { p.AddAlternate() })*) */
+	t.AtPosition("peg.peg", 77, 1)
+	t.AddRule("DoubleRanges")
+	t.AddCharacter(`]`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddName("DoubleRange")
+	t.AddSequence()
+	t.AddCharacter(`]`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddName("DoubleRange")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 77, 59)
+	t.AddAction(` p.AddAlternate() `)
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Range <- (('\\' 'p' '{' <([a-z] / [A-Z] / '_')+> '}' { p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]) }) / ('\\' 'P' '{' <([a-z] / [A-Z] / '_')+> '}' { p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]); p.AddClassComplement() }) / (Char !('-' '-') '-' Char { p.AddRange() }) / Char) */
+	t.AtPosition("peg.peg", 79, 1)
+	t.AddRule("Range")
+	t.AddCharacter(`\`)
+	t.AddCharacter(`p`)
+	t.AddSequence()
+	t.AddCharacter(`{`)
+	t.AddSequence()
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddPlus()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 79, 48)
+	t.AddAction(` p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`P`)
+	t.AddSequence()
+	t.AddCharacter(`{`)
+	t.AddSequence()
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`_`)
+	t.AddAlternate()
+	t.AddPlus()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 80, 48)
+	t.AddAction(` p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]); p.AddClassComplement() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Char")
+	t.AddCharacter(`-`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddPeekNot()
+	t.AddSequence()
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 81, 48)
+	t.AddAction(` p.AddRange() `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddName("Char")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* DoubleRange <- ((Char '-' Char { p.AddDoubleRange() }) / DoubleChar) */
+	t.AtPosition("peg.peg", 83, 1)
+	t.AddRule("DoubleRange")
+	t.AddName("Char")
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Char")
+	t.AddSequence()
+	t.AtPosition("peg.peg", 83, 48)
+	t.AddAction(` p.AddDoubleRange() `)
+	t.AddSequence()
+	t.AddName("DoubleChar")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Char <- (Escape / (!'\\' <.> { p.At(begin); p.AddCharacter(buffer[begin:end]) })) */
+	t.AtPosition("peg.peg", 85, 1)
+	t.AddRule("Char")
+	t.AddName("Escape")
+	t.AddCharacter(`\`)
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 86, 48)
+	t.AddAction(` p.At(begin); p.AddCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* DoubleChar <- (Escape / (<([a-z] / [A-Z])> { p.At(begin); p.AddDoubleCharacter(buffer[begin:end]) }) / (!'\\' <.> { p.At(begin); p.AddCharacter(buffer[begin:end]) })) */
+	t.AtPosition("peg.peg", 87, 1)
+	t.AddRule("DoubleChar")
+	t.AddName("Escape")
+	t.AddCharacter(`a`)
+	t.AddCharacter(`z`)
+	t.AddRange()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`Z`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddPush()
+	t.AtPosition("peg.peg", 88, 48)
+	t.AddAction(` p.At(begin); p.AddDoubleCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 89, 48)
+	t.AddAction(` p.At(begin); p.AddCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Hex <- ([0-9] / [a-f] / [A-F]) */
+	t.AtPosition("peg.peg", 90, 1)
+	t.AddRule("Hex")
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddCharacter(`a`)
+	t.AddCharacter(`f`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddCharacter(`A`)
+	t.AddCharacter(`F`)
+	t.AddRange()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Escape <- (('\\' ('a' / 'A') { p.AddCharacter("\a") }) / ('\\' ('b' / 'B') { p.AddCharacter("\b") }) / ('\\' ('e' / 'E') { p.AddCharacter("\x1B") }) / ('\\' ('f' / 'F') { p.AddCharacter("\f") }) / ('\\' ('n' / 'N') { p.AddCharacter("\n") }) / ('\\' ('r' / 'R') { p.AddCharacter("\r") }) / ('\\' ('t' / 'T') { p.AddCharacter("\t") }) / ('\\' ('v' / 'V') { p.AddCharacter("\v") }) / ('\\' '\'' { p.AddCharacter("'") }) / ('\\' '"' { p.AddCharacter("\"") }) / ('\\' '[' { p.AddCharacter("[") }) / ('\\' ']' { p.AddCharacter("]") }) / ('\\' '-' { p.AddCharacter("-") }) / ('\\' 'x' <(Hex Hex)> { p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' 'u' '{' <Hex+> '}' { p.Span(begin-3, end+1); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' 'u' <(Hex Hex Hex Hex)> { p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' 'U' <(Hex Hex Hex Hex Hex Hex Hex Hex)> { p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }) / ('\\' <([0-3] [0-7] [0-7])> { p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }) / ('\\' <([0-7] [0-7]?)> { p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }) / ('\\' '\\' { p.AddCharacter("\\") }) / ('\\' <.> { p.Span(begin-1, end); p.AddInvalidEscape(buffer[begin:end]) })) */
+	t.AtPosition("peg.peg", 91, 1)
+	t.AddRule("Escape")
+	t.AddCharacter(`\`)
+	t.AddCharacter(`a`)
+	t.AddCharacter(`A`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 91, 48)
+	t.AddAction(` p.AddCharacter("\a") `)
+	t.AddSequence()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`b`)
+	t.AddCharacter(`B`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 92, 48)
+	t.AddAction(` p.AddCharacter("\b") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`e`)
+	t.AddCharacter(`E`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 93, 48)
+	t.AddAction(` p.AddCharacter("\x1B") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`f`)
+	t.AddCharacter(`F`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 94, 48)
+	t.AddAction(` p.AddCharacter("\f") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`n`)
+	t.AddCharacter(`N`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 95, 48)
+	t.AddAction(` p.AddCharacter("\n") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`r`)
+	t.AddCharacter(`R`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 96, 48)
+	t.AddAction(` p.AddCharacter("\r") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`t`)
+	t.AddCharacter(`T`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 97, 48)
+	t.AddAction(` p.AddCharacter("\t") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`v`)
+	t.AddCharacter(`V`)
+	t.AddAlternate()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 98, 48)
+	t.AddAction(` p.AddCharacter("\v") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`'`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 99, 48)
+	t.AddAction(` p.AddCharacter("'") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`"`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 100, 48)
+	t.AddAction(` p.AddCharacter("\"") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`[`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 101, 48)
+	t.AddAction(` p.AddCharacter("[") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`]`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 102, 48)
+	t.AddAction(` p.AddCharacter("]") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 103, 48)
+	t.AddAction(` p.AddCharacter("-") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`x`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 104, 49)
+	t.AddAction(` p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`u`)
+	t.AddSequence()
+	t.AddCharacter(`{`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddPlus()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 105, 48)
+	t.AddAction(` p.Span(begin-3, end+1); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`u`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 106, 49)
+	t.AddAction(` p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`U`)
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddName("Hex")
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 108, 48)
+	t.AddAction(` p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`0`)
+	t.AddCharacter(`3`)
+	t.AddRange()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddSequence()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 109, 48)
+	t.AddAction(` p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`7`)
+	t.AddRange()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 110, 48)
+	t.AddAction(` p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddCharacter(`\`)
+	t.AddSequence()
+	t.AtPosition("peg.peg", 111, 48)
+	t.AddAction(` p.AddCharacter("\\") `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddCharacter(`\`)
+	t.AddDot()
+	t.AddPush()
+	t.AddSequence()
+	t.AtPosition("peg.peg", 112, 47)
+	t.AddAction(` p.Span(begin-1, end); p.AddInvalidEscape(buffer[begin:end]) `)
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* LeftArrow <- ('<' '-' Spacing) */
+	t.AtPosition("peg.peg", 113, 1)
+	t.AddRule("LeftArrow")
+	t.AddCharacter(`<`)
+	t.AddCharacter(`-`)
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Slash <- ('/' Spacing) */
+	t.AtPosition("peg.peg", 114, 1)
+	t.AddRule("Slash")
+	t.AddCharacter(`/`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* And <- ('&' Spacing) */
+	t.AtPosition("peg.peg", 115, 1)
+	t.AddRule("And")
+	t.AddCharacter(`&`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Not <- ('!' Spacing) */
+	t.AtPosition("peg.peg", 116, 1)
+	t.AddRule("Not")
+	t.AddCharacter(`!`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Question <- ('?' Spacing) */
+	t.AtPosition("peg.peg", 117, 1)
+	t.AddRule("Question")
+	t.AddCharacter(`?`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Star <- ('*' Spacing) */
+	t.AtPosition("peg.peg", 118, 1)
+	t.AddRule("Star")
+	t.AddCharacter(`*`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Plus <- ('+' Spacing) */
+	t.AtPosition("peg.peg", 119, 1)
+	t.AddRule("Plus")
+	t.AddCharacter(`+`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Repeat <- ('{' <((' ' / '\t')* [0-9]+ (' ' / '\t')* (',' (' ' / '\t')* [0-9]* (' ' / '\t')*)?)> '}' Spacing) */
+	t.AtPosition("peg.peg", 120, 1)
+	t.AddRule("Repeat")
+	t.AddCharacter(`{`)
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddPlus()
+	t.AddSequence()
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`,`)
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`0`)
+	t.AddCharacter(`9`)
+	t.AddRange()
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddStar()
+	t.AddSequence()
+	t.AddQuery()
+	t.AddSequence()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Open <- ('(' Spacing) */
+	t.AtPosition("peg.peg", 121, 1)
+	t.AddRule("Open")
+	t.AddCharacter(`(`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Close <- (')' Spacing) */
+	t.AtPosition("peg.peg", 122, 1)
+	t.AddRule("Close")
+	t.AddCharacter(`)`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Dot <- ('.' Spacing) */
+	t.AtPosition("peg.peg", 123, 1)
+	t.AddRule("Dot")
+	t.AddCharacter(`.`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Spacing <- (Space / Comment)* */
+	t.AtPosition("peg.peg", 124, 1)
+	t.AddRule("Spacing")
+	t.AddName("Space")
+	t.AddName("Comment")
+	t.AddAlternate()
+	t.AddStar()
+	t.AddExpression()
+
+	/* Comment <- ('#' (!EndOfLine .)* EndOfLine) */
+	t.AtPosition("peg.peg", 125, 1)
+	t.AddRule("Comment")
+	t.AddCharacter(`#`)
+	t.AddName("EndOfLine")
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddSequence()
+	t.AddStar()
+	t.AddSequence()
+	t.AddName("EndOfLine")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Space <- (' ' / '\t' / EndOfLine) */
+	t.AtPosition("peg.peg", 126, 1)
+	t.AddRule("Space")
+	t.AddCharacter(` `)
+	t.AddCharacter(`	`)
+	t.AddAlternate()
+	t.AddName("EndOfLine")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* EndOfLine <- (('\r' '\n') / '\n' / '\r') */
+	t.AtPosition("peg.peg", 127, 1)
+	t.AddRule("EndOfLine")
+	t.AddCharacter("\r")
+	t.AddCharacter("\n")
+	t.AddSequence()
+	t.AddCharacter("\n")
+	t.AddAlternate()
+	t.AddCharacter("\r")
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* EndOfFile <- !. */
+	t.AtPosition("peg.peg", 128, 1)
+	t.AddRule("EndOfFile")
+	t.AddDot()
+	t.AddPeekNot()
+	t.AddExpression()
+
+	/* Action <- ('{' <Braces*> '}' Spacing) */
+	t.AtPosition("peg.peg", 129, 1)
+	t.AddRule("Action")
+	t.AddCharacter(`{`)
+	t.AddName("Braces")
+	t.AddStar()
+	t.AddPush()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* Braces <- (('{' Braces* '}') / (!'}' .)) */
+	t.AtPosition("peg.peg", 130, 1)
+	t.AddRule("Braces")
+	t.AddCharacter(`{`)
+	t.AddName("Braces")
+	t.AddStar()
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddSequence()
+	t.AddCharacter(`}`)
+	t.AddPeekNot()
+	t.AddDot()
+	t.AddSequence()
+	t.AddAlternate()
+	t.AddExpression()
+
+	/* Begin <- ('<' Spacing) */
+	t.AtPosition("peg.peg", 131, 1)
+	t.AddRule("Begin")
+	t.AddCharacter(`<`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	/* End <- ('>' Spacing) */
+	t.AtPosition("peg.peg", 132, 1)
+	t.AddRule("End")
+	t.AddCharacter(`>`)
+	t.AddName("Spacing")
+	t.AddSequence()
+	t.AddExpression()
+
+	if err := t.Compile("bootstrap.peg.go"); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1 @@
+../../leg/leg.go
\ No newline at end of file
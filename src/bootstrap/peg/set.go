@@ -0,0 +1 @@
+../../set.go
\ No newline at end of file
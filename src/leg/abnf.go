@@ -0,0 +1,849 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type abnfType uint8
+
+const (
+	abnfAlternation abnfType = iota
+	abnfConcatenation
+	abnfRepetition
+	abnfRule
+	abnfString
+	abnfValues
+	abnfRange
+	abnfProse
+)
+
+/* A node of an ABNF rule. Repetitions have a min and max, -1 meaning unbounded. */
+type abnfNode struct {
+	abnfType
+	children    []*abnfNode
+	min, max    int
+	text        string
+	insensitive bool
+	values      []rune
+	line        int
+}
+
+/* The ABNF core rules of RFC 5234 appendix B.1, added when a grammar uses them. */
+const abnfCoreRules = `ALPHA = %x41-5A / %x61-7A
+BIT = "0" / "1"
+CHAR = %x01-7F
+CR = %x0D
+CRLF = CR LF
+CTL = %x00-1F / %x7F
+DIGIT = %x30-39
+DQUOTE = %x22
+HEXDIG = DIGIT / "A" / "B" / "C" / "D" / "E" / "F"
+HTAB = %x09
+LF = %x0A
+LWSP = *(WSP / CRLF WSP)
+OCTET = %x00-FF
+SP = %x20
+VCHAR = %x21-7E
+WSP = SP / HTAB
+`
+
+/* A recursive descent parser for RFC 5234 rule lists, with the %s and %i strings of RFC 7405. */
+type abnfParser struct {
+	file     string
+	buffer   []rune
+	position int
+	line     int
+}
+
+func (p *abnfParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("%v:%v: %v", p.file, p.line, fmt.Sprintf(format, a...))
+}
+
+func (p *abnfParser) peek(offset int) rune {
+	if p.position+offset < len(p.buffer) {
+		return p.buffer[p.position+offset]
+	}
+	return 0
+}
+
+func (p *abnfParser) eof() bool {
+	return p.position >= len(p.buffer)
+}
+
+/* The length of the line break at the current position, if there is one. */
+func (p *abnfParser) newline() int {
+	if p.peek(0) == '\n' {
+		return 1
+	} else if p.peek(0) == '\r' && p.peek(1) == '\n' {
+		return 2
+	}
+	return 0
+}
+
+/* Skip c-wsp: white space, comments, and line breaks followed by white space. */
+func (p *abnfParser) space() {
+	for !p.eof() {
+		switch c := p.peek(0); {
+		case c == ' ' || c == '\t':
+			p.position++
+		case c == ';':
+			for !p.eof() && p.newline() == 0 {
+				p.position++
+			}
+		case p.newline() > 0:
+			n := p.newline()
+			if next := p.peek(n); next != ' ' && next != '\t' {
+				return
+			}
+			p.position += n
+			p.line++
+		default:
+			return
+		}
+	}
+}
+
+/* Skip blank and comment only lines between rules. */
+func (p *abnfParser) blank() {
+	for !p.eof() {
+		start := p.position
+		for p.peek(0) == ' ' || p.peek(0) == '\t' {
+			p.position++
+		}
+		if p.peek(0) == ';' {
+			for !p.eof() && p.newline() == 0 {
+				p.position++
+			}
+		}
+		if n := p.newline(); n > 0 {
+			p.position += n
+			p.line++
+		} else if !p.eof() {
+			p.position = start
+			return
+		}
+	}
+}
+
+func isAlpha(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (p *abnfParser) rulename() string {
+	start := p.position
+	if !isAlpha(p.peek(0)) {
+		return ""
+	}
+	for isAlpha(p.peek(0)) || isDigit(p.peek(0)) || p.peek(0) == '-' {
+		p.position++
+	}
+	return string(p.buffer[start:p.position])
+}
+
+func (p *abnfParser) number() (int, bool) {
+	start := p.position
+	for isDigit(p.peek(0)) {
+		p.position++
+	}
+	if start == p.position {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(string(p.buffer[start:p.position]))
+	return n, true
+}
+
+/* Parse the rule list, calling define for every rule or incremental alternative. */
+func (p *abnfParser) rulelist(define func(name string, incremental bool, n *abnfNode) error) error {
+	for p.blank(); !p.eof(); p.blank() {
+		line := p.line
+		name := p.rulename()
+		if name == "" {
+			return p.errorf("expected a rule name")
+		}
+		p.space()
+		if p.peek(0) != '=' {
+			return p.errorf("expected '=' after rule name '%v'", name)
+		}
+		p.position++
+		incremental := p.peek(0) == '/'
+		if incremental {
+			p.position++
+		}
+		p.space()
+		n, err := p.alternation()
+		if err != nil {
+			return err
+		}
+		p.space()
+		if !p.eof() && p.newline() == 0 {
+			return p.errorf("unexpected '%c' in rule '%v'", p.peek(0), name)
+		}
+		n.line = line
+		if err := define(name, incremental, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *abnfParser) alternation() (*abnfNode, error) {
+	n := &abnfNode{abnfType: abnfAlternation, line: p.line}
+	for {
+		c, err := p.concatenation()
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, c)
+		start, line := p.position, p.line
+		p.space()
+		if p.peek(0) != '/' {
+			p.position, p.line = start, line
+			return n, nil
+		}
+		p.position++
+		p.space()
+	}
+}
+
+func (p *abnfParser) concatenation() (*abnfNode, error) {
+	n := &abnfNode{abnfType: abnfConcatenation, line: p.line}
+	for {
+		r, err := p.repetition()
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, r)
+		start, line := p.position, p.line
+		p.space()
+		if c := p.peek(0); p.position == start || !(isAlpha(c) || isDigit(c) || strings.ContainsRune("*([\"%<", c)) {
+			p.position, p.line = start, line
+			return n, nil
+		}
+	}
+}
+
+func (p *abnfParser) repetition() (*abnfNode, error) {
+	min, hasMin := p.number()
+	max := min
+	if p.peek(0) == '*' {
+		p.position++
+		if !hasMin {
+			min = 0
+		}
+		if m, ok := p.number(); ok {
+			max = m
+		} else {
+			max = -1
+		}
+		if max != -1 && max < min {
+			return nil, p.errorf("repetition %v*%v has a maximum below its minimum", min, max)
+		}
+	} else if !hasMin {
+		return p.element()
+	}
+	e, err := p.element()
+	if err != nil {
+		return nil, err
+	}
+	return &abnfNode{abnfType: abnfRepetition, children: []*abnfNode{e}, min: min, max: max, line: p.line}, nil
+}
+
+func (p *abnfParser) element() (*abnfNode, error) {
+	line := p.line
+	switch c := p.peek(0); {
+	case isAlpha(c):
+		return &abnfNode{abnfType: abnfRule, text: p.rulename(), line: line}, nil
+	case c == '(' || c == '[':
+		p.position++
+		p.space()
+		n, err := p.alternation()
+		if err != nil {
+			return nil, err
+		}
+		p.space()
+		end := ')'
+		if c == '[' {
+			end = ']'
+		}
+		if p.peek(0) != end {
+			return nil, p.errorf("expected '%c'", end)
+		}
+		p.position++
+		if c == '[' {
+			n = &abnfNode{abnfType: abnfRepetition, children: []*abnfNode{n}, min: 0, max: 1, line: line}
+		}
+		return n, nil
+	case c == '"':
+		return p.string(true)
+	case c == '%':
+		p.position++
+		switch base := unicode.ToLower(p.peek(0)); base {
+		case 's', 'i':
+			p.position++
+			if p.peek(0) != '"' {
+				return nil, p.errorf("expected '\"' after %%%c", base)
+			}
+			return p.string(base == 'i')
+		case 'b', 'd', 'x':
+			p.position++
+			return p.values(map[rune]int{'b': 2, 'd': 10, 'x': 16}[base])
+		}
+		return nil, p.errorf("expected one of b, d, x, s or i after '%%'")
+	case c == '<':
+		start := p.position + 1
+		for p.peek(0) != '>' {
+			if p.eof() || p.newline() > 0 {
+				return nil, p.errorf("unterminated prose value")
+			}
+			p.position++
+		}
+		p.position++
+		return &abnfNode{abnfType: abnfProse, text: string(p.buffer[start : p.position-1]), line: line}, nil
+	}
+	if p.eof() {
+		return nil, p.errorf("unexpected end of file")
+	}
+	return nil, p.errorf("unexpected '%c'", p.peek(0))
+}
+
+func (p *abnfParser) string(insensitive bool) (*abnfNode, error) {
+	p.position++
+	start := p.position
+	for p.peek(0) != '"' {
+		if c := p.peek(0); p.eof() || c < 0x20 || c > 0x7E {
+			return nil, p.errorf("unterminated string")
+		}
+		p.position++
+	}
+	p.position++
+	return &abnfNode{abnfType: abnfString, text: string(p.buffer[start : p.position-1]), insensitive: insensitive, line: p.line}, nil
+}
+
+func (p *abnfParser) value(base int) (rune, error) {
+	start := p.position
+	for d := strings.IndexRune("0123456789abcdef", unicode.ToLower(p.peek(0))); d >= 0 && d < base; d = strings.IndexRune("0123456789abcdef", unicode.ToLower(p.peek(0))) {
+		p.position++
+	}
+	v, err := strconv.ParseUint(string(p.buffer[start:p.position]), base, 32)
+	if err != nil || v > unicode.MaxRune {
+		return 0, p.errorf("invalid value '%v'", string(p.buffer[start:p.position]))
+	}
+	return rune(v), nil
+}
+
+func (p *abnfParser) values(base int) (*abnfNode, error) {
+	v, err := p.value(base)
+	if err != nil {
+		return nil, err
+	}
+	n := &abnfNode{abnfType: abnfValues, values: []rune{v}, line: p.line}
+	if p.peek(0) == '-' {
+		p.position++
+		upper, err := p.value(base)
+		if err != nil {
+			return nil, err
+		}
+		if upper < v {
+			return nil, p.errorf("value range %X-%X is empty", v, upper)
+		}
+		n.abnfType, n.values = abnfRange, append(n.values, upper)
+		return n, nil
+	}
+	for p.peek(0) == '.' {
+		p.position++
+		v, err := p.value(base)
+		if err != nil {
+			return nil, err
+		}
+		n.values = append(n.values, v)
+	}
+	return n, nil
+}
+
+/* Converts parsed ABNF rules into leg source. */
+type abnfConverter struct {
+	rules    map[string]*abnfNode
+	names    map[string]string
+	order    []string
+	used     map[string]bool
+	first    map[string]*abnfFirst
+	warnings []string
+	file     string
+}
+
+/* The characters a node can begin with, and whether it can match the empty string. */
+type abnfFirst struct {
+	ranges   ranges
+	nullable bool
+}
+
+func (c *abnfConverter) warn(line int, format string, a ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf("%v:%v: %v", c.file, line, fmt.Sprintf(format, a...)))
+}
+
+/* ABNF rule names are case insensitive; every reference takes the case of the definition. */
+func (c *abnfConverter) define(name string, incremental bool, n *abnfNode) error {
+	key := strings.ToLower(name)
+	if rule, ok := c.rules[key]; ok {
+		if !incremental {
+			c.warn(n.line, "rule '%v' redefined, use =/ to add alternatives", name)
+			return nil
+		}
+		rule.children = append(rule.children, n.children...)
+		return nil
+	}
+	if incremental {
+		c.warn(n.line, "incremental alternatives for undefined rule '%v'", name)
+	}
+	c.rules[key], c.names[key] = n, name
+	c.order = append(c.order, key)
+	return nil
+}
+
+/* Mark the rules used from n, adding the core rules on demand. */
+func (c *abnfConverter) use(n *abnfNode, core map[string]*abnfNode) {
+	if n.abnfType == abnfRule {
+		key := strings.ToLower(n.text)
+		if c.used[key] {
+			return
+		}
+		c.used[key] = true
+		if _, ok := c.rules[key]; !ok {
+			if rule, ok := core[key]; ok {
+				c.rules[key], c.names[key] = rule, strings.ToUpper(key)
+				c.order = append(c.order, key)
+			} else {
+				c.warn(n.line, "rule '%v' used but not defined", n.text)
+				return
+			}
+		}
+		c.use(c.rules[key], core)
+		return
+	}
+	for _, child := range n.children {
+		c.use(child, core)
+	}
+}
+
+func (c *abnfConverter) name(reference string) string {
+	if name, ok := c.names[strings.ToLower(reference)]; ok {
+		return name
+	}
+	return reference
+}
+
+/* Escape a character for a leg literal or, when quote is empty, a leg class. */
+func escapeLeg(c rune, quote string) string {
+	switch c {
+	case '\a':
+		return "\\a"
+	case '\b':
+		return "\\b"
+	case '\x1B':
+		return "\\e"
+	case '\f':
+		return "\\f"
+	case '\n':
+		return "\\n"
+	case '\r':
+		return "\\r"
+	case '\t':
+		return "\\t"
+	case '\v':
+		return "\\v"
+	case '\\':
+		return "\\\\"
+	case '[', ']', '-', '^':
+		if quote == "" {
+			return fmt.Sprintf("\\x%02X", c)
+		}
+	}
+	if string(c) == quote {
+		return "\\" + quote
+	}
+	switch {
+	case c < 0x20 || c >= 0x7F && c <= 0xFF:
+		return fmt.Sprintf("\\x%02X", c)
+	case c > 0xFFFF:
+		return fmt.Sprintf("\\U%08X", c)
+	case c > 0xFF:
+		return fmt.Sprintf("\\u%04X", c)
+	}
+	return string(c)
+}
+
+func hasLetters(s string) bool {
+	for _, c := range s {
+		if unicode.ToLower(c) != unicode.ToUpper(c) {
+			return true
+		}
+	}
+	return false
+}
+
+/* The ranges of an alternation of single characters, which leg writes as one class. */
+func (c *abnfConverter) class(n *abnfNode) (ranges, bool) {
+	switch n.abnfType {
+	case abnfAlternation, abnfConcatenation:
+		if n.abnfType == abnfConcatenation && len(n.children) != 1 {
+			return nil, false
+		}
+		var r ranges
+		for _, child := range n.children {
+			s, ok := c.class(child)
+			if !ok {
+				return nil, false
+			}
+			r = append(r, s...)
+		}
+		return r, len(r) > 0
+	case abnfRange:
+		return ranges{{n.values[0], n.values[1]}}, true
+	case abnfValues:
+		if len(n.values) == 1 {
+			return ranges{{n.values[0], n.values[0]}}, true
+		}
+	case abnfString:
+		if runes := []rune(n.text); len(runes) == 1 && n.insensitive && hasLetters(n.text) {
+			lower, upper := unicode.ToLower(runes[0]), unicode.ToUpper(runes[0])
+			return ranges{{lower, lower}, {upper, upper}}, true
+		} else if len(runes) == 1 {
+			return ranges{{runes[0], runes[0]}}, true
+		}
+	}
+	return nil, false
+}
+
+func (c *abnfConverter) expression(n *abnfNode) (string, int) {
+	if r, ok := c.class(n); ok && len(r) > 1 || n.abnfType == abnfRange {
+		sort.Sort(r)
+		s := "["
+		for _, p := range r {
+			s += escapeLeg(p[0], "")
+			if p[1] != p[0] {
+				s += "-" + escapeLeg(p[1], "")
+			}
+		}
+		return s + "]", precedencePrimary
+	}
+	switch n.abnfType {
+	case abnfAlternation:
+		if len(n.children) == 1 {
+			return c.expression(n.children[0])
+		}
+		var alternatives []string
+		for _, child := range n.children {
+			s, _ := c.expression(child)
+			alternatives = append(alternatives, s)
+		}
+		return strings.Join(alternatives, " | "), precedenceAlternate
+	case abnfConcatenation:
+		if len(n.children) == 1 {
+			return c.expression(n.children[0])
+		}
+		var elements []string
+		for _, child := range n.children {
+			s, p := c.expression(child)
+			if p < precedenceSequence {
+				s = "(" + s + ")"
+			}
+			elements = append(elements, s)
+		}
+		return strings.Join(elements, " "), precedenceSequence
+	case abnfRepetition:
+		return c.repetition(n)
+	case abnfRule:
+		return c.name(n.text), precedencePrimary
+	case abnfString:
+		if n.text == "" {
+			return "()", precedencePrimary
+		}
+		quote := "'"
+		if n.insensitive && hasLetters(n.text) {
+			quote = "\""
+		}
+		s := quote
+		for _, r := range n.text {
+			s += escapeLeg(r, quote)
+		}
+		return s + quote, precedencePrimary
+	case abnfValues:
+		s := "'"
+		for _, r := range n.values {
+			s += escapeLeg(r, "'")
+		}
+		return s + "'", precedencePrimary
+	case abnfProse:
+		c.warn(n.line, "prose value <%v> cannot be converted and never matches", n.text)
+		return "&{ false } # <" + n.text + ">\n", precedencePrimary
+	}
+	return "", precedencePrimary
+}
+
+/* Repetition becomes ?, * or +, or a count in braces. */
+func (c *abnfConverter) repetition(n *abnfNode) (string, int) {
+	if n.max == 0 {
+		return "()", precedencePrimary
+	}
+	element, p := c.expression(n.children[0])
+	if p < precedencePrimary {
+		element = "(" + element + ")"
+	}
+	switch {
+	case n.max == -1 && n.min == 0:
+		return element + "*", precedenceSuffix
+	case n.max == -1 && n.min == 1:
+		return element + "+", precedenceSuffix
+	case n.max == -1:
+		return fmt.Sprintf("%v{%v,}", element, n.min), precedenceSuffix
+	case n.min == 0 && n.max == 1:
+		return element + "?", precedenceSuffix
+	case n.min == n.max:
+		return fmt.Sprintf("%v{%v}", element, n.min), precedenceSuffix
+	}
+	return fmt.Sprintf("%v{%v,%v}", element, n.min, n.max), precedenceSuffix
+}
+
+func (c *abnfConverter) firstOf(n *abnfNode) abnfFirst {
+	switch n.abnfType {
+	case abnfAlternation:
+		var f abnfFirst
+		for _, child := range n.children {
+			s := c.firstOf(child)
+			f.ranges, f.nullable = append(f.ranges, s.ranges...), f.nullable || s.nullable
+		}
+		return f
+	case abnfConcatenation:
+		f := abnfFirst{nullable: true}
+		for _, child := range n.children {
+			s := c.firstOf(child)
+			f.ranges = append(f.ranges, s.ranges...)
+			if !s.nullable {
+				f.nullable = false
+				break
+			}
+		}
+		return f
+	case abnfRepetition:
+		f := c.firstOf(n.children[0])
+		f.nullable = f.nullable || n.min == 0
+		return f
+	case abnfRule:
+		key := strings.ToLower(n.text)
+		if f, ok := c.first[key]; ok {
+			if f == nil {
+				/* left recursion, which leg reports on its own */
+				return abnfFirst{}
+			}
+			return *f
+		}
+		rule, ok := c.rules[key]
+		if !ok {
+			return abnfFirst{}
+		}
+		c.first[key] = nil
+		f := c.firstOf(rule)
+		c.first[key] = &f
+		return f
+	case abnfString:
+		if n.text == "" {
+			return abnfFirst{nullable: true}
+		}
+		r := rune(n.text[0])
+		if n.insensitive {
+			return abnfFirst{ranges: ranges{{unicode.ToLower(r), unicode.ToLower(r)}, {unicode.ToUpper(r), unicode.ToUpper(r)}}}
+		}
+		return abnfFirst{ranges: ranges{{r, r}}}
+	case abnfValues:
+		return abnfFirst{ranges: ranges{{n.values[0], n.values[0]}}}
+	case abnfRange:
+		return abnfFirst{ranges: ranges{{n.values[0], n.values[1]}}}
+	}
+	return abnfFirst{}
+}
+
+/* The first character two sets of ranges have in common. */
+func (r ranges) overlap(s ranges) (rune, bool) {
+	found, overlap := rune(0), false
+	for _, a := range r {
+		for _, b := range s {
+			low, high := a[0], a[1]
+			if b[0] > low {
+				low = b[0]
+			}
+			if b[1] < high {
+				high = b[1]
+			}
+			if low <= high && (!overlap || low < found) {
+				found, overlap = low, true
+			}
+		}
+	}
+	return found, overlap
+}
+
+/* The text of a node which only matches a fixed string. */
+func (c *abnfConverter) fixed(n *abnfNode) (text string, insensitive bool, ok bool) {
+	switch n.abnfType {
+	case abnfAlternation:
+		if len(n.children) != 1 {
+			return "", false, false
+		}
+		return c.fixed(n.children[0])
+	case abnfConcatenation:
+		for _, child := range n.children {
+			s, in, ok := c.fixed(child)
+			if !ok {
+				return "", false, false
+			}
+			text, insensitive = text+s, insensitive || in && hasLetters(s)
+		}
+		return text, insensitive, true
+	case abnfString:
+		return n.text, n.insensitive, true
+	case abnfValues:
+		return string(n.values), false, true
+	}
+	return "", false, false
+}
+
+/* Warn where ordered choice differs from ABNF alternation, as when an earlier alternative matches a prefix of a later one. */
+func (c *abnfConverter) ambiguities(name string, n *abnfNode) {
+	for _, child := range n.children {
+		c.ambiguities(name, child)
+	}
+	if n.abnfType != abnfAlternation || len(n.children) < 2 {
+		return
+	}
+	firsts := make([]abnfFirst, len(n.children))
+	for i, child := range n.children {
+		firsts[i] = c.firstOf(child)
+	}
+	for i, a := range n.children {
+		if firsts[i].nullable && i < len(n.children)-1 {
+			c.warn(a.line, "rule '%v': alternative %v can match the empty string, so the alternatives after it are never tried", name, i+1)
+			continue
+		}
+		for j := i + 1; j < len(n.children); j++ {
+			b := n.children[j]
+			if s, si, ok := c.fixed(a); ok {
+				if t, ti, ok := c.fixed(b); ok && len(s) <= len(t) && (s == t[:len(s)] || (si || ti) && strings.EqualFold(s, t[:len(s)])) {
+					c.warn(a.line, "rule '%v': alternative %v matches a prefix of alternative %v, which ordered choice never reaches; put the longer one first", name, i+1, j+1)
+					continue
+				}
+			}
+			if r, ok := firsts[i].ranges.overlap(firsts[j].ranges); ok {
+				c.warn(a.line, "rule '%v': alternatives %v and %v can both begin with %q, ordered choice commits to the first that matches", name, i+1, j+1, r)
+			}
+		}
+	}
+}
+
+/* Convert an ABNF rule list into a leg grammar, returning warnings for what does not carry over. */
+func ImportABNF(w io.Writer, source []byte, file, packageName, typeName string) ([]string, error) {
+	c := &abnfConverter{rules: make(map[string]*abnfNode), names: make(map[string]string), used: make(map[string]bool),
+		first: make(map[string]*abnfFirst), file: file}
+	p := &abnfParser{file: file, buffer: []rune(string(source)), line: 1}
+	if err := p.rulelist(c.define); err != nil {
+		return nil, err
+	}
+	if len(c.order) == 0 {
+		return nil, fmt.Errorf("%v: no rules", file)
+	}
+
+	core := make(map[string]*abnfNode)
+	corep := &abnfParser{file: "core", buffer: []rune(abnfCoreRules), line: 1}
+	corep.rulelist(func(name string, incremental bool, n *abnfNode) error {
+		core[strings.ToLower(name)] = n
+		return nil
+	})
+	defined := len(c.order)
+	for _, key := range c.order[:defined] {
+		c.use(&abnfNode{abnfType: abnfRule, text: key}, core)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# Converted from %v by leg import.\n\npackage %v\n\nYYSTYPE int\n\ntype %v Peg {\n}\n", filepath.Base(file), packageName, typeName)
+	for i, key := range c.order {
+		if i == defined {
+			out.WriteString("\n# Core rules of RFC 5234 appendix B.1\n")
+		}
+		rule := c.rules[key]
+		if i < defined {
+			c.ambiguities(c.names[key], rule)
+		}
+		body, _ := c.expression(rule)
+		fmt.Fprintf(&out, "\n%v = %v\n", c.names[key], strings.TrimRight(body, "\n"))
+	}
+	_, err := w.Write(out.Bytes())
+	return c.warnings, err
+}
+
+func importGrammar(arguments []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	from := flags.String("from", "abnf", "the notation of the grammar: abnf")
+	packageName := flags.String("package", "main", "the package of the generated parser")
+	typeName := flags.String("type", "", "the type of the generated parser, by default named after the file")
+	output := flags.String("o", "", "write the grammar to this file instead of stdout")
+	flags.Parse(arguments)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		log.Fatalf("FILE: the grammar to import")
+	}
+	if *from != "abnf" {
+		log.Fatalf("unknown notation %v, use abnf", *from)
+	}
+
+	file := flags.Arg(0)
+	source, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *typeName == "" {
+		for _, c := range strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)) {
+			if isAlpha(c) || isDigit(c) && *typeName != "" {
+				*typeName += string(c)
+			}
+		}
+		if *typeName == "" {
+			*typeName = "Grammar"
+		}
+		*typeName = strings.ToUpper((*typeName)[:1]) + (*typeName)[1:]
+	}
+
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	warnings, err := ImportABNF(out, source, file, *packageName, *typeName)
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
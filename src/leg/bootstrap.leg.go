@@ -1,40 +1,53 @@
+// Code generated by leg. DO NOT EDIT.
+
 package main
 
 import (
-	/*"bytes"*/
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"html"
+	"io"
 	"math"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 const END_SYMBOL rune = 4
 
-type YYSTYPE int
-
 /* The rule types inferred from the grammar are below. */
 type Rule uint8
 
 const (
 	RuleUnknown Rule = iota
 	RuleGrammar
+	RuleImport
 	RuleDeclaration
 	RuleTrailer
+	RuleHighlight
 	RuleDefinition
+	RuleParameter
 	RuleExpression
 	RuleSequence
 	RulePrefix
 	RuleSuffix
 	RulePrimary
 	RuleIdentifier
+	RuleCall
 	RuleLiteral
 	RuleClass
+	RuleClassSet
 	RuleRanges
+	RuleOperands
+	RuleOperand
+	RuleNestedSet
 	RuleDoubleRanges
 	RuleRange
 	RuleDoubleRange
 	RuleChar
 	RuleDoubleChar
+	RuleHex
 	RuleEscape
 	RuleAction
 	RuleBraces
@@ -46,8 +59,10 @@ const (
 	RuleQuestion
 	RuleStar
 	RulePlus
+	RuleRepeat
 	RuleOpen
 	RuleClose
+	RuleComma
 	RuleDot
 	RuleRPERCENT
 	Rule_
@@ -61,8 +76,8 @@ const (
 	RuleAction1
 	RuleAction2
 	RuleAction3
-	RulePegText
 	RuleAction4
+	RulePegText
 	RuleAction5
 	RuleAction6
 	RuleAction7
@@ -109,9 +124,37 @@ const (
 	RuleAction48
 	RuleAction49
 	RuleAction50
+	RuleAction51
+	RuleAction52
+	RuleAction53
+	RuleAction54
+	RuleAction55
+	RuleAction56
+	RuleAction57
+	RuleAction58
+	RuleAction59
+	RuleAction60
+	RuleAction61
+	RuleAction62
+	RuleAction63
+	RuleAction64
+	RuleAction65
+	RuleAction66
+	RuleAction67
+	RuleAction68
+	RuleAction69
+	RuleAction70
+	RuleAction71
+	RuleAction72
+	RuleAction73
+	RuleAction74
+	RuleAction75
+	RuleAction76
+	RuleAction77
 
 	RuleActionPush
 	RuleActionPop
+	RuleActionSet
 	RulePre_
 	Rule_In_
 	Rule_Suf
@@ -120,23 +163,32 @@ const (
 var Rul3s = [...]string{
 	"Unknown",
 	"Grammar",
+	"Import",
 	"Declaration",
 	"Trailer",
+	"Highlight",
 	"Definition",
+	"Parameter",
 	"Expression",
 	"Sequence",
 	"Prefix",
 	"Suffix",
 	"Primary",
 	"Identifier",
+	"Call",
 	"Literal",
 	"Class",
+	"ClassSet",
 	"Ranges",
+	"Operands",
+	"Operand",
+	"NestedSet",
 	"DoubleRanges",
 	"Range",
 	"DoubleRange",
 	"Char",
 	"DoubleChar",
+	"Hex",
 	"Escape",
 	"Action",
 	"Braces",
@@ -148,8 +200,10 @@ var Rul3s = [...]string{
 	"Question",
 	"Star",
 	"Plus",
+	"Repeat",
 	"Open",
 	"Close",
+	"Comma",
 	"Dot",
 	"RPERCENT",
 	"_",
@@ -163,8 +217,8 @@ var Rul3s = [...]string{
 	"Action1",
 	"Action2",
 	"Action3",
-	"PegText",
 	"Action4",
+	"PegText",
 	"Action5",
 	"Action6",
 	"Action7",
@@ -211,9 +265,37 @@ var Rul3s = [...]string{
 	"Action48",
 	"Action49",
 	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
+	"Action60",
+	"Action61",
+	"Action62",
+	"Action63",
+	"Action64",
+	"Action65",
+	"Action66",
+	"Action67",
+	"Action68",
+	"Action69",
+	"Action70",
+	"Action71",
+	"Action72",
+	"Action73",
+	"Action74",
+	"Action75",
+	"Action76",
+	"Action77",
 
 	"RuleActionPush",
 	"RuleActionPop",
+	"RuleActionSet",
 	"Pre_",
 	"_In_",
 	"_Suf",
@@ -223,11 +305,14 @@ type TokenTree interface {
 	Print()
 	PrintSyntax()
 	PrintSyntaxTree(buffer string)
+	Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune))
+	ParseTree() *ParseNode
 	Add(rule Rule, begin, end, next, depth int)
 	Expand(index int) TokenTree
 	Tokens() <-chan token32
 	Error() []token32
 	trim(length int)
+	token(index int) token32
 }
 
 /* ${@} bit structure for abstract syntax tree */
@@ -240,6 +325,11 @@ func (t *token16) isZero() bool {
 	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
 }
 
+/* Semantic variable stack operations are not part of the syntax tree. */
+func (t *token16) isStackOperation() bool {
+	return t.Rule == RuleActionPush || t.Rule == RuleActionPop || t.Rule == RuleActionSet
+}
+
 func (t *token16) isParentOf(u token16) bool {
 	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
 }
@@ -261,6 +351,10 @@ func (t *tokens16) trim(length int) {
 	t.tree = t.tree[0:length]
 }
 
+func (t *tokens16) token(index int) token32 {
+	return t.tree[index].GetToken32()
+}
+
 func (t *tokens16) Print() {
 	for _, token := range t.tree {
 		fmt.Println(token.String())
@@ -278,6 +372,9 @@ func (t *tokens16) Order() [][]token16 {
 			t.tree = t.tree[:i]
 			break
 		}
+		if token.isStackOperation() {
+			continue
+		}
 		depth := int(token.next)
 		if length := len(depths); depth >= length {
 			depths = depths[:depth+1]
@@ -293,6 +390,9 @@ func (t *tokens16) Order() [][]token16 {
 	}
 
 	for i, token := range t.tree {
+		if token.isStackOperation() {
+			continue
+		}
 		depth := token.next
 		token.next = int16(i)
 		ordered[depth][depths[depth]] = token
@@ -432,6 +532,71 @@ func (t *tokens16) PrintSyntaxTree(buffer string) {
 	}
 }
 
+/* Split the buffer into runs of text, each with the class of the innermost rule styled. */
+func (t *tokens16) Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune)) {
+	tokens, ordered := t.PreOrder()
+	position, mark, class := 0, 0, ""
+	run := func(c string, end int) {
+		if c != class {
+			if mark < position {
+				write(class, buffer[mark:position])
+			}
+			mark, class = position, c
+		}
+		position = end
+	}
+	for token := range tokens {
+		if !token.leaf {
+			continue
+		}
+		begin, end := int(token.begin), int(token.end)
+		if end > len(buffer) {
+			end = len(buffer)
+		}
+		if begin > position {
+			run("", begin)
+		}
+		if end <= position {
+			continue
+		}
+		c, ok := styles[token.Rule]
+		for i := int(token.next) - 1; !ok && i >= 0; i-- {
+			c, ok = styles[ordered[i][token.depths[i]-1].Rule]
+		}
+		run(c, end)
+	}
+	run("", len(buffer))
+	if mark < position {
+		write(class, buffer[mark:position])
+	}
+}
+
+/* Build the parse tree; tokens are stored in post order, so children come before their parent. */
+func (t *tokens16) ParseTree() *ParseNode {
+	var levels [][]*ParseNode
+	for _, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			break
+		}
+		if token.isStackOperation() {
+			continue
+		}
+		depth := int(token.next)
+		for len(levels) < depth+2 {
+			levels = append(levels, nil)
+		}
+		n := &ParseNode{Rule: token.Rule, Begin: int(token.begin), End: int(token.end), Children: levels[depth+1]}
+		levels[depth+1], levels[depth] = nil, append(levels[depth], n)
+	}
+	if len(levels) == 0 {
+		return &ParseNode{}
+	} else if roots := levels[0]; len(roots) == 1 {
+		return roots[0]
+	} else {
+		return &ParseNode{Begin: roots[0].Begin, End: roots[len(roots)-1].End, Children: roots}
+	}
+}
+
 func (t *tokens16) Add(rule Rule, begin, end, depth, index int) {
 	t.tree[index] = token16{Rule: rule, begin: int16(begin), end: int16(end), next: int16(depth)}
 }
@@ -470,6 +635,11 @@ func (t *token32) isZero() bool {
 	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
 }
 
+/* Semantic variable stack operations are not part of the syntax tree. */
+func (t *token32) isStackOperation() bool {
+	return t.Rule == RuleActionPush || t.Rule == RuleActionPop || t.Rule == RuleActionSet
+}
+
 func (t *token32) isParentOf(u token32) bool {
 	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
 }
@@ -491,6 +661,10 @@ func (t *tokens32) trim(length int) {
 	t.tree = t.tree[0:length]
 }
 
+func (t *tokens32) token(index int) token32 {
+	return t.tree[index].GetToken32()
+}
+
 func (t *tokens32) Print() {
 	for _, token := range t.tree {
 		fmt.Println(token.String())
@@ -508,6 +682,9 @@ func (t *tokens32) Order() [][]token32 {
 			t.tree = t.tree[:i]
 			break
 		}
+		if token.isStackOperation() {
+			continue
+		}
 		depth := int(token.next)
 		if length := len(depths); depth >= length {
 			depths = depths[:depth+1]
@@ -523,6 +700,9 @@ func (t *tokens32) Order() [][]token32 {
 	}
 
 	for i, token := range t.tree {
+		if token.isStackOperation() {
+			continue
+		}
 		depth := token.next
 		token.next = int32(i)
 		ordered[depth][depths[depth]] = token
@@ -662,6 +842,71 @@ func (t *tokens32) PrintSyntaxTree(buffer string) {
 	}
 }
 
+/* Split the buffer into runs of text, each with the class of the innermost rule styled. */
+func (t *tokens32) Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune)) {
+	tokens, ordered := t.PreOrder()
+	position, mark, class := 0, 0, ""
+	run := func(c string, end int) {
+		if c != class {
+			if mark < position {
+				write(class, buffer[mark:position])
+			}
+			mark, class = position, c
+		}
+		position = end
+	}
+	for token := range tokens {
+		if !token.leaf {
+			continue
+		}
+		begin, end := int(token.begin), int(token.end)
+		if end > len(buffer) {
+			end = len(buffer)
+		}
+		if begin > position {
+			run("", begin)
+		}
+		if end <= position {
+			continue
+		}
+		c, ok := styles[token.Rule]
+		for i := int(token.next) - 1; !ok && i >= 0; i-- {
+			c, ok = styles[ordered[i][token.depths[i]-1].Rule]
+		}
+		run(c, end)
+	}
+	run("", len(buffer))
+	if mark < position {
+		write(class, buffer[mark:position])
+	}
+}
+
+/* Build the parse tree; tokens are stored in post order, so children come before their parent. */
+func (t *tokens32) ParseTree() *ParseNode {
+	var levels [][]*ParseNode
+	for _, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			break
+		}
+		if token.isStackOperation() {
+			continue
+		}
+		depth := int(token.next)
+		for len(levels) < depth+2 {
+			levels = append(levels, nil)
+		}
+		n := &ParseNode{Rule: token.Rule, Begin: int(token.begin), End: int(token.end), Children: levels[depth+1]}
+		levels[depth+1], levels[depth] = nil, append(levels[depth], n)
+	}
+	if len(levels) == 0 {
+		return &ParseNode{}
+	} else if roots := levels[0]; len(roots) == 1 {
+		return roots[0]
+	} else {
+		return &ParseNode{Begin: roots[0].Begin, End: roots[len(roots)-1].End, Children: roots}
+	}
+}
+
 func (t *tokens32) Add(rule Rule, begin, end, depth, index int) {
 	t.tree[index] = token32{Rule: rule, begin: int32(begin), end: int32(end), next: int32(depth)}
 }
@@ -712,12 +957,119 @@ func (t *tokens32) Expand(index int) TokenTree {
 	return nil
 }
 
+/* A node of the parse tree. */
+type ParseNode struct {
+	Rule
+	Begin, End int
+	Children   []*ParseNode
+}
+
+func (n *ParseNode) text(buffer []rune) string {
+	begin, end := n.Begin, n.End
+	if end > len(buffer) {
+		end = len(buffer)
+	}
+	if begin > end {
+		begin = end
+	}
+	return string(buffer[begin:end])
+}
+
+/* Write the parse tree as JSON objects with rule, begin, end, text and children. */
+func (n *ParseNode) WriteJSON(w io.Writer, buffer string) error {
+	var encode func(n *ParseNode)
+	runes, out := []rune(buffer), &bytes.Buffer{}
+	encode = func(n *ParseNode) {
+		text, _ := json.Marshal(n.text(runes))
+		fmt.Fprintf(out, "{\"rule\":\"%v\",\"begin\":%v,\"end\":%v,\"text\":%s,\"children\":[", Rul3s[n.Rule], n.Begin, n.End, text)
+		for i, child := range n.Children {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			encode(child)
+		}
+		out.WriteString("]}")
+	}
+	encode(n)
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, out.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteString("\n")
+	_, err := indented.WriteTo(w)
+	return err
+}
+
+/* Write the parse tree as S-expressions, with the matched text at the leaves. */
+func (n *ParseNode) WriteSExpression(w io.Writer, buffer string) error {
+	var write func(n *ParseNode, indent string) error
+	runes := []rune(buffer)
+	write = func(n *ParseNode, indent string) error {
+		if len(n.Children) == 0 {
+			_, err := fmt.Fprintf(w, "%v(%v %v %v %v)", indent, Rul3s[n.Rule], n.Begin, n.End, strconv.Quote(n.text(runes)))
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%v(%v %v %v", indent, Rul3s[n.Rule], n.Begin, n.End); err != nil {
+			return err
+		}
+		for _, child := range n.Children {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+			if err := write(child, indent+"  "); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, ")")
+		return err
+	}
+	if err := write(n, ""); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+/* Write the parse tree as a Graphviz DOT digraph. */
+func (n *ParseNode) WriteDOT(w io.Writer, buffer string) error {
+	var write func(n *ParseNode) (int, error)
+	runes, id := []rune(buffer), 0
+	quote := func(s string) string {
+		return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s)
+	}
+	write = func(n *ParseNode) (int, error) {
+		self := id
+		id++
+		if _, err := fmt.Fprintf(w, "  n%v [label=\"%v\\n%v\"];\n", self, quote(Rul3s[n.Rule]), quote(strconv.Quote(n.text(runes)))); err != nil {
+			return self, err
+		}
+		for _, child := range n.Children {
+			c, err := write(child)
+			if err != nil {
+				return self, err
+			}
+			if _, err := fmt.Fprintf(w, "  n%v -> n%v;\n", self, c); err != nil {
+				return self, err
+			}
+		}
+		return self, nil
+	}
+	if _, err := io.WriteString(w, "digraph Leg {\n  node [shape=box];\n"); err != nil {
+		return err
+	}
+	if _, err := write(n); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
 type Leg struct {
 	*Tree
 
 	Buffer string
 	buffer []rune
-	rules  [93]func() bool
+	rules  [131]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	TokenTree
@@ -782,124 +1134,459 @@ func (p *Leg) PrintSyntaxTree() {
 	p.TokenTree.PrintSyntaxTree(p.Buffer)
 }
 
+func (p *Leg) WriteJSON(w io.Writer) error {
+	return p.ParseTree().WriteJSON(w, p.Buffer)
+}
+
+func (p *Leg) WriteSExpression(w io.Writer) error {
+	return p.ParseTree().WriteSExpression(w, p.Buffer)
+}
+
+func (p *Leg) WriteDOT(w io.Writer) error {
+	return p.ParseTree().WriteDOT(w, p.Buffer)
+}
+
 func (p *Leg) Highlighter() {
 	p.TokenTree.PrintSyntax()
 }
 
+/* The style classes given to rules with %highlight. */
+var Highlights = map[Rule]string{
+	RuleComment:    "comment",
+	RuleLiteral:    "string",
+	RuleClass:      "string",
+	RuleAction:     "action",
+	RuleIdentifier: "identifier",
+	RuleCall:       "identifier",
+	RuleEqual:      "operator",
+	RuleColon:      "operator",
+	RuleBar:        "operator",
+	RuleAnd:        "operator",
+	RuleNot:        "operator",
+	RuleQuestion:   "operator",
+	RuleStar:       "operator",
+	RulePlus:       "operator",
+	RuleRepeat:     "operator",
+	RuleOpen:       "operator",
+	RuleClose:      "operator",
+	RuleComma:      "operator",
+	RuleDot:        "operator",
+	RuleBegin:      "operator",
+	RuleEnd:        "operator",
+	Rule_:          "space",
+}
+
+/* The SGR parameters used for common style classes by HighlightANSI. */
+var HighlightColors = map[string]string{
+	"comment":    "32",
+	"string":     "33",
+	"number":     "35",
+	"keyword":    "1;34",
+	"identifier": "36",
+	"operator":   "1",
+	"action":     "2",
+	"error":      "31",
+}
+
+/* Write the buffer with ANSI colors; nil styles and colors default to Highlights and HighlightColors. */
+func (p *Leg) HighlightANSI(w io.Writer, styles map[Rule]string, colors map[string]string) (err error) {
+	if styles == nil {
+		styles = Highlights
+	}
+	if colors == nil {
+		colors = HighlightColors
+	}
+	p.TokenTree.Highlight([]rune(p.Buffer), styles, func(class string, text []rune) {
+		if err != nil {
+			return
+		}
+		if color, ok := colors[class]; ok {
+			_, err = fmt.Fprintf(w, "\x1B[%vm%v\x1B[m", color, string(text))
+		} else {
+			_, err = io.WriteString(w, string(text))
+		}
+	})
+	return
+}
+
+/* Write the buffer as HTML with styled text in spans of its class; nil styles default to Highlights. */
+func (p *Leg) HighlightHTML(w io.Writer, styles map[Rule]string) (err error) {
+	if styles == nil {
+		styles = Highlights
+	}
+	p.TokenTree.Highlight([]rune(p.Buffer), styles, func(class string, text []rune) {
+		if err != nil {
+			return
+		}
+		if class != "" {
+			_, err = fmt.Fprintf(w, "<span class=\"%v\">%v</span>", html.EscapeString(class), html.EscapeString(string(text)))
+		} else {
+			_, err = io.WriteString(w, html.EscapeString(string(text)))
+		}
+	})
+	return
+}
+
 func (p *Leg) Execute() {
 	buffer, begin, end := p.Buffer, 0, 0
+	/* tokens count runes, but begin and end index the bytes of buffer */
+	offset := func(i int) int { return i }
+	if strings.IndexFunc(buffer, func(c rune) bool { return c >= 0x80 }) >= 0 {
+		offsets := make([]int, 0, len(buffer)+1)
+		for i := range buffer {
+			offsets = append(offsets, i)
+		}
+		offsets = append(offsets, len(buffer))
+		offset = func(i int) int { return offsets[i] }
+	}
 
 	for token := range p.TokenTree.Tokens() {
 		switch token.Rule {
 		case RulePegText:
-			begin, end = int(token.begin), int(token.end)
+			begin, end = offset(int(token.begin)), offset(int(token.end))
 		case RuleAction0:
+//line leg.leg:31:48
 			p.AddPackage(buffer[begin:end])
+//line bootstrap.leg.go:1251
 		case RuleAction1:
+//line leg.leg:32:52
 			p.AddYYSType(buffer[begin:end])
+//line bootstrap.leg.go:1255
 		case RuleAction2:
+//line leg.leg:33:59
 			p.AddLeg(buffer[begin:end])
+//line bootstrap.leg.go:1259
 		case RuleAction3:
+//line leg.leg:34:59
 			p.AddState(buffer[begin:end])
+//line bootstrap.leg.go:1263
 		case RuleAction4:
-			p.AddDeclaration(buffer[begin:end])
+//line leg.leg:37:50
+			p.AddImportPrefix(buffer[begin:end])
+//line bootstrap.leg.go:1267
 		case RuleAction5:
-			p.AddTrailer(buffer[begin:end])
+//line leg.leg:38:55
+			p.AddImport(buffer[begin:end])
+//line bootstrap.leg.go:1271
 		case RuleAction6:
-			p.AddRule(buffer[begin:end])
+//line leg.leg:40:47
+			p.At(begin)
+			p.AddDeclaration(buffer[begin:end])
+//line bootstrap.leg.go:1276
 		case RuleAction7:
-			p.AddExpression()
+//line leg.leg:41:28
+			p.At(begin)
+			p.AddTrailer(buffer[begin:end])
+//line bootstrap.leg.go:1281
 		case RuleAction8:
-			p.AddAlternate()
+//line leg.leg:42:50
+			p.AddHighlight(buffer[begin:end])
+//line bootstrap.leg.go:1285
 		case RuleAction9:
-			p.AddNil()
-			p.AddAlternate()
+//line leg.leg:43:50
+			p.At(begin)
+			p.AddHighlightRule(buffer[begin:end])
+//line bootstrap.leg.go:1290
 		case RuleAction10:
-			p.AddNil()
+//line leg.leg:46:33
+			p.At(begin)
+			p.AddRule(buffer[begin:end])
+//line bootstrap.leg.go:1295
 		case RuleAction11:
-			p.AddSequence()
+//line leg.leg:48:33
+			p.At(begin)
+			p.AddRule(buffer[begin:end])
+//line bootstrap.leg.go:1300
 		case RuleAction12:
-			p.AddPredicate(buffer[begin:end])
+//line leg.leg:49:35
+			p.AddExpression()
+//line bootstrap.leg.go:1304
 		case RuleAction13:
-			p.AddPeekFor()
+//line leg.leg:50:29
+			p.AddParameter(buffer[begin:end])
+//line bootstrap.leg.go:1308
 		case RuleAction14:
-			p.AddPeekNot()
+//line leg.leg:51:37
+			p.AddAlternate()
+//line bootstrap.leg.go:1312
 		case RuleAction15:
-			p.AddQuery()
+//line leg.leg:52:28
+			p.AddNil()
+			p.AddAlternate()
+//line bootstrap.leg.go:1317
 		case RuleAction16:
-			p.AddStar()
+//line leg.leg:54:26
+			p.AddNil()
+//line bootstrap.leg.go:1321
 		case RuleAction17:
-			p.AddPlus()
+//line leg.leg:55:29
+			p.AddSequence()
+//line bootstrap.leg.go:1325
 		case RuleAction18:
-			p.AddVariable(buffer[begin:end])
+//line leg.leg:57:27
+			p.At(begin)
+			p.AddPredicate(buffer[begin:end])
+//line bootstrap.leg.go:1330
 		case RuleAction19:
-			p.AddName(buffer[begin:end])
+//line leg.leg:58:22
+			p.AddPeekFor()
+//line bootstrap.leg.go:1334
 		case RuleAction20:
-			p.AddName(buffer[begin:end])
+//line leg.leg:59:22
+			p.AddPeekNot()
+//line bootstrap.leg.go:1338
 		case RuleAction21:
-			p.AddDot()
+//line leg.leg:61:47
+			p.AddQuery()
+//line bootstrap.leg.go:1342
 		case RuleAction22:
-			p.AddAction(buffer[begin:end])
+//line leg.leg:62:48
+			p.AddStar()
+//line bootstrap.leg.go:1346
 		case RuleAction23:
-			p.AddPush()
+//line leg.leg:63:48
+			p.AddPlus()
+//line bootstrap.leg.go:1350
 		case RuleAction24:
-			p.AddSequence()
+//line leg.leg:64:48
+			p.Span(begin-1, end+1)
+			p.AddRepeat(buffer[begin:end])
+//line bootstrap.leg.go:1355
 		case RuleAction25:
-			p.AddSequence()
+//line leg.leg:66:43
+			p.At(begin)
+			p.AddVariable(buffer[begin:end])
+//line bootstrap.leg.go:1360
 		case RuleAction26:
-			p.AddPeekNot()
+//line leg.leg:67:44
+			p.At(begin)
+			p.AddName(buffer[begin:end])
+//line bootstrap.leg.go:1365
+		case RuleAction27:
+//line leg.leg:68:44
+			p.At(begin)
+			p.AddVariable(buffer[begin:end])
+//line bootstrap.leg.go:1370
+		case RuleAction28:
+//line leg.leg:69:48
+			p.AddPush()
+			p.AddLabel()
+//line bootstrap.leg.go:1375
+		case RuleAction29:
+//line leg.leg:70:44
+			p.At(begin)
+			p.AddBackReference(buffer[begin:end])
+//line bootstrap.leg.go:1380
+		case RuleAction30:
+//line leg.leg:71:44
+			p.At(begin)
+			p.AddCall(buffer[begin:end])
+//line bootstrap.leg.go:1385
+		case RuleAction31:
+//line leg.leg:72:44
+			p.AddArgument()
+//line bootstrap.leg.go:1389
+		case RuleAction32:
+//line leg.leg:73:44
+			p.AddArgument()
+//line bootstrap.leg.go:1393
+		case RuleAction33:
+//line leg.leg:75:44
+			p.At(begin)
+			p.AddName(buffer[begin:end])
+//line bootstrap.leg.go:1398
+		case RuleAction34:
+//line leg.leg:79:48
 			p.AddDot()
+//line bootstrap.leg.go:1402
+		case RuleAction35:
+//line leg.leg:80:48
+			p.At(begin)
+			p.AddAction(buffer[begin:end])
+//line bootstrap.leg.go:1407
+		case RuleAction36:
+//line leg.leg:81:48
+			p.AddPush()
+//line bootstrap.leg.go:1411
+		case RuleAction37:
+//line leg.leg:87:55
 			p.AddSequence()
-		case RuleAction27:
+//line bootstrap.leg.go:1415
+		case RuleAction38:
+//line leg.leg:89:50
+			p.AddSequence()
+//line bootstrap.leg.go:1419
+		case RuleAction39:
+//line leg.leg:91:51
 			p.AddPeekNot()
 			p.AddDot()
 			p.AddSequence()
-		case RuleAction28:
+//line bootstrap.leg.go:1425
+		case RuleAction40:
+//line leg.leg:94:58
+			p.AddClassComplement()
+//line bootstrap.leg.go:1429
+		case RuleAction41:
+//line leg.leg:96:40
+			p.AddClassText(buffer[begin:end])
+//line bootstrap.leg.go:1433
+		case RuleAction42:
+//line leg.leg:98:37
+			p.AddClassDifference()
+//line bootstrap.leg.go:1437
+		case RuleAction43:
+//line leg.leg:99:43
+			p.AddClassIntersection()
+//line bootstrap.leg.go:1441
+		case RuleAction44:
+//line leg.leg:101:48
 			p.AddAlternate()
-		case RuleAction29:
+//line bootstrap.leg.go:1445
+		case RuleAction45:
+//line leg.leg:103:52
 			p.AddAlternate()
-		case RuleAction30:
+//line bootstrap.leg.go:1449
+		case RuleAction46:
+//line leg.leg:105:40
+			p.AddClassComplement()
+//line bootstrap.leg.go:1453
+		case RuleAction47:
+//line leg.leg:108:39
+			p.AddClassDifference()
+//line bootstrap.leg.go:1457
+		case RuleAction48:
+//line leg.leg:109:45
+			p.AddClassIntersection()
+//line bootstrap.leg.go:1461
+		case RuleAction49:
+//line leg.leg:111:54
+			p.AddAlternate()
+//line bootstrap.leg.go:1465
+		case RuleAction50:
+//line leg.leg:113:37
+			p.Span(begin-3, end+1)
+			p.AddProperty(buffer[begin:end])
+//line bootstrap.leg.go:1470
+		case RuleAction51:
+//line leg.leg:114:46
+			p.Span(begin-3, end+1)
+			p.AddProperty(buffer[begin:end])
+			p.AddClassComplement()
+//line bootstrap.leg.go:1476
+		case RuleAction52:
+//line leg.leg:115:46
 			p.AddRange()
-		case RuleAction31:
+//line bootstrap.leg.go:1480
+		case RuleAction53:
+//line leg.leg:117:41
 			p.AddDoubleRange()
-		case RuleAction32:
+//line bootstrap.leg.go:1484
+		case RuleAction54:
+//line leg.leg:120:46
+			p.At(begin)
 			p.AddCharacter(buffer[begin:end])
-		case RuleAction33:
+//line bootstrap.leg.go:1489
+		case RuleAction55:
+//line leg.leg:122:34
+			p.At(begin)
 			p.AddDoubleCharacter(buffer[begin:end])
-		case RuleAction34:
+//line bootstrap.leg.go:1494
+		case RuleAction56:
+//line leg.leg:123:46
+			p.At(begin)
 			p.AddCharacter(buffer[begin:end])
-		case RuleAction35:
+//line bootstrap.leg.go:1499
+		case RuleAction57:
+//line leg.leg:125:45
 			p.AddCharacter("\a")
-		case RuleAction36:
+//line bootstrap.leg.go:1503
+		case RuleAction58:
+//line leg.leg:126:46
 			p.AddCharacter("\b")
-		case RuleAction37:
+//line bootstrap.leg.go:1507
+		case RuleAction59:
+//line leg.leg:127:46
 			p.AddCharacter("\x1B")
-		case RuleAction38:
+//line bootstrap.leg.go:1511
+		case RuleAction60:
+//line leg.leg:128:46
 			p.AddCharacter("\f")
-		case RuleAction39:
+//line bootstrap.leg.go:1515
+		case RuleAction61:
+//line leg.leg:129:46
 			p.AddCharacter("\n")
-		case RuleAction40:
+//line bootstrap.leg.go:1519
+		case RuleAction62:
+//line leg.leg:130:46
 			p.AddCharacter("\r")
-		case RuleAction41:
+//line bootstrap.leg.go:1523
+		case RuleAction63:
+//line leg.leg:131:46
 			p.AddCharacter("\t")
-		case RuleAction42:
+//line bootstrap.leg.go:1527
+		case RuleAction64:
+//line leg.leg:132:46
 			p.AddCharacter("\v")
-		case RuleAction43:
+//line bootstrap.leg.go:1531
+		case RuleAction65:
+//line leg.leg:133:34
 			p.AddCharacter("'")
-		case RuleAction44:
+//line bootstrap.leg.go:1535
+		case RuleAction66:
+//line leg.leg:134:34
 			p.AddCharacter("\"")
-		case RuleAction45:
+//line bootstrap.leg.go:1539
+		case RuleAction67:
+//line leg.leg:135:46
 			p.AddCharacter("[")
-		case RuleAction46:
+//line bootstrap.leg.go:1543
+		case RuleAction68:
+//line leg.leg:136:46
 			p.AddCharacter("]")
-		case RuleAction47:
+//line bootstrap.leg.go:1547
+		case RuleAction69:
+//line leg.leg:137:46
 			p.AddCharacter("-")
-		case RuleAction48:
+//line bootstrap.leg.go:1551
+		case RuleAction70:
+//line leg.leg:138:47
+			p.Span(begin-2, end)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.leg.go:1556
+		case RuleAction71:
+//line leg.leg:139:46
+			p.Span(begin-3, end+1)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.leg.go:1561
+		case RuleAction72:
+//line leg.leg:140:47
+			p.Span(begin-2, end)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.leg.go:1566
+		case RuleAction73:
+//line leg.leg:142:46
+			p.Span(begin-2, end)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.leg.go:1571
+		case RuleAction74:
+//line leg.leg:143:46
+			p.Span(begin-1, end)
 			p.AddOctalCharacter(buffer[begin:end])
-		case RuleAction49:
+//line bootstrap.leg.go:1576
+		case RuleAction75:
+//line leg.leg:144:46
+			p.Span(begin-1, end)
 			p.AddOctalCharacter(buffer[begin:end])
-		case RuleAction50:
+//line bootstrap.leg.go:1581
+		case RuleAction76:
+//line leg.leg:145:46
 			p.AddCharacter("\\")
+//line bootstrap.leg.go:1585
+		case RuleAction77:
+//line leg.leg:146:45
+			p.Span(begin-1, end)
+			p.AddInvalidEscape(buffer[begin:end])
+//line bootstrap.leg.go:1590
 
 		}
 	}
@@ -966,7 +1653,7 @@ func (p *Leg) Init() {
 
 	rules = [...]func() bool{
 		nil,
-		/* 0 Grammar <- <(_ ('p' 'a' 'c' 'k' 'a' 'g' 'e') _ Identifier Action0 ('Y' 'Y' 'S' 'T' 'Y' 'P' 'E') _ Identifier Action1 ('t' 'y' 'p' 'e') _ Identifier Action2 ('P' 'e' 'g') _ Action Action3 (Declaration / Definition)+ Trailer? EndOfFile)> */
+		/* 0 Grammar <- <(_ ('p' 'a' 'c' 'k' 'a' 'g' 'e' _ Identifier Action0 ('Y' 'Y' 'S' 'T' 'Y' 'P' 'E') _ Identifier Action1 ('t' 'y' 'p' 'e') _ Identifier Action2 ('P' 'e' 'g') _ Action Action3)? (Import / Declaration / Highlight / Definition)+ Trailer? EndOfFile)> (leg.leg:31) */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
@@ -976,431 +1663,913 @@ func (p *Leg) Init() {
 				if !rules[Rule_]() {
 					goto l0
 				}
-				if buffer[position] != rune('p') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('a') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('c') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('k') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('a') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('g') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('e') {
-					goto l0
-				}
-				position++
-				if !rules[Rule_]() {
-					goto l0
-				}
-				if !rules[RuleIdentifier]() {
-					goto l0
-				}
 				{
 
-					add(RuleAction0, position)
-				}
-				if buffer[position] != rune('Y') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('Y') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('S') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('T') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('Y') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('P') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('E') {
-					goto l0
-				}
-				position++
-				if !rules[Rule_]() {
-					goto l0
-				}
-				if !rules[RuleIdentifier]() {
-					goto l0
-				}
-				{
+					position2, tokenIndex2, depth2 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('c') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('k') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('g') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l2
+					}
+					position++
+					if !rules[Rule_]() {
+						goto l2
+					}
+					if !rules[RuleIdentifier]() {
+						goto l2
+					}
+					{
 
-					add(RuleAction1, position)
-				}
-				if buffer[position] != rune('t') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('y') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('p') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('e') {
-					goto l0
-				}
-				position++
-				if !rules[Rule_]() {
-					goto l0
-				}
-				if !rules[RuleIdentifier]() {
-					goto l0
-				}
-				{
+						add(RuleAction0, position)
+					}
+					if buffer[position] != rune('Y') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('Y') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('S') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('T') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('Y') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('P') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('E') {
+						goto l2
+					}
+					position++
+					if !rules[Rule_]() {
+						goto l2
+					}
+					if !rules[RuleIdentifier]() {
+						goto l2
+					}
+					{
 
-					add(RuleAction2, position)
-				}
-				if buffer[position] != rune('P') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('e') {
-					goto l0
-				}
-				position++
-				if buffer[position] != rune('g') {
-					goto l0
-				}
-				position++
-				if !rules[Rule_]() {
-					goto l0
-				}
-				if !rules[RuleAction]() {
-					goto l0
-				}
-				{
+						add(RuleAction1, position)
+					}
+					if buffer[position] != rune('t') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('y') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('p') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l2
+					}
+					position++
+					if !rules[Rule_]() {
+						goto l2
+					}
+					if !rules[RuleIdentifier]() {
+						goto l2
+					}
+					{
+
+						add(RuleAction2, position)
+					}
+					if buffer[position] != rune('P') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l2
+					}
+					position++
+					if buffer[position] != rune('g') {
+						goto l2
+					}
+					position++
+					if !rules[Rule_]() {
+						goto l2
+					}
+					if !rules[RuleAction]() {
+						goto l2
+					}
+					{
 
-					add(RuleAction3, position)
+						add(RuleAction3, position)
+					}
+					goto l3
+				l2:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
 				}
+			l3:
 				{
 
-					position8, tokenIndex8, depth8 := position, tokenIndex, depth
+					position10, tokenIndex10, depth10 := position, tokenIndex, depth
 					{
 
-						position10 := position
+						position12 := position
 						depth++
+						if buffer[position] != rune('%') {
+							goto l11
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l11
+						}
+						position++
+						if buffer[position] != rune('m') {
+							goto l11
+						}
+						position++
+						if buffer[position] != rune('p') {
+							goto l11
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l11
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l11
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l11
+						}
+						position++
+						if !rules[Rule_]() {
+							goto l11
+						}
+						{
+
+							position13, tokenIndex13, depth13 := position, tokenIndex, depth
+							if !rules[RuleIdentifier]() {
+								goto l13
+							}
+							{
+
+								add(RuleAction4, position)
+							}
+							goto l14
+						l13:
+							position, tokenIndex, depth = position13, tokenIndex13, depth13
+						}
+					l14:
+						if buffer[position] != rune('"') {
+							goto l11
+						}
+						position++
 						{
 
-							position11 := position
+							position16 := position
 							depth++
-							if buffer[position] != rune('%') {
-								goto l9
+							{
+
+								position19, tokenIndex19, depth19 := position, tokenIndex, depth
+								if buffer[position] != rune('"') {
+									goto l19
+								}
+								position++
+								goto l11
+							l19:
+								position, tokenIndex, depth = position19, tokenIndex19, depth19
 							}
-							position++
-							if buffer[position] != rune('{') {
-								goto l9
+							if !matchDot() {
+								goto l11
+							}
+						l17:
+							{
+
+								position18, tokenIndex18, depth18 := position, tokenIndex, depth
+								{
+
+									position20, tokenIndex20, depth20 := position, tokenIndex, depth
+									if buffer[position] != rune('"') {
+										goto l20
+									}
+									position++
+									goto l18
+								l20:
+									position, tokenIndex, depth = position20, tokenIndex20, depth20
+								}
+								if !matchDot() {
+									goto l18
+								}
+								goto l17
+							l18:
+								position, tokenIndex, depth = position18, tokenIndex18, depth18
 							}
-							position++
 							depth--
-							add(RulePegText, position11)
+							add(RulePegText, position16)
+						}
+						if buffer[position] != rune('"') {
+							goto l11
+						}
+						position++
+						if !rules[Rule_]() {
+							goto l11
 						}
 						{
 
-							position12 := position
+							add(RuleAction5, position)
+						}
+						depth--
+						add(RuleImport, position12)
+					}
+					goto l10
+				l11:
+					position, tokenIndex, depth = position10, tokenIndex10, depth10
+					{
+
+						position23 := position
+						depth++
+						if buffer[position] != rune('%') {
+							goto l22
+						}
+						position++
+						if buffer[position] != rune('{') {
+							goto l22
+						}
+						position++
+						{
+
+							position24 := position
 							depth++
-						l13:
+						l25:
 							{
 
-								position14, tokenIndex14, depth14 := position, tokenIndex, depth
+								position26, tokenIndex26, depth26 := position, tokenIndex, depth
 								{
 
-									position15, tokenIndex15, depth15 := position, tokenIndex, depth
-									{
-
-										position16 := position
-										depth++
-										if buffer[position] != rune('%') {
-											goto l15
-										}
-										position++
-										if buffer[position] != rune('}') {
-											goto l15
-										}
-										position++
-										depth--
-										add(RulePegText, position16)
+									position27, tokenIndex27, depth27 := position, tokenIndex, depth
+									if buffer[position] != rune('%') {
+										goto l27
+									}
+									position++
+									if buffer[position] != rune('}') {
+										goto l27
 									}
-									goto l14
-								l15:
-									position, tokenIndex, depth = position15, tokenIndex15, depth15
+									position++
+									goto l26
+								l27:
+									position, tokenIndex, depth = position27, tokenIndex27, depth27
 								}
 								if !matchDot() {
-									goto l14
+									goto l26
 								}
-								goto l13
-							l14:
-								position, tokenIndex, depth = position14, tokenIndex14, depth14
+								goto l25
+							l26:
+								position, tokenIndex, depth = position26, tokenIndex26, depth26
 							}
 							depth--
-							add(RulePegText, position12)
+							add(RulePegText, position24)
 						}
 						{
 
-							position17 := position
+							position28 := position
 							depth++
 							if buffer[position] != rune('%') {
-								goto l9
+								goto l22
 							}
 							position++
 							if buffer[position] != rune('}') {
-								goto l9
+								goto l22
 							}
 							position++
 							if !rules[Rule_]() {
-								goto l9
+								goto l22
 							}
 							depth--
-							add(RuleRPERCENT, position17)
+							add(RuleRPERCENT, position28)
 						}
 						{
 
-							add(RuleAction4, position)
+							add(RuleAction6, position)
 						}
 						depth--
-						add(RuleDeclaration, position10)
+						add(RuleDeclaration, position23)
 					}
-					goto l8
-				l9:
-					position, tokenIndex, depth = position8, tokenIndex8, depth8
+					goto l10
+				l22:
+					position, tokenIndex, depth = position10, tokenIndex10, depth10
 					{
 
-						position19 := position
+						position31 := position
 						depth++
+						if buffer[position] != rune('%') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('h') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('g') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('h') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('l') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('g') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('h') {
+							goto l30
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l30
+						}
+						position++
+						if !rules[Rule_]() {
+							goto l30
+						}
 						if !rules[RuleIdentifier]() {
-							goto l0
+							goto l30
 						}
 						{
 
-							add(RuleAction6, position)
-						}
-						if !rules[RuleEqual]() {
-							goto l0
+							add(RuleAction8, position)
 						}
-						if !rules[RuleExpression]() {
-							goto l0
+						if !rules[RuleIdentifier]() {
+							goto l30
 						}
 						{
 
-							add(RuleAction7, position)
+							position35, tokenIndex35, depth35 := position, tokenIndex, depth
+							if !rules[RuleEqual]() {
+								goto l35
+							}
+							goto l30
+						l35:
+							position, tokenIndex, depth = position35, tokenIndex35, depth35
 						}
-						depth--
-						add(RuleDefinition, position19)
-					}
-				}
-			l8:
-			l6:
-				{
-
-					position7, tokenIndex7, depth7 := position, tokenIndex, depth
-					{
-
-						position22, tokenIndex22, depth22 := position, tokenIndex, depth
 						{
 
-							position24 := position
+							add(RuleAction9, position)
+						}
+					l33:
+						{
+
+							position34, tokenIndex34, depth34 := position, tokenIndex, depth
+							if !rules[RuleIdentifier]() {
+								goto l34
+							}
+							{
+
+								position37, tokenIndex37, depth37 := position, tokenIndex, depth
+								if !rules[RuleEqual]() {
+									goto l37
+								}
+								goto l34
+							l37:
+								position, tokenIndex, depth = position37, tokenIndex37, depth37
+							}
+							{
+
+								add(RuleAction9, position)
+							}
+							goto l33
+						l34:
+							position, tokenIndex, depth = position34, tokenIndex34, depth34
+						}
+						depth--
+						add(RuleHighlight, position31)
+					}
+					goto l10
+				l30:
+					position, tokenIndex, depth = position10, tokenIndex10, depth10
+					{
+
+						position39 := position
+						depth++
+						{
+
+							position40, tokenIndex40, depth40 := position, tokenIndex, depth
+							if !rules[RuleCall]() {
+								goto l41
+							}
+							{
+
+								add(RuleAction10, position)
+							}
+							if !rules[RuleParameter]() {
+								goto l41
+							}
+						l43:
+							{
+
+								position44, tokenIndex44, depth44 := position, tokenIndex, depth
+								if !rules[RuleComma]() {
+									goto l44
+								}
+								if !rules[RuleParameter]() {
+									goto l44
+								}
+								goto l43
+							l44:
+								position, tokenIndex, depth = position44, tokenIndex44, depth44
+							}
+							if !rules[RuleClose]() {
+								goto l41
+							}
+							goto l40
+						l41:
+							position, tokenIndex, depth = position40, tokenIndex40, depth40
+							if !rules[RuleIdentifier]() {
+								goto l0
+							}
+							{
+
+								add(RuleAction11, position)
+							}
+						}
+					l40:
+						if !rules[RuleEqual]() {
+							goto l0
+						}
+						if !rules[RuleExpression]() {
+							goto l0
+						}
+						{
+
+							add(RuleAction12, position)
+						}
+						depth--
+						add(RuleDefinition, position39)
+					}
+				}
+			l10:
+			l8:
+				{
+
+					position9, tokenIndex9, depth9 := position, tokenIndex, depth
+					{
+
+						position47, tokenIndex47, depth47 := position, tokenIndex, depth
+						{
+
+							position49 := position
 							depth++
+							if buffer[position] != rune('%') {
+								goto l48
+							}
+							position++
+							if buffer[position] != rune('i') {
+								goto l48
+							}
+							position++
+							if buffer[position] != rune('m') {
+								goto l48
+							}
+							position++
+							if buffer[position] != rune('p') {
+								goto l48
+							}
+							position++
+							if buffer[position] != rune('o') {
+								goto l48
+							}
+							position++
+							if buffer[position] != rune('r') {
+								goto l48
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l48
+							}
+							position++
+							if !rules[Rule_]() {
+								goto l48
+							}
+							{
+
+								position50, tokenIndex50, depth50 := position, tokenIndex, depth
+								if !rules[RuleIdentifier]() {
+									goto l50
+								}
+								{
+
+									add(RuleAction4, position)
+								}
+								goto l51
+							l50:
+								position, tokenIndex, depth = position50, tokenIndex50, depth50
+							}
+						l51:
+							if buffer[position] != rune('"') {
+								goto l48
+							}
+							position++
 							{
 
-								position25 := position
+								position53 := position
 								depth++
-								if buffer[position] != rune('%') {
-									goto l23
+								{
+
+									position56, tokenIndex56, depth56 := position, tokenIndex, depth
+									if buffer[position] != rune('"') {
+										goto l56
+									}
+									position++
+									goto l48
+								l56:
+									position, tokenIndex, depth = position56, tokenIndex56, depth56
 								}
-								position++
-								if buffer[position] != rune('{') {
-									goto l23
+								if !matchDot() {
+									goto l48
+								}
+							l54:
+								{
+
+									position55, tokenIndex55, depth55 := position, tokenIndex, depth
+									{
+
+										position57, tokenIndex57, depth57 := position, tokenIndex, depth
+										if buffer[position] != rune('"') {
+											goto l57
+										}
+										position++
+										goto l55
+									l57:
+										position, tokenIndex, depth = position57, tokenIndex57, depth57
+									}
+									if !matchDot() {
+										goto l55
+									}
+									goto l54
+								l55:
+									position, tokenIndex, depth = position55, tokenIndex55, depth55
 								}
-								position++
 								depth--
-								add(RulePegText, position25)
+								add(RulePegText, position53)
+							}
+							if buffer[position] != rune('"') {
+								goto l48
+							}
+							position++
+							if !rules[Rule_]() {
+								goto l48
+							}
+							{
+
+								add(RuleAction5, position)
 							}
+							depth--
+							add(RuleImport, position49)
+						}
+						goto l47
+					l48:
+						position, tokenIndex, depth = position47, tokenIndex47, depth47
+						{
+
+							position60 := position
+							depth++
+							if buffer[position] != rune('%') {
+								goto l59
+							}
+							position++
+							if buffer[position] != rune('{') {
+								goto l59
+							}
+							position++
 							{
 
-								position26 := position
+								position61 := position
 								depth++
-							l27:
+							l62:
 								{
 
-									position28, tokenIndex28, depth28 := position, tokenIndex, depth
+									position63, tokenIndex63, depth63 := position, tokenIndex, depth
 									{
 
-										position29, tokenIndex29, depth29 := position, tokenIndex, depth
-										{
-
-											position30 := position
-											depth++
-											if buffer[position] != rune('%') {
-												goto l29
-											}
-											position++
-											if buffer[position] != rune('}') {
-												goto l29
-											}
-											position++
-											depth--
-											add(RulePegText, position30)
+										position64, tokenIndex64, depth64 := position, tokenIndex, depth
+										if buffer[position] != rune('%') {
+											goto l64
+										}
+										position++
+										if buffer[position] != rune('}') {
+											goto l64
 										}
-										goto l28
-									l29:
-										position, tokenIndex, depth = position29, tokenIndex29, depth29
+										position++
+										goto l63
+									l64:
+										position, tokenIndex, depth = position64, tokenIndex64, depth64
 									}
 									if !matchDot() {
-										goto l28
+										goto l63
 									}
-									goto l27
-								l28:
-									position, tokenIndex, depth = position28, tokenIndex28, depth28
+									goto l62
+								l63:
+									position, tokenIndex, depth = position63, tokenIndex63, depth63
 								}
 								depth--
-								add(RulePegText, position26)
+								add(RulePegText, position61)
 							}
 							{
 
-								position31 := position
+								position65 := position
 								depth++
 								if buffer[position] != rune('%') {
-									goto l23
+									goto l59
 								}
 								position++
 								if buffer[position] != rune('}') {
-									goto l23
+									goto l59
 								}
 								position++
 								if !rules[Rule_]() {
-									goto l23
+									goto l59
 								}
 								depth--
-								add(RuleRPERCENT, position31)
+								add(RuleRPERCENT, position65)
 							}
 							{
 
-								add(RuleAction4, position)
+								add(RuleAction6, position)
 							}
 							depth--
-							add(RuleDeclaration, position24)
+							add(RuleDeclaration, position60)
 						}
-						goto l22
-					l23:
-						position, tokenIndex, depth = position22, tokenIndex22, depth22
+						goto l47
+					l59:
+						position, tokenIndex, depth = position47, tokenIndex47, depth47
 						{
 
-							position33 := position
+							position68 := position
 							depth++
+							if buffer[position] != rune('%') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('h') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('i') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('g') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('h') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('l') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('i') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('g') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('h') {
+								goto l67
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l67
+							}
+							position++
+							if !rules[Rule_]() {
+								goto l67
+							}
 							if !rules[RuleIdentifier]() {
-								goto l7
+								goto l67
 							}
 							{
 
-								add(RuleAction6, position)
+								add(RuleAction8, position)
+							}
+							if !rules[RuleIdentifier]() {
+								goto l67
+							}
+							{
+
+								position72, tokenIndex72, depth72 := position, tokenIndex, depth
+								if !rules[RuleEqual]() {
+									goto l72
+								}
+								goto l67
+							l72:
+								position, tokenIndex, depth = position72, tokenIndex72, depth72
+							}
+							{
+
+								add(RuleAction9, position)
+							}
+						l70:
+							{
+
+								position71, tokenIndex71, depth71 := position, tokenIndex, depth
+								if !rules[RuleIdentifier]() {
+									goto l71
+								}
+								{
+
+									position74, tokenIndex74, depth74 := position, tokenIndex, depth
+									if !rules[RuleEqual]() {
+										goto l74
+									}
+									goto l71
+								l74:
+									position, tokenIndex, depth = position74, tokenIndex74, depth74
+								}
+								{
+
+									add(RuleAction9, position)
+								}
+								goto l70
+							l71:
+								position, tokenIndex, depth = position71, tokenIndex71, depth71
+							}
+							depth--
+							add(RuleHighlight, position68)
+						}
+						goto l47
+					l67:
+						position, tokenIndex, depth = position47, tokenIndex47, depth47
+						{
+
+							position76 := position
+							depth++
+							{
+
+								position77, tokenIndex77, depth77 := position, tokenIndex, depth
+								if !rules[RuleCall]() {
+									goto l78
+								}
+								{
+
+									add(RuleAction10, position)
+								}
+								if !rules[RuleParameter]() {
+									goto l78
+								}
+							l80:
+								{
+
+									position81, tokenIndex81, depth81 := position, tokenIndex, depth
+									if !rules[RuleComma]() {
+										goto l81
+									}
+									if !rules[RuleParameter]() {
+										goto l81
+									}
+									goto l80
+								l81:
+									position, tokenIndex, depth = position81, tokenIndex81, depth81
+								}
+								if !rules[RuleClose]() {
+									goto l78
+								}
+								goto l77
+							l78:
+								position, tokenIndex, depth = position77, tokenIndex77, depth77
+								if !rules[RuleIdentifier]() {
+									goto l9
+								}
+								{
+
+									add(RuleAction11, position)
+								}
 							}
+						l77:
 							if !rules[RuleEqual]() {
-								goto l7
+								goto l9
 							}
 							if !rules[RuleExpression]() {
-								goto l7
+								goto l9
 							}
 							{
 
-								add(RuleAction7, position)
+								add(RuleAction12, position)
 							}
 							depth--
-							add(RuleDefinition, position33)
+							add(RuleDefinition, position76)
 						}
 					}
-				l22:
-					goto l6
-				l7:
-					position, tokenIndex, depth = position7, tokenIndex7, depth7
+				l47:
+					goto l8
+				l9:
+					position, tokenIndex, depth = position9, tokenIndex9, depth9
 				}
 				{
 
-					position36, tokenIndex36, depth36 := position, tokenIndex, depth
+					position84, tokenIndex84, depth84 := position, tokenIndex, depth
 					{
 
-						position38 := position
+						position86 := position
 						depth++
 						if buffer[position] != rune('%') {
-							goto l36
+							goto l84
 						}
 						position++
 						if buffer[position] != rune('%') {
-							goto l36
+							goto l84
 						}
 						position++
 						{
 
-							position39 := position
+							position87 := position
 							depth++
-						l40:
+						l88:
 							{
 
-								position41, tokenIndex41, depth41 := position, tokenIndex, depth
+								position89, tokenIndex89, depth89 := position, tokenIndex, depth
 								if !matchDot() {
-									goto l41
+									goto l89
 								}
-								goto l40
-							l41:
-								position, tokenIndex, depth = position41, tokenIndex41, depth41
+								goto l88
+							l89:
+								position, tokenIndex, depth = position89, tokenIndex89, depth89
 							}
 							depth--
-							add(RulePegText, position39)
+							add(RulePegText, position87)
 						}
 						{
 
-							add(RuleAction5, position)
+							add(RuleAction7, position)
 						}
 						depth--
-						add(RuleTrailer, position38)
+						add(RuleTrailer, position86)
 					}
-					goto l37
-				l36:
-					position, tokenIndex, depth = position36, tokenIndex36, depth36
+					goto l85
+				l84:
+					position, tokenIndex, depth = position84, tokenIndex84, depth84
 				}
-			l37:
+			l85:
 				{
 
-					position43 := position
+					position91 := position
 					depth++
 					{
 
-						position44, tokenIndex44, depth44 := position, tokenIndex, depth
+						position92, tokenIndex92, depth92 := position, tokenIndex, depth
 						if !matchDot() {
-							goto l44
+							goto l92
 						}
 						goto l0
-					l44:
-						position, tokenIndex, depth = position44, tokenIndex44, depth44
+					l92:
+						position, tokenIndex, depth = position92, tokenIndex92, depth92
 					}
 					depth--
-					add(RuleEndOfFile, position43)
+					add(RuleEndOfFile, position91)
 				}
 				depth--
 				add(RuleGrammar, position1)
@@ -1410,1952 +2579,3151 @@ func (p *Leg) Init() {
 			position, tokenIndex, depth = position0, tokenIndex0, depth0
 			return false
 		},
-		/* 1 Declaration <- <(<('%' '{')> <(!<('%' '}')> .)*> RPERCENT Action4)> */
+		/* 1 Import <- <('%' 'i' 'm' 'p' 'o' 'r' 't' _ (Identifier Action4)? '"' <(!'"' .)+> '"' _ Action5)> (leg.leg:37) */
+		nil,
+		/* 2 Declaration <- <('%' '{' <(!('%' '}') .)*> RPERCENT Action6)> (leg.leg:40) */
+		nil,
+		/* 3 Trailer <- <('%' '%' <.*> Action7)> (leg.leg:41) */
 		nil,
-		/* 2 Trailer <- <('%' '%' (<.*> Action5))> */
+		/* 4 Highlight <- <('%' 'h' 'i' 'g' 'h' 'l' 'i' 'g' 'h' 't' _ Identifier Action8 (Identifier !Equal Action9)+)> (leg.leg:42) */
 		nil,
-		/* 3 Definition <- <(Identifier Action6 Equal Expression Action7)> */
+		/* 5 Definition <- <(((Call Action10 Parameter (Comma Parameter)* Close) / (Identifier Action11)) Equal Expression Action12)> (leg.leg:46) */
 		nil,
-		/* 4 Expression <- <((Sequence (Bar Sequence Action8)* (Bar Action9)?) / Action10)> */
+		/* 6 Parameter <- <(Identifier Action13)> (leg.leg:50) */
+		func() bool {
+			position98, tokenIndex98, depth98 := position, tokenIndex, depth
+			{
+
+				position99 := position
+				depth++
+				if !rules[RuleIdentifier]() {
+					goto l98
+				}
+				{
+
+					add(RuleAction13, position)
+				}
+				depth--
+				add(RuleParameter, position99)
+			}
+			return true
+		l98:
+			position, tokenIndex, depth = position98, tokenIndex98, depth98
+			return false
+		},
+		/* 7 Expression <- <((Sequence (Bar Sequence Action14)* (Bar Action15)?) / Action16)> (leg.leg:51) */
 		func() bool {
 			{
 
-				position49 := position
+				position102 := position
 				depth++
 				{
 
-					position50, tokenIndex50, depth50 := position, tokenIndex, depth
+					position103, tokenIndex103, depth103 := position, tokenIndex, depth
 					if !rules[RuleSequence]() {
-						goto l51
+						goto l104
 					}
-				l52:
+				l105:
 					{
 
-						position53, tokenIndex53, depth53 := position, tokenIndex, depth
+						position106, tokenIndex106, depth106 := position, tokenIndex, depth
 						if !rules[RuleBar]() {
-							goto l53
+							goto l106
 						}
 						if !rules[RuleSequence]() {
-							goto l53
+							goto l106
 						}
 						{
 
-							add(RuleAction8, position)
+							add(RuleAction14, position)
 						}
-						goto l52
-					l53:
-						position, tokenIndex, depth = position53, tokenIndex53, depth53
+						goto l105
+					l106:
+						position, tokenIndex, depth = position106, tokenIndex106, depth106
 					}
 					{
 
-						position55, tokenIndex55, depth55 := position, tokenIndex, depth
+						position108, tokenIndex108, depth108 := position, tokenIndex, depth
 						if !rules[RuleBar]() {
-							goto l55
+							goto l108
 						}
 						{
 
-							add(RuleAction9, position)
+							add(RuleAction15, position)
 						}
-						goto l56
-					l55:
-						position, tokenIndex, depth = position55, tokenIndex55, depth55
+						goto l109
+					l108:
+						position, tokenIndex, depth = position108, tokenIndex108, depth108
 					}
-				l56:
-					goto l50
-				l51:
-					position, tokenIndex, depth = position50, tokenIndex50, depth50
+				l109:
+					goto l103
+				l104:
+					position, tokenIndex, depth = position103, tokenIndex103, depth103
 					{
 
-						add(RuleAction10, position)
+						add(RuleAction16, position)
 					}
 				}
-			l50:
+			l103:
 				depth--
-				add(RuleExpression, position49)
+				add(RuleExpression, position102)
 			}
 			return true
 		},
-		/* 5 Sequence <- <(Prefix (Prefix Action11)*)> */
+		/* 8 Sequence <- <(Prefix (Prefix Action17)*)> (leg.leg:55) */
 		func() bool {
-			position59, tokenIndex59, depth59 := position, tokenIndex, depth
+			position112, tokenIndex112, depth112 := position, tokenIndex, depth
 			{
 
-				position60 := position
+				position113 := position
 				depth++
 				if !rules[RulePrefix]() {
-					goto l59
+					goto l112
 				}
-			l61:
+			l114:
 				{
 
-					position62, tokenIndex62, depth62 := position, tokenIndex, depth
+					position115, tokenIndex115, depth115 := position, tokenIndex, depth
 					if !rules[RulePrefix]() {
-						goto l62
+						goto l115
 					}
 					{
 
-						add(RuleAction11, position)
+						add(RuleAction17, position)
 					}
-					goto l61
-				l62:
-					position, tokenIndex, depth = position62, tokenIndex62, depth62
+					goto l114
+				l115:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
 				}
 				depth--
-				add(RuleSequence, position60)
+				add(RuleSequence, position113)
 			}
 			return true
-		l59:
-			position, tokenIndex, depth = position59, tokenIndex59, depth59
+		l112:
+			position, tokenIndex, depth = position112, tokenIndex112, depth112
 			return false
 		},
-		/* 6 Prefix <- <((And Action Action12) / ((&('!') (Not Suffix Action14)) | (&('&') (And Suffix Action13)) | (&('"' | '\'' | '(' | '-' | '.' | '<' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '[' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z' | '{') Suffix)))> */
+		/* 9 Prefix <- <((And Action Action18) / ((&('!') (Not Suffix Action20)) | (&('&') (And Suffix Action19)) | (&('"' | '\'' | '(' | '-' | '.' | '<' | '=' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '[' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z' | '{') Suffix)))> (leg.leg:57) */
 		func() bool {
-			position64, tokenIndex64, depth64 := position, tokenIndex, depth
+			position117, tokenIndex117, depth117 := position, tokenIndex, depth
 			{
 
-				position65 := position
+				position118 := position
 				depth++
 				{
 
-					position66, tokenIndex66, depth66 := position, tokenIndex, depth
+					position119, tokenIndex119, depth119 := position, tokenIndex, depth
 					if !rules[RuleAnd]() {
-						goto l67
+						goto l120
 					}
 					if !rules[RuleAction]() {
-						goto l67
+						goto l120
 					}
 					{
 
-						add(RuleAction12, position)
+						add(RuleAction18, position)
 					}
-					goto l66
-				l67:
-					position, tokenIndex, depth = position66, tokenIndex66, depth66
+					goto l119
+				l120:
+					position, tokenIndex, depth = position119, tokenIndex119, depth119
 					{
 
 						switch buffer[position] {
 						case '!':
 							{
 
-								position70 := position
+								position123 := position
 								depth++
 								if buffer[position] != rune('!') {
-									goto l64
+									goto l117
 								}
 								position++
 								if !rules[Rule_]() {
-									goto l64
+									goto l117
 								}
 								depth--
-								add(RuleNot, position70)
+								add(RuleNot, position123)
 							}
 							if !rules[RuleSuffix]() {
-								goto l64
+								goto l117
 							}
 							{
 
-								add(RuleAction14, position)
+								add(RuleAction20, position)
 							}
 							break
 						case '&':
 							if !rules[RuleAnd]() {
-								goto l64
+								goto l117
 							}
 							if !rules[RuleSuffix]() {
-								goto l64
+								goto l117
 							}
 							{
 
-								add(RuleAction13, position)
+								add(RuleAction19, position)
 							}
 							break
 						default:
 							if !rules[RuleSuffix]() {
-								goto l64
+								goto l117
 							}
 							break
 						}
 					}
 
 				}
-			l66:
+			l119:
 				depth--
-				add(RulePrefix, position65)
+				add(RulePrefix, position118)
 			}
 			return true
-		l64:
-			position, tokenIndex, depth = position64, tokenIndex64, depth64
+		l117:
+			position, tokenIndex, depth = position117, tokenIndex117, depth117
 			return false
 		},
-		/* 7 Suffix <- <(Primary ((&('+') (Plus Action17)) | (&('*') (Star Action16)) | (&('?') (Question Action15)))?)> */
+		/* 10 Suffix <- <(Primary ((&('{') (Repeat Action24)) | (&('+') (Plus Action23)) | (&('*') (Star Action22)) | (&('?') (Question Action21)))?)> (leg.leg:61) */
 		func() bool {
-			position73, tokenIndex73, depth73 := position, tokenIndex, depth
+			position126, tokenIndex126, depth126 := position, tokenIndex, depth
 			{
 
-				position74 := position
+				position127 := position
 				depth++
 				{
 
-					position75 := position
+					position128 := position
 					depth++
 					{
 
-						position76, tokenIndex76, depth76 := position, tokenIndex, depth
+						position129, tokenIndex129, depth129 := position, tokenIndex, depth
 						if !rules[RuleIdentifier]() {
-							goto l77
+							goto l130
 						}
 						{
 
-							add(RuleAction18, position)
+							add(RuleAction25, position)
 						}
-						{
-
-							position79 := position
-							depth++
-							if buffer[position] != rune(':') {
-								goto l77
-							}
-							position++
-							if !rules[Rule_]() {
-								goto l77
-							}
-							depth--
-							add(RuleColon, position79)
+						if !rules[RuleColon]() {
+							goto l130
 						}
 						if !rules[RuleIdentifier]() {
-							goto l77
+							goto l130
 						}
 						{
 
-							position80, tokenIndex80, depth80 := position, tokenIndex, depth
+							position132, tokenIndex132, depth132 := position, tokenIndex, depth
 							if !rules[RuleEqual]() {
-								goto l80
+								goto l132
 							}
-							goto l77
-						l80:
-							position, tokenIndex, depth = position80, tokenIndex80, depth80
+							goto l130
+						l132:
+							position, tokenIndex, depth = position132, tokenIndex132, depth132
 						}
 						{
 
-							add(RuleAction19, position)
+							add(RuleAction26, position)
+						}
+						goto l129
+					l130:
+						position, tokenIndex, depth = position129, tokenIndex129, depth129
+						if !rules[RuleIdentifier]() {
+							goto l134
 						}
-						goto l76
-					l77:
-						position, tokenIndex, depth = position76, tokenIndex76, depth76
 						{
 
-							switch buffer[position] {
-							case '<':
-								{
+							add(RuleAction27, position)
+						}
+						if !rules[RuleColon]() {
+							goto l134
+						}
+						if !rules[RuleBegin]() {
+							goto l134
+						}
+						if !rules[RuleExpression]() {
+							goto l134
+						}
+						if !rules[RuleEnd]() {
+							goto l134
+						}
+						{
 
-									position83 := position
-									depth++
-									if buffer[position] != rune('<') {
-										goto l73
-									}
-									position++
-									if !rules[Rule_]() {
-										goto l73
-									}
-									depth--
-									add(RuleBegin, position83)
+							add(RuleAction28, position)
+						}
+						goto l129
+					l134:
+						position, tokenIndex, depth = position129, tokenIndex129, depth129
+						if !rules[RuleCall]() {
+							goto l137
+						}
+						{
+
+							add(RuleAction30, position)
+						}
+						if !rules[RuleExpression]() {
+							goto l137
+						}
+						{
+
+							add(RuleAction31, position)
+						}
+					l140:
+						{
+
+							position141, tokenIndex141, depth141 := position, tokenIndex, depth
+							if !rules[RuleComma]() {
+								goto l141
+							}
+							if !rules[RuleExpression]() {
+								goto l141
+							}
+							{
+
+								add(RuleAction32, position)
+							}
+							goto l140
+						l141:
+							position, tokenIndex, depth = position141, tokenIndex141, depth141
+						}
+						if !rules[RuleClose]() {
+							goto l137
+						}
+						{
+
+							position143, tokenIndex143, depth143 := position, tokenIndex, depth
+							if !rules[RuleEqual]() {
+								goto l143
+							}
+							goto l137
+						l143:
+							position, tokenIndex, depth = position143, tokenIndex143, depth143
+						}
+						goto l129
+					l137:
+						position, tokenIndex, depth = position129, tokenIndex129, depth129
+						{
+
+							switch buffer[position] {
+							case '<':
+								if !rules[RuleBegin]() {
+									goto l126
 								}
 								if !rules[RuleExpression]() {
-									goto l73
+									goto l126
 								}
-								{
-
-									position84 := position
-									depth++
-									if buffer[position] != rune('>') {
-										goto l73
-									}
-									position++
-									if !rules[Rule_]() {
-										goto l73
-									}
-									depth--
-									add(RuleEnd, position84)
+								if !rules[RuleEnd]() {
+									goto l126
 								}
 								{
 
-									add(RuleAction23, position)
+									add(RuleAction36, position)
 								}
 								break
 							case '{':
 								if !rules[RuleAction]() {
-									goto l73
+									goto l126
 								}
 								{
 
-									add(RuleAction22, position)
+									add(RuleAction35, position)
 								}
 								break
 							case '.':
 								{
 
-									position87 := position
+									position147 := position
 									depth++
 									if buffer[position] != rune('.') {
-										goto l73
+										goto l126
 									}
 									position++
 									if !rules[Rule_]() {
-										goto l73
+										goto l126
 									}
 									depth--
-									add(RuleDot, position87)
+									add(RuleDot, position147)
 								}
 								{
 
-									add(RuleAction21, position)
+									add(RuleAction34, position)
 								}
 								break
 							case '[':
 								{
 
-									position89 := position
+									position149 := position
 									depth++
 									{
 
-										position90, tokenIndex90, depth90 := position, tokenIndex, depth
-										if buffer[position] != rune('[') {
-											goto l91
-										}
-										position++
-										if buffer[position] != rune('[') {
-											goto l91
-										}
-										position++
+										position150 := position
+										depth++
 										{
 
-											position92, tokenIndex92, depth92 := position, tokenIndex, depth
+											position151, tokenIndex151, depth151 := position, tokenIndex, depth
+											if buffer[position] != rune('[') {
+												goto l152
+											}
+											position++
+											if buffer[position] != rune('[') {
+												goto l152
+											}
+											position++
 											{
 
-												position94, tokenIndex94, depth94 := position, tokenIndex, depth
-												if buffer[position] != rune('^') {
-													goto l95
-												}
-												position++
-												if !rules[RuleDoubleRanges]() {
-													goto l95
-												}
+												position153, tokenIndex153, depth153 := position, tokenIndex, depth
 												{
 
-													add(RuleAction26, position)
-												}
-												goto l94
-											l95:
-												position, tokenIndex, depth = position94, tokenIndex94, depth94
-												if !rules[RuleDoubleRanges]() {
-													goto l92
+													position155, tokenIndex155, depth155 := position, tokenIndex, depth
+													if buffer[position] != rune('^') {
+														goto l156
+													}
+													position++
+													if !rules[RuleDoubleRanges]() {
+														goto l156
+													}
+													{
+
+														add(RuleAction39, position)
+													}
+													goto l155
+												l156:
+													position, tokenIndex, depth = position155, tokenIndex155, depth155
+													if !rules[RuleDoubleRanges]() {
+														goto l153
+													}
 												}
+											l155:
+												goto l154
+											l153:
+												position, tokenIndex, depth = position153, tokenIndex153, depth153
 											}
-										l94:
-											goto l93
-										l92:
-											position, tokenIndex, depth = position92, tokenIndex92, depth92
-										}
-									l93:
-										if buffer[position] != rune(']') {
-											goto l91
-										}
-										position++
-										if buffer[position] != rune(']') {
-											goto l91
-										}
-										position++
-										goto l90
-									l91:
-										position, tokenIndex, depth = position90, tokenIndex90, depth90
-										if buffer[position] != rune('[') {
-											goto l73
-										}
-										position++
-										{
-
-											position97, tokenIndex97, depth97 := position, tokenIndex, depth
+										l154:
+											if buffer[position] != rune(']') {
+												goto l152
+											}
+											position++
+											if buffer[position] != rune(']') {
+												goto l152
+											}
+											position++
+											goto l151
+										l152:
+											position, tokenIndex, depth = position151, tokenIndex151, depth151
+											if buffer[position] != rune('[') {
+												goto l126
+											}
+											position++
 											{
 
-												position99, tokenIndex99, depth99 := position, tokenIndex, depth
-												if buffer[position] != rune('^') {
-													goto l100
-												}
-												position++
-												if !rules[RuleRanges]() {
-													goto l100
-												}
+												position158, tokenIndex158, depth158 := position, tokenIndex, depth
 												{
 
-													add(RuleAction27, position)
-												}
-												goto l99
-											l100:
-												position, tokenIndex, depth = position99, tokenIndex99, depth99
-												if !rules[RuleRanges]() {
-													goto l97
+													position160, tokenIndex160, depth160 := position, tokenIndex, depth
+													if buffer[position] != rune('^') {
+														goto l161
+													}
+													position++
+													if !rules[RuleClassSet]() {
+														goto l161
+													}
+													{
+
+														add(RuleAction40, position)
+													}
+													goto l160
+												l161:
+													position, tokenIndex, depth = position160, tokenIndex160, depth160
+													if !rules[RuleClassSet]() {
+														goto l158
+													}
 												}
+											l160:
+												goto l159
+											l158:
+												position, tokenIndex, depth = position158, tokenIndex158, depth158
 											}
-										l99:
-											goto l98
-										l97:
-											position, tokenIndex, depth = position97, tokenIndex97, depth97
-										}
-									l98:
-										if buffer[position] != rune(']') {
-											goto l73
+										l159:
+											if buffer[position] != rune(']') {
+												goto l126
+											}
+											position++
 										}
-										position++
+									l151:
+										depth--
+										add(RulePegText, position150)
+									}
+									{
+
+										add(RuleAction41, position)
 									}
-								l90:
 									if !rules[Rule_]() {
-										goto l73
+										goto l126
 									}
 									depth--
-									add(RuleClass, position89)
+									add(RuleClass, position149)
 								}
 								break
 							case '"', '\'':
 								{
 
-									position102 := position
+									position164 := position
 									depth++
 									{
 
-										position103, tokenIndex103, depth103 := position, tokenIndex, depth
+										position165, tokenIndex165, depth165 := position, tokenIndex, depth
 										if buffer[position] != rune('\'') {
-											goto l104
+											goto l166
 										}
 										position++
 										{
 
-											position105, tokenIndex105, depth105 := position, tokenIndex, depth
+											position167, tokenIndex167, depth167 := position, tokenIndex, depth
 											{
 
-												position107, tokenIndex107, depth107 := position, tokenIndex, depth
+												position169, tokenIndex169, depth169 := position, tokenIndex, depth
 												if buffer[position] != rune('\'') {
-													goto l107
+													goto l169
 												}
 												position++
-												goto l105
-											l107:
-												position, tokenIndex, depth = position107, tokenIndex107, depth107
+												goto l167
+											l169:
+												position, tokenIndex, depth = position169, tokenIndex169, depth169
 											}
 											if !rules[RuleChar]() {
-												goto l105
+												goto l167
 											}
-											goto l106
-										l105:
-											position, tokenIndex, depth = position105, tokenIndex105, depth105
+											goto l168
+										l167:
+											position, tokenIndex, depth = position167, tokenIndex167, depth167
 										}
-									l106:
-									l108:
+									l168:
+									l170:
 										{
 
-											position109, tokenIndex109, depth109 := position, tokenIndex, depth
+											position171, tokenIndex171, depth171 := position, tokenIndex, depth
 											{
 
-												position110, tokenIndex110, depth110 := position, tokenIndex, depth
+												position172, tokenIndex172, depth172 := position, tokenIndex, depth
 												if buffer[position] != rune('\'') {
-													goto l110
+													goto l172
 												}
 												position++
-												goto l109
-											l110:
-												position, tokenIndex, depth = position110, tokenIndex110, depth110
+												goto l171
+											l172:
+												position, tokenIndex, depth = position172, tokenIndex172, depth172
 											}
 											if !rules[RuleChar]() {
-												goto l109
+												goto l171
 											}
 											{
 
-												add(RuleAction24, position)
+												add(RuleAction37, position)
 											}
-											goto l108
-										l109:
-											position, tokenIndex, depth = position109, tokenIndex109, depth109
+											goto l170
+										l171:
+											position, tokenIndex, depth = position171, tokenIndex171, depth171
 										}
 										if buffer[position] != rune('\'') {
-											goto l104
+											goto l166
 										}
 										position++
 										if !rules[Rule_]() {
-											goto l104
+											goto l166
 										}
-										goto l103
-									l104:
-										position, tokenIndex, depth = position103, tokenIndex103, depth103
+										goto l165
+									l166:
+										position, tokenIndex, depth = position165, tokenIndex165, depth165
 										if buffer[position] != rune('"') {
-											goto l73
+											goto l126
 										}
 										position++
 										{
 
-											position112, tokenIndex112, depth112 := position, tokenIndex, depth
+											position174, tokenIndex174, depth174 := position, tokenIndex, depth
 											{
 
-												position114, tokenIndex114, depth114 := position, tokenIndex, depth
+												position176, tokenIndex176, depth176 := position, tokenIndex, depth
 												if buffer[position] != rune('"') {
-													goto l114
+													goto l176
 												}
 												position++
-												goto l112
-											l114:
-												position, tokenIndex, depth = position114, tokenIndex114, depth114
+												goto l174
+											l176:
+												position, tokenIndex, depth = position176, tokenIndex176, depth176
 											}
 											if !rules[RuleDoubleChar]() {
-												goto l112
+												goto l174
 											}
-											goto l113
-										l112:
-											position, tokenIndex, depth = position112, tokenIndex112, depth112
+											goto l175
+										l174:
+											position, tokenIndex, depth = position174, tokenIndex174, depth174
 										}
-									l113:
-									l115:
+									l175:
+									l177:
 										{
 
-											position116, tokenIndex116, depth116 := position, tokenIndex, depth
+											position178, tokenIndex178, depth178 := position, tokenIndex, depth
 											{
 
-												position117, tokenIndex117, depth117 := position, tokenIndex, depth
+												position179, tokenIndex179, depth179 := position, tokenIndex, depth
 												if buffer[position] != rune('"') {
-													goto l117
+													goto l179
 												}
 												position++
-												goto l116
-											l117:
-												position, tokenIndex, depth = position117, tokenIndex117, depth117
+												goto l178
+											l179:
+												position, tokenIndex, depth = position179, tokenIndex179, depth179
 											}
 											if !rules[RuleDoubleChar]() {
-												goto l116
+												goto l178
 											}
 											{
 
-												add(RuleAction25, position)
+												add(RuleAction38, position)
 											}
-											goto l115
-										l116:
-											position, tokenIndex, depth = position116, tokenIndex116, depth116
+											goto l177
+										l178:
+											position, tokenIndex, depth = position178, tokenIndex178, depth178
 										}
 										if buffer[position] != rune('"') {
-											goto l73
+											goto l126
 										}
 										position++
 										if !rules[Rule_]() {
-											goto l73
+											goto l126
 										}
 									}
-								l103:
+								l165:
 									depth--
-									add(RuleLiteral, position102)
+									add(RuleLiteral, position164)
 								}
 								break
 							case '(':
-								{
-
-									position119 := position
-									depth++
-									if buffer[position] != rune('(') {
-										goto l73
-									}
-									position++
-									if !rules[Rule_]() {
-										goto l73
-									}
-									depth--
-									add(RuleOpen, position119)
+								if !rules[RuleOpen]() {
+									goto l126
 								}
 								if !rules[RuleExpression]() {
-									goto l73
+									goto l126
+								}
+								if !rules[RuleClose]() {
+									goto l126
+								}
+								break
+							case '=':
+								if !rules[RuleEqual]() {
+									goto l126
+								}
+								if !rules[RuleIdentifier]() {
+									goto l126
 								}
 								{
 
-									position120 := position
-									depth++
-									if buffer[position] != rune(')') {
-										goto l73
-									}
-									position++
-									if !rules[Rule_]() {
-										goto l73
-									}
-									depth--
-									add(RuleClose, position120)
+									add(RuleAction29, position)
 								}
 								break
 							default:
+								{
+
+									position182, tokenIndex182, depth182 := position, tokenIndex, depth
+									if !rules[RuleCall]() {
+										goto l182
+									}
+									goto l126
+								l182:
+									position, tokenIndex, depth = position182, tokenIndex182, depth182
+								}
 								if !rules[RuleIdentifier]() {
-									goto l73
+									goto l126
 								}
 								{
 
-									position121, tokenIndex121, depth121 := position, tokenIndex, depth
+									position183, tokenIndex183, depth183 := position, tokenIndex, depth
 									if !rules[RuleEqual]() {
-										goto l121
+										goto l183
 									}
-									goto l73
-								l121:
-									position, tokenIndex, depth = position121, tokenIndex121, depth121
+									goto l126
+								l183:
+									position, tokenIndex, depth = position183, tokenIndex183, depth183
 								}
 								{
 
-									add(RuleAction20, position)
+									add(RuleAction33, position)
 								}
 								break
 							}
 						}
 
 					}
-				l76:
+				l129:
 					depth--
-					add(RulePrimary, position75)
+					add(RulePrimary, position128)
 				}
 				{
 
-					position123, tokenIndex123, depth123 := position, tokenIndex, depth
+					position185, tokenIndex185, depth185 := position, tokenIndex, depth
 					{
 
 						switch buffer[position] {
+						case '{':
+							{
+
+								position188 := position
+								depth++
+								if buffer[position] != rune('{') {
+									goto l185
+								}
+								position++
+								{
+
+									position189 := position
+									depth++
+								l190:
+									{
+
+										position191, tokenIndex191, depth191 := position, tokenIndex, depth
+										{
+
+											position192, tokenIndex192, depth192 := position, tokenIndex, depth
+											if buffer[position] != rune(' ') {
+												goto l193
+											}
+											position++
+											goto l192
+										l193:
+											position, tokenIndex, depth = position192, tokenIndex192, depth192
+											if buffer[position] != rune('\t') {
+												goto l191
+											}
+											position++
+										}
+									l192:
+										goto l190
+									l191:
+										position, tokenIndex, depth = position191, tokenIndex191, depth191
+									}
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l185
+									}
+									position++
+								l194:
+									{
+
+										position195, tokenIndex195, depth195 := position, tokenIndex, depth
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l195
+										}
+										position++
+										goto l194
+									l195:
+										position, tokenIndex, depth = position195, tokenIndex195, depth195
+									}
+								l196:
+									{
+
+										position197, tokenIndex197, depth197 := position, tokenIndex, depth
+										{
+
+											position198, tokenIndex198, depth198 := position, tokenIndex, depth
+											if buffer[position] != rune(' ') {
+												goto l199
+											}
+											position++
+											goto l198
+										l199:
+											position, tokenIndex, depth = position198, tokenIndex198, depth198
+											if buffer[position] != rune('\t') {
+												goto l197
+											}
+											position++
+										}
+									l198:
+										goto l196
+									l197:
+										position, tokenIndex, depth = position197, tokenIndex197, depth197
+									}
+									{
+
+										position200, tokenIndex200, depth200 := position, tokenIndex, depth
+										if buffer[position] != rune(',') {
+											goto l200
+										}
+										position++
+									l202:
+										{
+
+											position203, tokenIndex203, depth203 := position, tokenIndex, depth
+											{
+
+												position204, tokenIndex204, depth204 := position, tokenIndex, depth
+												if buffer[position] != rune(' ') {
+													goto l205
+												}
+												position++
+												goto l204
+											l205:
+												position, tokenIndex, depth = position204, tokenIndex204, depth204
+												if buffer[position] != rune('\t') {
+													goto l203
+												}
+												position++
+											}
+										l204:
+											goto l202
+										l203:
+											position, tokenIndex, depth = position203, tokenIndex203, depth203
+										}
+									l206:
+										{
+
+											position207, tokenIndex207, depth207 := position, tokenIndex, depth
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l207
+											}
+											position++
+											goto l206
+										l207:
+											position, tokenIndex, depth = position207, tokenIndex207, depth207
+										}
+									l208:
+										{
+
+											position209, tokenIndex209, depth209 := position, tokenIndex, depth
+											{
+
+												position210, tokenIndex210, depth210 := position, tokenIndex, depth
+												if buffer[position] != rune(' ') {
+													goto l211
+												}
+												position++
+												goto l210
+											l211:
+												position, tokenIndex, depth = position210, tokenIndex210, depth210
+												if buffer[position] != rune('\t') {
+													goto l209
+												}
+												position++
+											}
+										l210:
+											goto l208
+										l209:
+											position, tokenIndex, depth = position209, tokenIndex209, depth209
+										}
+										goto l201
+									l200:
+										position, tokenIndex, depth = position200, tokenIndex200, depth200
+									}
+								l201:
+									depth--
+									add(RulePegText, position189)
+								}
+								if buffer[position] != rune('}') {
+									goto l185
+								}
+								position++
+								if !rules[Rule_]() {
+									goto l185
+								}
+								depth--
+								add(RuleRepeat, position188)
+							}
+							{
+
+								add(RuleAction24, position)
+							}
+							break
 						case '+':
 							{
 
-								position126 := position
+								position213 := position
 								depth++
 								if buffer[position] != rune('+') {
-									goto l123
+									goto l185
 								}
 								position++
 								if !rules[Rule_]() {
-									goto l123
+									goto l185
 								}
 								depth--
-								add(RulePlus, position126)
+								add(RulePlus, position213)
 							}
 							{
 
-								add(RuleAction17, position)
+								add(RuleAction23, position)
 							}
 							break
 						case '*':
 							{
 
-								position128 := position
+								position215 := position
 								depth++
 								if buffer[position] != rune('*') {
-									goto l123
+									goto l185
 								}
 								position++
 								if !rules[Rule_]() {
-									goto l123
+									goto l185
 								}
 								depth--
-								add(RuleStar, position128)
+								add(RuleStar, position215)
 							}
 							{
 
-								add(RuleAction16, position)
+								add(RuleAction22, position)
 							}
 							break
 						default:
 							{
 
-								position130 := position
-								depth++
-								if buffer[position] != rune('?') {
-									goto l123
-								}
-								position++
-								if !rules[Rule_]() {
-									goto l123
-								}
-								depth--
-								add(RuleQuestion, position130)
-							}
-							{
+								position217 := position
+								depth++
+								if buffer[position] != rune('?') {
+									goto l185
+								}
+								position++
+								if !rules[Rule_]() {
+									goto l185
+								}
+								depth--
+								add(RuleQuestion, position217)
+							}
+							{
+
+								add(RuleAction21, position)
+							}
+							break
+						}
+					}
+
+					goto l186
+				l185:
+					position, tokenIndex, depth = position185, tokenIndex185, depth185
+				}
+			l186:
+				depth--
+				add(RuleSuffix, position127)
+			}
+			return true
+		l126:
+			position, tokenIndex, depth = position126, tokenIndex126, depth126
+			return false
+		},
+		/* 11 Primary <- <((Identifier Action25 Colon Identifier !Equal Action26) / (Identifier Action27 Colon Begin Expression End Action28) / (Call Action30 Expression Action31 (Comma Expression Action32)* Close !Equal) / ((&('<') (Begin Expression End Action36)) | (&('{') (Action Action35)) | (&('.') (Dot Action34)) | (&('[') Class) | (&('"' | '\'') Literal) | (&('(') (Open Expression Close)) | (&('=') (Equal Identifier Action29)) | (&('-' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') (!Call Identifier !Equal Action33))))> (leg.leg:66) */
+		nil,
+		/* 12 Identifier <- <(<(((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('-') '-') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])) ((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('-') '-') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> _)> (leg.leg:85) */
+		func() bool {
+			position220, tokenIndex220, depth220 := position, tokenIndex, depth
+			{
+
+				position221 := position
+				depth++
+				{
+
+					position222 := position
+					depth++
+					{
+
+						switch buffer[position] {
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l220
+							}
+							position++
+							break
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l220
+							}
+							position++
+							break
+						case '-':
+							if buffer[position] != rune('-') {
+								goto l220
+							}
+							position++
+							break
+						default:
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l220
+							}
+							position++
+							break
+						}
+					}
+
+				l224:
+					{
+
+						position225, tokenIndex225, depth225 := position, tokenIndex, depth
+						{
+
+							switch buffer[position] {
+							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l225
+								}
+								position++
+								break
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l225
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l225
+								}
+								position++
+								break
+							case '-':
+								if buffer[position] != rune('-') {
+									goto l225
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l225
+								}
+								position++
+								break
+							}
+						}
+
+						goto l224
+					l225:
+						position, tokenIndex, depth = position225, tokenIndex225, depth225
+					}
+					depth--
+					add(RulePegText, position222)
+				}
+				if !rules[Rule_]() {
+					goto l220
+				}
+				depth--
+				add(RuleIdentifier, position221)
+			}
+			return true
+		l220:
+			position, tokenIndex, depth = position220, tokenIndex220, depth220
+			return false
+		},
+		/* 13 Call <- <(<(((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('-') '-') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])) ((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('-') '-') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> Open)> (leg.leg:86) */
+		func() bool {
+			position227, tokenIndex227, depth227 := position, tokenIndex, depth
+			{
+
+				position228 := position
+				depth++
+				{
+
+					position229 := position
+					depth++
+					{
+
+						switch buffer[position] {
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l227
+							}
+							position++
+							break
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l227
+							}
+							position++
+							break
+						case '-':
+							if buffer[position] != rune('-') {
+								goto l227
+							}
+							position++
+							break
+						default:
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l227
+							}
+							position++
+							break
+						}
+					}
+
+				l231:
+					{
+
+						position232, tokenIndex232, depth232 := position, tokenIndex, depth
+						{
+
+							switch buffer[position] {
+							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l232
+								}
+								position++
+								break
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l232
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l232
+								}
+								position++
+								break
+							case '-':
+								if buffer[position] != rune('-') {
+									goto l232
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l232
+								}
+								position++
+								break
+							}
+						}
+
+						goto l231
+					l232:
+						position, tokenIndex, depth = position232, tokenIndex232, depth232
+					}
+					depth--
+					add(RulePegText, position229)
+				}
+				if !rules[RuleOpen]() {
+					goto l227
+				}
+				depth--
+				add(RuleCall, position228)
+			}
+			return true
+		l227:
+			position, tokenIndex, depth = position227, tokenIndex227, depth227
+			return false
+		},
+		/* 14 Literal <- <(('\'' (!'\'' Char)? (!'\'' Char Action37)* '\'' _) / ('"' (!'"' DoubleChar)? (!'"' DoubleChar Action38)* '"' _))> (leg.leg:87) */
+		nil,
+		/* 15 Class <- <(<(('[' '[' (('^' DoubleRanges Action39) / DoubleRanges)? (']' ']')) / ('[' (('^' ClassSet Action40) / ClassSet)? ']'))> Action41 _)> (leg.leg:91) */
+		nil,
+		/* 16 ClassSet <- <(Ranges (('-' '-' Operands Action42) / ('&' '&' Operands Action43))*)> (leg.leg:98) */
+		func() bool {
+			position236, tokenIndex236, depth236 := position, tokenIndex, depth
+			{
+
+				position237 := position
+				depth++
+				{
+
+					position238 := position
+					depth++
+					{
+
+						position239, tokenIndex239, depth239 := position, tokenIndex, depth
+						if buffer[position] != rune(']') {
+							goto l239
+						}
+						position++
+						goto l236
+					l239:
+						position, tokenIndex, depth = position239, tokenIndex239, depth239
+					}
+					if !rules[RuleRange]() {
+						goto l236
+					}
+				l240:
+					{
+
+						position241, tokenIndex241, depth241 := position, tokenIndex, depth
+						{
+
+							position242, tokenIndex242, depth242 := position, tokenIndex, depth
+							if buffer[position] != rune(']') {
+								goto l242
+							}
+							position++
+							goto l241
+						l242:
+							position, tokenIndex, depth = position242, tokenIndex242, depth242
+						}
+						{
+
+							position243, tokenIndex243, depth243 := position, tokenIndex, depth
+							if buffer[position] != rune('-') {
+								goto l243
+							}
+							position++
+							if buffer[position] != rune('-') {
+								goto l243
+							}
+							position++
+							goto l241
+						l243:
+							position, tokenIndex, depth = position243, tokenIndex243, depth243
+						}
+						{
+
+							position244, tokenIndex244, depth244 := position, tokenIndex, depth
+							if buffer[position] != rune('&') {
+								goto l244
+							}
+							position++
+							if buffer[position] != rune('&') {
+								goto l244
+							}
+							position++
+							goto l241
+						l244:
+							position, tokenIndex, depth = position244, tokenIndex244, depth244
+						}
+						if !rules[RuleRange]() {
+							goto l241
+						}
+						{
+
+							add(RuleAction44, position)
+						}
+						goto l240
+					l241:
+						position, tokenIndex, depth = position241, tokenIndex241, depth241
+					}
+					depth--
+					add(RuleRanges, position238)
+				}
+			l246:
+				{
+
+					position247, tokenIndex247, depth247 := position, tokenIndex, depth
+					{
+
+						position248, tokenIndex248, depth248 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l249
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l249
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l249
+						}
+						{
+
+							add(RuleAction42, position)
+						}
+						goto l248
+					l249:
+						position, tokenIndex, depth = position248, tokenIndex248, depth248
+						if buffer[position] != rune('&') {
+							goto l247
+						}
+						position++
+						if buffer[position] != rune('&') {
+							goto l247
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l247
+						}
+						{
+
+							add(RuleAction43, position)
+						}
+					}
+				l248:
+					goto l246
+				l247:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+				}
+				depth--
+				add(RuleClassSet, position237)
+			}
+			return true
+		l236:
+			position, tokenIndex, depth = position236, tokenIndex236, depth236
+			return false
+		},
+		/* 17 Ranges <- <(!']' Range (!']' !('-' '-') !('&' '&') Range Action44)*)> (leg.leg:101) */
+		nil,
+		/* 18 Operands <- <(!']' Operand (!']' !('-' '-') !('&' '&') Operand Action45)*)> (leg.leg:103) */
+		func() bool {
+			position253, tokenIndex253, depth253 := position, tokenIndex, depth
+			{
+
+				position254 := position
+				depth++
+				{
+
+					position255, tokenIndex255, depth255 := position, tokenIndex, depth
+					if buffer[position] != rune(']') {
+						goto l255
+					}
+					position++
+					goto l253
+				l255:
+					position, tokenIndex, depth = position255, tokenIndex255, depth255
+				}
+				if !rules[RuleOperand]() {
+					goto l253
+				}
+			l256:
+				{
+
+					position257, tokenIndex257, depth257 := position, tokenIndex, depth
+					{
+
+						position258, tokenIndex258, depth258 := position, tokenIndex, depth
+						if buffer[position] != rune(']') {
+							goto l258
+						}
+						position++
+						goto l257
+					l258:
+						position, tokenIndex, depth = position258, tokenIndex258, depth258
+					}
+					{
+
+						position259, tokenIndex259, depth259 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l259
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l259
+						}
+						position++
+						goto l257
+					l259:
+						position, tokenIndex, depth = position259, tokenIndex259, depth259
+					}
+					{
 
-								add(RuleAction15, position)
-							}
-							break
+						position260, tokenIndex260, depth260 := position, tokenIndex, depth
+						if buffer[position] != rune('&') {
+							goto l260
+						}
+						position++
+						if buffer[position] != rune('&') {
+							goto l260
 						}
+						position++
+						goto l257
+					l260:
+						position, tokenIndex, depth = position260, tokenIndex260, depth260
 					}
+					if !rules[RuleOperand]() {
+						goto l257
+					}
+					{
 
-					goto l124
-				l123:
-					position, tokenIndex, depth = position123, tokenIndex123, depth123
+						add(RuleAction45, position)
+					}
+					goto l256
+				l257:
+					position, tokenIndex, depth = position257, tokenIndex257, depth257
 				}
-			l124:
 				depth--
-				add(RuleSuffix, position74)
+				add(RuleOperands, position254)
 			}
 			return true
-		l73:
-			position, tokenIndex, depth = position73, tokenIndex73, depth73
+		l253:
+			position, tokenIndex, depth = position253, tokenIndex253, depth253
 			return false
 		},
-		/* 8 Primary <- <((Identifier Action18 Colon Identifier !Equal Action19) / ((&('<') (Begin Expression End Action23)) | (&('{') (Action Action22)) | (&('.') (Dot Action21)) | (&('[') Class) | (&('"' | '\'') Literal) | (&('(') (Open Expression Close)) | (&('-' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') (Identifier !Equal Action20))))> */
-		nil,
-		/* 9 Identifier <- <(<(((&('_') '_') | (&('-') '-') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') ([a-z] / [A-Z]))) ((&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('-') '-') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') ([a-z] / [A-Z])))*)> _)> */
+		/* 19 Operand <- <(('[' (('^' NestedSet Action46) / NestedSet) ']') / Range)> (leg.leg:105) */
 		func() bool {
-			position133, tokenIndex133, depth133 := position, tokenIndex, depth
+			position262, tokenIndex262, depth262 := position, tokenIndex, depth
 			{
 
-				position134 := position
+				position263 := position
 				depth++
 				{
 
-					position135 := position
-					depth++
-					{
-
-						switch buffer[position] {
-						case '_':
-							if buffer[position] != rune('_') {
-								goto l133
-							}
-							position++
-							break
-						case '-':
-							if buffer[position] != rune('-') {
-								goto l133
-							}
-							position++
-							break
-						default:
-							{
-
-								position137, tokenIndex137, depth137 := position, tokenIndex, depth
-								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l138
-								}
-								position++
-								goto l137
-							l138:
-								position, tokenIndex, depth = position137, tokenIndex137, depth137
-								if c := buffer[position]; c < rune('A') || c > rune('Z') {
-									goto l133
-								}
-								position++
-							}
-						l137:
-							break
-						}
+					position264, tokenIndex264, depth264 := position, tokenIndex, depth
+					if buffer[position] != rune('[') {
+						goto l265
 					}
-
-				l139:
+					position++
 					{
 
-						position140, tokenIndex140, depth140 := position, tokenIndex, depth
+						position266, tokenIndex266, depth266 := position, tokenIndex, depth
+						if buffer[position] != rune('^') {
+							goto l267
+						}
+						position++
+						if !rules[RuleNestedSet]() {
+							goto l267
+						}
 						{
 
-							switch buffer[position] {
-							case '_':
-								if buffer[position] != rune('_') {
-									goto l140
-								}
-								position++
-								break
-							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l140
-								}
-								position++
-								break
-							case '-':
-								if buffer[position] != rune('-') {
-									goto l140
-								}
-								position++
-								break
-							default:
-								{
-
-									position142, tokenIndex142, depth142 := position, tokenIndex, depth
-									if c := buffer[position]; c < rune('a') || c > rune('z') {
-										goto l143
-									}
-									position++
-									goto l142
-								l143:
-									position, tokenIndex, depth = position142, tokenIndex142, depth142
-									if c := buffer[position]; c < rune('A') || c > rune('Z') {
-										goto l140
-									}
-									position++
-								}
-							l142:
-								break
-							}
+							add(RuleAction46, position)
+						}
+						goto l266
+					l267:
+						position, tokenIndex, depth = position266, tokenIndex266, depth266
+						if !rules[RuleNestedSet]() {
+							goto l265
 						}
-
-						goto l139
-					l140:
-						position, tokenIndex, depth = position140, tokenIndex140, depth140
 					}
-					depth--
-					add(RulePegText, position135)
-				}
-				if !rules[Rule_]() {
-					goto l133
+				l266:
+					if buffer[position] != rune(']') {
+						goto l265
+					}
+					position++
+					goto l264
+				l265:
+					position, tokenIndex, depth = position264, tokenIndex264, depth264
+					if !rules[RuleRange]() {
+						goto l262
+					}
 				}
+			l264:
 				depth--
-				add(RuleIdentifier, position134)
+				add(RuleOperand, position263)
 			}
 			return true
-		l133:
-			position, tokenIndex, depth = position133, tokenIndex133, depth133
+		l262:
+			position, tokenIndex, depth = position262, tokenIndex262, depth262
 			return false
 		},
-		/* 10 Literal <- <(('\'' (!'\'' Char)? (!'\'' Char Action24)* '\'' _) / ('"' (!'"' DoubleChar)? (!'"' DoubleChar Action25)* '"' _))> */
-		nil,
-		/* 11 Class <- <((('[' '[' (('^' DoubleRanges Action26) / DoubleRanges)? (']' ']')) / ('[' (('^' Ranges Action27) / Ranges)? ']')) _)> */
-		nil,
-		/* 12 Ranges <- <(!']' Range (!']' Range Action28)*)> */
+		/* 20 NestedSet <- <(Operands (('-' '-' Operands Action47) / ('&' '&' Operands Action48))*)> (leg.leg:108) */
 		func() bool {
-			position146, tokenIndex146, depth146 := position, tokenIndex, depth
+			position269, tokenIndex269, depth269 := position, tokenIndex, depth
 			{
 
-				position147 := position
+				position270 := position
 				depth++
-				{
-
-					position148, tokenIndex148, depth148 := position, tokenIndex, depth
-					if buffer[position] != rune(']') {
-						goto l148
-					}
-					position++
-					goto l146
-				l148:
-					position, tokenIndex, depth = position148, tokenIndex148, depth148
-				}
-				if !rules[RuleRange]() {
-					goto l146
+				if !rules[RuleOperands]() {
+					goto l269
 				}
-			l149:
+			l271:
 				{
 
-					position150, tokenIndex150, depth150 := position, tokenIndex, depth
+					position272, tokenIndex272, depth272 := position, tokenIndex, depth
 					{
 
-						position151, tokenIndex151, depth151 := position, tokenIndex, depth
-						if buffer[position] != rune(']') {
-							goto l151
+						position273, tokenIndex273, depth273 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l274
 						}
 						position++
-						goto l150
-					l151:
-						position, tokenIndex, depth = position151, tokenIndex151, depth151
-					}
-					if !rules[RuleRange]() {
-						goto l150
-					}
-					{
+						if buffer[position] != rune('-') {
+							goto l274
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l274
+						}
+						{
+
+							add(RuleAction47, position)
+						}
+						goto l273
+					l274:
+						position, tokenIndex, depth = position273, tokenIndex273, depth273
+						if buffer[position] != rune('&') {
+							goto l272
+						}
+						position++
+						if buffer[position] != rune('&') {
+							goto l272
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l272
+						}
+						{
 
-						add(RuleAction28, position)
+							add(RuleAction48, position)
+						}
 					}
-					goto l149
-				l150:
-					position, tokenIndex, depth = position150, tokenIndex150, depth150
+				l273:
+					goto l271
+				l272:
+					position, tokenIndex, depth = position272, tokenIndex272, depth272
 				}
 				depth--
-				add(RuleRanges, position147)
+				add(RuleNestedSet, position270)
 			}
 			return true
-		l146:
-			position, tokenIndex, depth = position146, tokenIndex146, depth146
+		l269:
+			position, tokenIndex, depth = position269, tokenIndex269, depth269
 			return false
 		},
-		/* 13 DoubleRanges <- <(!(']' ']') DoubleRange (!(']' ']') DoubleRange Action29)*)> */
+		/* 21 DoubleRanges <- <(!(']' ']') DoubleRange (!(']' ']') DoubleRange Action49)*)> (leg.leg:111) */
 		func() bool {
-			position153, tokenIndex153, depth153 := position, tokenIndex, depth
+			position277, tokenIndex277, depth277 := position, tokenIndex, depth
 			{
 
-				position154 := position
+				position278 := position
 				depth++
 				{
 
-					position155, tokenIndex155, depth155 := position, tokenIndex, depth
+					position279, tokenIndex279, depth279 := position, tokenIndex, depth
 					if buffer[position] != rune(']') {
-						goto l155
+						goto l279
 					}
 					position++
 					if buffer[position] != rune(']') {
-						goto l155
+						goto l279
 					}
 					position++
-					goto l153
-				l155:
-					position, tokenIndex, depth = position155, tokenIndex155, depth155
+					goto l277
+				l279:
+					position, tokenIndex, depth = position279, tokenIndex279, depth279
 				}
 				if !rules[RuleDoubleRange]() {
-					goto l153
+					goto l277
 				}
-			l156:
+			l280:
 				{
 
-					position157, tokenIndex157, depth157 := position, tokenIndex, depth
+					position281, tokenIndex281, depth281 := position, tokenIndex, depth
 					{
 
-						position158, tokenIndex158, depth158 := position, tokenIndex, depth
+						position282, tokenIndex282, depth282 := position, tokenIndex, depth
 						if buffer[position] != rune(']') {
-							goto l158
+							goto l282
 						}
 						position++
 						if buffer[position] != rune(']') {
-							goto l158
+							goto l282
 						}
 						position++
-						goto l157
-					l158:
-						position, tokenIndex, depth = position158, tokenIndex158, depth158
+						goto l281
+					l282:
+						position, tokenIndex, depth = position282, tokenIndex282, depth282
 					}
 					if !rules[RuleDoubleRange]() {
-						goto l157
+						goto l281
 					}
 					{
 
-						add(RuleAction29, position)
+						add(RuleAction49, position)
 					}
-					goto l156
-				l157:
-					position, tokenIndex, depth = position157, tokenIndex157, depth157
+					goto l280
+				l281:
+					position, tokenIndex, depth = position281, tokenIndex281, depth281
 				}
 				depth--
-				add(RuleDoubleRanges, position154)
+				add(RuleDoubleRanges, position278)
 			}
 			return true
-		l153:
-			position, tokenIndex, depth = position153, tokenIndex153, depth153
+		l277:
+			position, tokenIndex, depth = position277, tokenIndex277, depth277
 			return false
 		},
-		/* 14 Range <- <((Char '-' Char Action30) / Char)> */
+		/* 22 Range <- <(('\\' 'p' '{' <((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> '}' Action50) / ('\\' 'P' '{' <((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> '}' Action51) / (Char !('-' '-') '-' Char Action52) / Char)> (leg.leg:113) */
 		func() bool {
-			position160, tokenIndex160, depth160 := position, tokenIndex, depth
+			position284, tokenIndex284, depth284 := position, tokenIndex, depth
 			{
 
-				position161 := position
+				position285 := position
 				depth++
 				{
 
-					position162, tokenIndex162, depth162 := position, tokenIndex, depth
+					position286, tokenIndex286, depth286 := position, tokenIndex, depth
+					if buffer[position] != rune('\\') {
+						goto l287
+					}
+					position++
+					if buffer[position] != rune('p') {
+						goto l287
+					}
+					position++
+					if buffer[position] != rune('{') {
+						goto l287
+					}
+					position++
+					{
+
+						position288 := position
+						depth++
+						{
+
+							switch buffer[position] {
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l287
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l287
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l287
+								}
+								position++
+								break
+							}
+						}
+
+					l289:
+						{
+
+							position290, tokenIndex290, depth290 := position, tokenIndex, depth
+							{
+
+								switch buffer[position] {
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l290
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l290
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l290
+									}
+									position++
+									break
+								}
+							}
+
+							goto l289
+						l290:
+							position, tokenIndex, depth = position290, tokenIndex290, depth290
+						}
+						depth--
+						add(RulePegText, position288)
+					}
+					if buffer[position] != rune('}') {
+						goto l287
+					}
+					position++
+					{
+
+						add(RuleAction50, position)
+					}
+					goto l286
+				l287:
+					position, tokenIndex, depth = position286, tokenIndex286, depth286
+					if buffer[position] != rune('\\') {
+						goto l294
+					}
+					position++
+					if buffer[position] != rune('P') {
+						goto l294
+					}
+					position++
+					if buffer[position] != rune('{') {
+						goto l294
+					}
+					position++
+					{
+
+						position295 := position
+						depth++
+						{
+
+							switch buffer[position] {
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l294
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l294
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l294
+								}
+								position++
+								break
+							}
+						}
+
+					l296:
+						{
+
+							position297, tokenIndex297, depth297 := position, tokenIndex, depth
+							{
+
+								switch buffer[position] {
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l297
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l297
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l297
+									}
+									position++
+									break
+								}
+							}
+
+							goto l296
+						l297:
+							position, tokenIndex, depth = position297, tokenIndex297, depth297
+						}
+						depth--
+						add(RulePegText, position295)
+					}
+					if buffer[position] != rune('}') {
+						goto l294
+					}
+					position++
+					{
+
+						add(RuleAction51, position)
+					}
+					goto l286
+				l294:
+					position, tokenIndex, depth = position286, tokenIndex286, depth286
 					if !rules[RuleChar]() {
-						goto l163
+						goto l301
+					}
+					{
+
+						position302, tokenIndex302, depth302 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l302
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l302
+						}
+						position++
+						goto l301
+					l302:
+						position, tokenIndex, depth = position302, tokenIndex302, depth302
 					}
 					if buffer[position] != rune('-') {
-						goto l163
+						goto l301
 					}
 					position++
 					if !rules[RuleChar]() {
-						goto l163
+						goto l301
 					}
 					{
 
-						add(RuleAction30, position)
+						add(RuleAction52, position)
 					}
-					goto l162
-				l163:
-					position, tokenIndex, depth = position162, tokenIndex162, depth162
+					goto l286
+				l301:
+					position, tokenIndex, depth = position286, tokenIndex286, depth286
 					if !rules[RuleChar]() {
-						goto l160
+						goto l284
 					}
 				}
-			l162:
+			l286:
 				depth--
-				add(RuleRange, position161)
+				add(RuleRange, position285)
 			}
 			return true
-		l160:
-			position, tokenIndex, depth = position160, tokenIndex160, depth160
+		l284:
+			position, tokenIndex, depth = position284, tokenIndex284, depth284
 			return false
 		},
-		/* 15 DoubleRange <- <((Char '-' Char Action31) / DoubleChar)> */
+		/* 23 DoubleRange <- <((Char '-' Char Action53) / DoubleChar)> (leg.leg:117) */
 		func() bool {
-			position165, tokenIndex165, depth165 := position, tokenIndex, depth
+			position304, tokenIndex304, depth304 := position, tokenIndex, depth
 			{
 
-				position166 := position
+				position305 := position
 				depth++
 				{
 
-					position167, tokenIndex167, depth167 := position, tokenIndex, depth
+					position306, tokenIndex306, depth306 := position, tokenIndex, depth
 					if !rules[RuleChar]() {
-						goto l168
+						goto l307
 					}
 					if buffer[position] != rune('-') {
-						goto l168
+						goto l307
 					}
 					position++
 					if !rules[RuleChar]() {
-						goto l168
+						goto l307
 					}
 					{
 
-						add(RuleAction31, position)
+						add(RuleAction53, position)
 					}
-					goto l167
-				l168:
-					position, tokenIndex, depth = position167, tokenIndex167, depth167
+					goto l306
+				l307:
+					position, tokenIndex, depth = position306, tokenIndex306, depth306
 					if !rules[RuleDoubleChar]() {
-						goto l165
+						goto l304
 					}
 				}
-			l167:
+			l306:
 				depth--
-				add(RuleDoubleRange, position166)
+				add(RuleDoubleRange, position305)
 			}
 			return true
-		l165:
-			position, tokenIndex, depth = position165, tokenIndex165, depth165
+		l304:
+			position, tokenIndex, depth = position304, tokenIndex304, depth304
 			return false
 		},
-		/* 16 Char <- <(Escape / (!'\\' <.> Action32))> */
+		/* 24 Char <- <(Escape / (!'\\' <.> Action54))> (leg.leg:119) */
 		func() bool {
-			position170, tokenIndex170, depth170 := position, tokenIndex, depth
+			position309, tokenIndex309, depth309 := position, tokenIndex, depth
 			{
 
-				position171 := position
+				position310 := position
 				depth++
 				{
 
-					position172, tokenIndex172, depth172 := position, tokenIndex, depth
+					position311, tokenIndex311, depth311 := position, tokenIndex, depth
 					if !rules[RuleEscape]() {
-						goto l173
+						goto l312
 					}
-					goto l172
-				l173:
-					position, tokenIndex, depth = position172, tokenIndex172, depth172
+					goto l311
+				l312:
+					position, tokenIndex, depth = position311, tokenIndex311, depth311
 					{
 
-						position174, tokenIndex174, depth174 := position, tokenIndex, depth
+						position313, tokenIndex313, depth313 := position, tokenIndex, depth
 						if buffer[position] != rune('\\') {
-							goto l174
+							goto l313
 						}
 						position++
-						goto l170
-					l174:
-						position, tokenIndex, depth = position174, tokenIndex174, depth174
+						goto l309
+					l313:
+						position, tokenIndex, depth = position313, tokenIndex313, depth313
 					}
 					{
 
-						position175 := position
+						position314 := position
 						depth++
 						if !matchDot() {
-							goto l170
+							goto l309
 						}
 						depth--
-						add(RulePegText, position175)
+						add(RulePegText, position314)
 					}
 					{
 
-						add(RuleAction32, position)
+						add(RuleAction54, position)
 					}
 				}
-			l172:
+			l311:
 				depth--
-				add(RuleChar, position171)
+				add(RuleChar, position310)
 			}
 			return true
-		l170:
-			position, tokenIndex, depth = position170, tokenIndex170, depth170
+		l309:
+			position, tokenIndex, depth = position309, tokenIndex309, depth309
 			return false
 		},
-		/* 17 DoubleChar <- <(Escape / (<([a-z] / [A-Z])> Action33) / (!'\\' <.> Action34))> */
+		/* 25 DoubleChar <- <(Escape / (<([a-z] / [A-Z])> Action55) / (!'\\' <.> Action56))> (leg.leg:121) */
 		func() bool {
-			position177, tokenIndex177, depth177 := position, tokenIndex, depth
+			position316, tokenIndex316, depth316 := position, tokenIndex, depth
 			{
 
-				position178 := position
+				position317 := position
 				depth++
 				{
 
-					position179, tokenIndex179, depth179 := position, tokenIndex, depth
+					position318, tokenIndex318, depth318 := position, tokenIndex, depth
 					if !rules[RuleEscape]() {
-						goto l180
+						goto l319
 					}
-					goto l179
-				l180:
-					position, tokenIndex, depth = position179, tokenIndex179, depth179
+					goto l318
+				l319:
+					position, tokenIndex, depth = position318, tokenIndex318, depth318
 					{
 
-						position182 := position
+						position321 := position
 						depth++
 						{
 
-							position183, tokenIndex183, depth183 := position, tokenIndex, depth
+							position322, tokenIndex322, depth322 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l184
+								goto l323
 							}
 							position++
-							goto l183
-						l184:
-							position, tokenIndex, depth = position183, tokenIndex183, depth183
+							goto l322
+						l323:
+							position, tokenIndex, depth = position322, tokenIndex322, depth322
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l181
+								goto l320
 							}
 							position++
 						}
-					l183:
+					l322:
 						depth--
-						add(RulePegText, position182)
+						add(RulePegText, position321)
 					}
 					{
 
-						add(RuleAction33, position)
+						add(RuleAction55, position)
 					}
-					goto l179
-				l181:
-					position, tokenIndex, depth = position179, tokenIndex179, depth179
+					goto l318
+				l320:
+					position, tokenIndex, depth = position318, tokenIndex318, depth318
 					{
 
-						position186, tokenIndex186, depth186 := position, tokenIndex, depth
+						position325, tokenIndex325, depth325 := position, tokenIndex, depth
 						if buffer[position] != rune('\\') {
-							goto l186
+							goto l325
 						}
 						position++
-						goto l177
-					l186:
-						position, tokenIndex, depth = position186, tokenIndex186, depth186
+						goto l316
+					l325:
+						position, tokenIndex, depth = position325, tokenIndex325, depth325
 					}
 					{
 
-						position187 := position
+						position326 := position
 						depth++
 						if !matchDot() {
-							goto l177
+							goto l316
 						}
 						depth--
-						add(RulePegText, position187)
+						add(RulePegText, position326)
 					}
 					{
 
-						add(RuleAction34, position)
+						add(RuleAction56, position)
+					}
+				}
+			l318:
+				depth--
+				add(RuleDoubleChar, position317)
+			}
+			return true
+		l316:
+			position, tokenIndex, depth = position316, tokenIndex316, depth316
+			return false
+		},
+		/* 26 Hex <- <((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]))> (leg.leg:124) */
+		func() bool {
+			position328, tokenIndex328, depth328 := position, tokenIndex, depth
+			{
+
+				position329 := position
+				depth++
+				{
+
+					switch buffer[position] {
+					case 'A', 'B', 'C', 'D', 'E', 'F':
+						if c := buffer[position]; c < rune('A') || c > rune('F') {
+							goto l328
+						}
+						position++
+						break
+					case 'a', 'b', 'c', 'd', 'e', 'f':
+						if c := buffer[position]; c < rune('a') || c > rune('f') {
+							goto l328
+						}
+						position++
+						break
+					default:
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l328
+						}
+						position++
+						break
 					}
 				}
-			l179:
+
 				depth--
-				add(RuleDoubleChar, position178)
+				add(RuleHex, position329)
 			}
 			return true
-		l177:
-			position, tokenIndex, depth = position177, tokenIndex177, depth177
+		l328:
+			position, tokenIndex, depth = position328, tokenIndex328, depth328
 			return false
 		},
-		/* 18 Escape <- <(('\\' ('a' / 'A') Action35) / ('\\' ('b' / 'B') Action36) / ('\\' ('e' / 'E') Action37) / ('\\' ('f' / 'F') Action38) / ('\\' ('n' / 'N') Action39) / ('\\' ('r' / 'R') Action40) / ('\\' ('t' / 'T') Action41) / ('\\' ('v' / 'V') Action42) / ('\\' '\'' Action43) / ('\\' '"' Action44) / ('\\' '[' Action45) / ('\\' ']' Action46) / ('\\' '-' Action47) / ('\\' <([0-3] [0-7] [0-7])> Action48) / ('\\' <([0-7] [0-7]?)> Action49) / ('\\' '\\' Action50))> */
+		/* 27 Escape <- <(('\\' ('a' / 'A') Action57) / ('\\' ('b' / 'B') Action58) / ('\\' ('e' / 'E') Action59) / ('\\' ('f' / 'F') Action60) / ('\\' ('n' / 'N') Action61) / ('\\' ('r' / 'R') Action62) / ('\\' ('t' / 'T') Action63) / ('\\' ('v' / 'V') Action64) / ('\\' '\'' Action65) / ('\\' '"' Action66) / ('\\' '[' Action67) / ('\\' ']' Action68) / ('\\' '-' Action69) / ('\\' 'x' <(Hex Hex)> Action70) / ('\\' 'u' '{' <Hex+> '}' Action71) / ('\\' 'u' <(Hex Hex Hex Hex)> Action72) / ('\\' 'U' <(Hex Hex Hex Hex Hex Hex Hex Hex)> Action73) / ('\\' <([0-3] [0-7] [0-7])> Action74) / ('\\' <([0-7] [0-7]?)> Action75) / ('\\' '\\' Action76) / ('\\' <.> Action77))> (leg.leg:125) */
 		func() bool {
-			position189, tokenIndex189, depth189 := position, tokenIndex, depth
+			position331, tokenIndex331, depth331 := position, tokenIndex, depth
 			{
 
-				position190 := position
+				position332 := position
 				depth++
 				{
 
-					position191, tokenIndex191, depth191 := position, tokenIndex, depth
+					position333, tokenIndex333, depth333 := position, tokenIndex, depth
 					if buffer[position] != rune('\\') {
-						goto l192
+						goto l334
 					}
 					position++
 					{
 
-						position193, tokenIndex193, depth193 := position, tokenIndex, depth
+						position335, tokenIndex335, depth335 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l194
+							goto l336
 						}
 						position++
-						goto l193
-					l194:
-						position, tokenIndex, depth = position193, tokenIndex193, depth193
+						goto l335
+					l336:
+						position, tokenIndex, depth = position335, tokenIndex335, depth335
 						if buffer[position] != rune('A') {
-							goto l192
+							goto l334
 						}
 						position++
 					}
-				l193:
+				l335:
 					{
 
-						add(RuleAction35, position)
+						add(RuleAction57, position)
 					}
-					goto l191
-				l192:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l334:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l196
+						goto l338
 					}
 					position++
 					{
 
-						position197, tokenIndex197, depth197 := position, tokenIndex, depth
+						position339, tokenIndex339, depth339 := position, tokenIndex, depth
 						if buffer[position] != rune('b') {
-							goto l198
+							goto l340
 						}
 						position++
-						goto l197
-					l198:
-						position, tokenIndex, depth = position197, tokenIndex197, depth197
+						goto l339
+					l340:
+						position, tokenIndex, depth = position339, tokenIndex339, depth339
 						if buffer[position] != rune('B') {
-							goto l196
+							goto l338
 						}
 						position++
 					}
-				l197:
+				l339:
 					{
 
-						add(RuleAction36, position)
+						add(RuleAction58, position)
 					}
-					goto l191
-				l196:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l338:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l200
+						goto l342
 					}
 					position++
 					{
 
-						position201, tokenIndex201, depth201 := position, tokenIndex, depth
+						position343, tokenIndex343, depth343 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l202
+							goto l344
 						}
 						position++
-						goto l201
-					l202:
-						position, tokenIndex, depth = position201, tokenIndex201, depth201
+						goto l343
+					l344:
+						position, tokenIndex, depth = position343, tokenIndex343, depth343
 						if buffer[position] != rune('E') {
-							goto l200
+							goto l342
 						}
 						position++
 					}
-				l201:
+				l343:
 					{
 
-						add(RuleAction37, position)
+						add(RuleAction59, position)
 					}
-					goto l191
-				l200:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l342:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l204
+						goto l346
 					}
 					position++
 					{
 
-						position205, tokenIndex205, depth205 := position, tokenIndex, depth
+						position347, tokenIndex347, depth347 := position, tokenIndex, depth
 						if buffer[position] != rune('f') {
-							goto l206
+							goto l348
 						}
 						position++
-						goto l205
-					l206:
-						position, tokenIndex, depth = position205, tokenIndex205, depth205
+						goto l347
+					l348:
+						position, tokenIndex, depth = position347, tokenIndex347, depth347
 						if buffer[position] != rune('F') {
-							goto l204
+							goto l346
 						}
 						position++
 					}
-				l205:
+				l347:
 					{
 
-						add(RuleAction38, position)
+						add(RuleAction60, position)
 					}
-					goto l191
-				l204:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l346:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l208
+						goto l350
 					}
 					position++
 					{
 
-						position209, tokenIndex209, depth209 := position, tokenIndex, depth
+						position351, tokenIndex351, depth351 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l210
+							goto l352
 						}
 						position++
-						goto l209
-					l210:
-						position, tokenIndex, depth = position209, tokenIndex209, depth209
+						goto l351
+					l352:
+						position, tokenIndex, depth = position351, tokenIndex351, depth351
 						if buffer[position] != rune('N') {
-							goto l208
+							goto l350
 						}
 						position++
 					}
-				l209:
+				l351:
 					{
 
-						add(RuleAction39, position)
+						add(RuleAction61, position)
 					}
-					goto l191
-				l208:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l350:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l212
+						goto l354
 					}
 					position++
 					{
 
-						position213, tokenIndex213, depth213 := position, tokenIndex, depth
+						position355, tokenIndex355, depth355 := position, tokenIndex, depth
 						if buffer[position] != rune('r') {
-							goto l214
+							goto l356
 						}
 						position++
-						goto l213
-					l214:
-						position, tokenIndex, depth = position213, tokenIndex213, depth213
+						goto l355
+					l356:
+						position, tokenIndex, depth = position355, tokenIndex355, depth355
 						if buffer[position] != rune('R') {
-							goto l212
+							goto l354
 						}
 						position++
 					}
-				l213:
+				l355:
 					{
 
-						add(RuleAction40, position)
+						add(RuleAction62, position)
 					}
-					goto l191
-				l212:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l354:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l216
+						goto l358
 					}
 					position++
 					{
 
-						position217, tokenIndex217, depth217 := position, tokenIndex, depth
+						position359, tokenIndex359, depth359 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l218
+							goto l360
 						}
 						position++
-						goto l217
-					l218:
-						position, tokenIndex, depth = position217, tokenIndex217, depth217
+						goto l359
+					l360:
+						position, tokenIndex, depth = position359, tokenIndex359, depth359
 						if buffer[position] != rune('T') {
-							goto l216
+							goto l358
 						}
 						position++
 					}
-				l217:
+				l359:
 					{
 
-						add(RuleAction41, position)
+						add(RuleAction63, position)
 					}
-					goto l191
-				l216:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l358:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l220
+						goto l362
 					}
 					position++
 					{
 
-						position221, tokenIndex221, depth221 := position, tokenIndex, depth
+						position363, tokenIndex363, depth363 := position, tokenIndex, depth
 						if buffer[position] != rune('v') {
-							goto l222
+							goto l364
 						}
 						position++
-						goto l221
-					l222:
-						position, tokenIndex, depth = position221, tokenIndex221, depth221
+						goto l363
+					l364:
+						position, tokenIndex, depth = position363, tokenIndex363, depth363
 						if buffer[position] != rune('V') {
-							goto l220
+							goto l362
 						}
 						position++
 					}
-				l221:
+				l363:
 					{
 
-						add(RuleAction42, position)
+						add(RuleAction64, position)
 					}
-					goto l191
-				l220:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l362:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l224
+						goto l366
 					}
 					position++
 					if buffer[position] != rune('\'') {
-						goto l224
+						goto l366
 					}
 					position++
 					{
 
-						add(RuleAction43, position)
+						add(RuleAction65, position)
 					}
-					goto l191
-				l224:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l366:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l226
+						goto l368
 					}
 					position++
 					if buffer[position] != rune('"') {
-						goto l226
+						goto l368
 					}
 					position++
 					{
 
-						add(RuleAction44, position)
+						add(RuleAction66, position)
 					}
-					goto l191
-				l226:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l368:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l228
+						goto l370
 					}
 					position++
 					if buffer[position] != rune('[') {
-						goto l228
+						goto l370
 					}
 					position++
 					{
 
-						add(RuleAction45, position)
+						add(RuleAction67, position)
 					}
-					goto l191
-				l228:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l370:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l230
+						goto l372
 					}
 					position++
 					if buffer[position] != rune(']') {
-						goto l230
+						goto l372
 					}
 					position++
 					{
 
-						add(RuleAction46, position)
+						add(RuleAction68, position)
 					}
-					goto l191
-				l230:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l372:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l232
+						goto l374
 					}
 					position++
 					if buffer[position] != rune('-') {
-						goto l232
+						goto l374
+					}
+					position++
+					{
+
+						add(RuleAction69, position)
+					}
+					goto l333
+				l374:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
+					if buffer[position] != rune('\\') {
+						goto l376
+					}
+					position++
+					if buffer[position] != rune('x') {
+						goto l376
+					}
+					position++
+					{
+
+						position377 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l376
+						}
+						if !rules[RuleHex]() {
+							goto l376
+						}
+						depth--
+						add(RulePegText, position377)
+					}
+					{
+
+						add(RuleAction70, position)
+					}
+					goto l333
+				l376:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
+					if buffer[position] != rune('\\') {
+						goto l379
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l379
 					}
 					position++
+					if buffer[position] != rune('{') {
+						goto l379
+					}
+					position++
+					{
+
+						position380 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l379
+						}
+					l381:
+						{
+
+							position382, tokenIndex382, depth382 := position, tokenIndex, depth
+							if !rules[RuleHex]() {
+								goto l382
+							}
+							goto l381
+						l382:
+							position, tokenIndex, depth = position382, tokenIndex382, depth382
+						}
+						depth--
+						add(RulePegText, position380)
+					}
+					if buffer[position] != rune('}') {
+						goto l379
+					}
+					position++
+					{
+
+						add(RuleAction71, position)
+					}
+					goto l333
+				l379:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
+					if buffer[position] != rune('\\') {
+						goto l384
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l384
+					}
+					position++
+					{
+
+						position385 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l384
+						}
+						if !rules[RuleHex]() {
+							goto l384
+						}
+						if !rules[RuleHex]() {
+							goto l384
+						}
+						if !rules[RuleHex]() {
+							goto l384
+						}
+						depth--
+						add(RulePegText, position385)
+					}
+					{
+
+						add(RuleAction72, position)
+					}
+					goto l333
+				l384:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
+					if buffer[position] != rune('\\') {
+						goto l387
+					}
+					position++
+					if buffer[position] != rune('U') {
+						goto l387
+					}
+					position++
+					{
+
+						position388 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						if !rules[RuleHex]() {
+							goto l387
+						}
+						depth--
+						add(RulePegText, position388)
+					}
 					{
 
-						add(RuleAction47, position)
+						add(RuleAction73, position)
 					}
-					goto l191
-				l232:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l387:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l234
+						goto l390
 					}
 					position++
 					{
 
-						position235 := position
+						position391 := position
 						depth++
 						if c := buffer[position]; c < rune('0') || c > rune('3') {
-							goto l234
+							goto l390
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('7') {
-							goto l234
+							goto l390
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('7') {
-							goto l234
+							goto l390
 						}
 						position++
 						depth--
-						add(RulePegText, position235)
+						add(RulePegText, position391)
 					}
 					{
 
-						add(RuleAction48, position)
+						add(RuleAction74, position)
 					}
-					goto l191
-				l234:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l390:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l237
+						goto l393
 					}
 					position++
 					{
 
-						position238 := position
+						position394 := position
 						depth++
 						if c := buffer[position]; c < rune('0') || c > rune('7') {
-							goto l237
+							goto l393
 						}
 						position++
 						{
 
-							position239, tokenIndex239, depth239 := position, tokenIndex, depth
+							position395, tokenIndex395, depth395 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('0') || c > rune('7') {
-								goto l239
+								goto l395
 							}
 							position++
-							goto l240
-						l239:
-							position, tokenIndex, depth = position239, tokenIndex239, depth239
+							goto l396
+						l395:
+							position, tokenIndex, depth = position395, tokenIndex395, depth395
 						}
-					l240:
+					l396:
 						depth--
-						add(RulePegText, position238)
+						add(RulePegText, position394)
 					}
 					{
 
-						add(RuleAction49, position)
+						add(RuleAction75, position)
 					}
-					goto l191
-				l237:
-					position, tokenIndex, depth = position191, tokenIndex191, depth191
+					goto l333
+				l393:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
 					if buffer[position] != rune('\\') {
-						goto l189
+						goto l398
 					}
 					position++
 					if buffer[position] != rune('\\') {
-						goto l189
+						goto l398
 					}
 					position++
 					{
 
-						add(RuleAction50, position)
+						add(RuleAction76, position)
+					}
+					goto l333
+				l398:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
+					if buffer[position] != rune('\\') {
+						goto l331
+					}
+					position++
+					{
+
+						position400 := position
+						depth++
+						if !matchDot() {
+							goto l331
+						}
+						depth--
+						add(RulePegText, position400)
+					}
+					{
+
+						add(RuleAction77, position)
 					}
 				}
-			l191:
+			l333:
 				depth--
-				add(RuleEscape, position190)
+				add(RuleEscape, position332)
 			}
 			return true
-		l189:
-			position, tokenIndex, depth = position189, tokenIndex189, depth189
+		l331:
+			position, tokenIndex, depth = position331, tokenIndex331, depth331
 			return false
 		},
-		/* 19 Action <- <('{' <Braces*> '}' _)> */
+		/* 28 Action <- <('{' <Braces*> '}' _)> (leg.leg:147) */
 		func() bool {
-			position243, tokenIndex243, depth243 := position, tokenIndex, depth
+			position402, tokenIndex402, depth402 := position, tokenIndex, depth
 			{
 
-				position244 := position
+				position403 := position
 				depth++
 				if buffer[position] != rune('{') {
-					goto l243
+					goto l402
 				}
 				position++
 				{
 
-					position245 := position
+					position404 := position
 					depth++
-				l246:
+				l405:
 					{
 
-						position247, tokenIndex247, depth247 := position, tokenIndex, depth
+						position406, tokenIndex406, depth406 := position, tokenIndex, depth
 						if !rules[RuleBraces]() {
-							goto l247
+							goto l406
 						}
-						goto l246
-					l247:
-						position, tokenIndex, depth = position247, tokenIndex247, depth247
+						goto l405
+					l406:
+						position, tokenIndex, depth = position406, tokenIndex406, depth406
 					}
 					depth--
-					add(RulePegText, position245)
+					add(RulePegText, position404)
 				}
 				if buffer[position] != rune('}') {
-					goto l243
+					goto l402
 				}
 				position++
 				if !rules[Rule_]() {
-					goto l243
+					goto l402
 				}
 				depth--
-				add(RuleAction, position244)
+				add(RuleAction, position403)
 			}
 			return true
-		l243:
-			position, tokenIndex, depth = position243, tokenIndex243, depth243
+		l402:
+			position, tokenIndex, depth = position402, tokenIndex402, depth402
 			return false
 		},
-		/* 20 Braces <- <(('{' Braces* '}') / (!'}' .))> */
+		/* 29 Braces <- <(('{' Braces* '}') / (!'}' .))> (leg.leg:148) */
 		func() bool {
-			position248, tokenIndex248, depth248 := position, tokenIndex, depth
+			position407, tokenIndex407, depth407 := position, tokenIndex, depth
 			{
 
-				position249 := position
+				position408 := position
 				depth++
 				{
 
-					position250, tokenIndex250, depth250 := position, tokenIndex, depth
+					position409, tokenIndex409, depth409 := position, tokenIndex, depth
 					if buffer[position] != rune('{') {
-						goto l251
+						goto l410
 					}
 					position++
-				l252:
+				l411:
 					{
 
-						position253, tokenIndex253, depth253 := position, tokenIndex, depth
+						position412, tokenIndex412, depth412 := position, tokenIndex, depth
 						if !rules[RuleBraces]() {
-							goto l253
+							goto l412
 						}
-						goto l252
-					l253:
-						position, tokenIndex, depth = position253, tokenIndex253, depth253
+						goto l411
+					l412:
+						position, tokenIndex, depth = position412, tokenIndex412, depth412
 					}
 					if buffer[position] != rune('}') {
-						goto l251
+						goto l410
 					}
 					position++
-					goto l250
-				l251:
-					position, tokenIndex, depth = position250, tokenIndex250, depth250
+					goto l409
+				l410:
+					position, tokenIndex, depth = position409, tokenIndex409, depth409
 					{
 
-						position254, tokenIndex254, depth254 := position, tokenIndex, depth
+						position413, tokenIndex413, depth413 := position, tokenIndex, depth
 						if buffer[position] != rune('}') {
-							goto l254
+							goto l413
 						}
 						position++
-						goto l248
-					l254:
-						position, tokenIndex, depth = position254, tokenIndex254, depth254
+						goto l407
+					l413:
+						position, tokenIndex, depth = position413, tokenIndex413, depth413
 					}
 					if !matchDot() {
-						goto l248
+						goto l407
 					}
 				}
-			l250:
+			l409:
 				depth--
-				add(RuleBraces, position249)
+				add(RuleBraces, position408)
 			}
 			return true
-		l248:
-			position, tokenIndex, depth = position248, tokenIndex248, depth248
+		l407:
+			position, tokenIndex, depth = position407, tokenIndex407, depth407
 			return false
 		},
-		/* 21 Equal <- <('=' _)> */
+		/* 30 Equal <- <('=' _)> (leg.leg:149) */
 		func() bool {
-			position255, tokenIndex255, depth255 := position, tokenIndex, depth
+			position414, tokenIndex414, depth414 := position, tokenIndex, depth
 			{
 
-				position256 := position
+				position415 := position
 				depth++
 				if buffer[position] != rune('=') {
-					goto l255
+					goto l414
 				}
 				position++
 				if !rules[Rule_]() {
-					goto l255
+					goto l414
 				}
 				depth--
-				add(RuleEqual, position256)
+				add(RuleEqual, position415)
 			}
 			return true
-		l255:
-			position, tokenIndex, depth = position255, tokenIndex255, depth255
+		l414:
+			position, tokenIndex, depth = position414, tokenIndex414, depth414
 			return false
 		},
-		/* 22 Colon <- <(':' _)> */
-		nil,
-		/* 23 Bar <- <('|' _)> */
+		/* 31 Colon <- <(':' _)> (leg.leg:150) */
+		func() bool {
+			position416, tokenIndex416, depth416 := position, tokenIndex, depth
+			{
+
+				position417 := position
+				depth++
+				if buffer[position] != rune(':') {
+					goto l416
+				}
+				position++
+				if !rules[Rule_]() {
+					goto l416
+				}
+				depth--
+				add(RuleColon, position417)
+			}
+			return true
+		l416:
+			position, tokenIndex, depth = position416, tokenIndex416, depth416
+			return false
+		},
+		/* 32 Bar <- <('|' _)> (leg.leg:151) */
 		func() bool {
-			position258, tokenIndex258, depth258 := position, tokenIndex, depth
+			position418, tokenIndex418, depth418 := position, tokenIndex, depth
 			{
 
-				position259 := position
+				position419 := position
 				depth++
 				if buffer[position] != rune('|') {
-					goto l258
+					goto l418
 				}
 				position++
 				if !rules[Rule_]() {
-					goto l258
+					goto l418
 				}
 				depth--
-				add(RuleBar, position259)
+				add(RuleBar, position419)
 			}
 			return true
-		l258:
-			position, tokenIndex, depth = position258, tokenIndex258, depth258
+		l418:
+			position, tokenIndex, depth = position418, tokenIndex418, depth418
 			return false
 		},
-		/* 24 And <- <('&' _)> */
+		/* 33 And <- <('&' _)> (leg.leg:152) */
 		func() bool {
-			position260, tokenIndex260, depth260 := position, tokenIndex, depth
+			position420, tokenIndex420, depth420 := position, tokenIndex, depth
 			{
 
-				position261 := position
+				position421 := position
 				depth++
 				if buffer[position] != rune('&') {
-					goto l260
+					goto l420
 				}
 				position++
 				if !rules[Rule_]() {
-					goto l260
+					goto l420
 				}
 				depth--
-				add(RuleAnd, position261)
+				add(RuleAnd, position421)
 			}
 			return true
-		l260:
-			position, tokenIndex, depth = position260, tokenIndex260, depth260
+		l420:
+			position, tokenIndex, depth = position420, tokenIndex420, depth420
 			return false
 		},
-		/* 25 Not <- <('!' _)> */
+		/* 34 Not <- <('!' _)> (leg.leg:153) */
 		nil,
-		/* 26 Question <- <('?' _)> */
+		/* 35 Question <- <('?' _)> (leg.leg:154) */
 		nil,
-		/* 27 Star <- <('*' _)> */
+		/* 36 Star <- <('*' _)> (leg.leg:155) */
 		nil,
-		/* 28 Plus <- <('+' _)> */
+		/* 37 Plus <- <('+' _)> (leg.leg:156) */
 		nil,
-		/* 29 Open <- <('(' _)> */
+		/* 38 Repeat <- <('{' <((' ' / '\t')* [0-9]+ (' ' / '\t')* (',' (' ' / '\t')* [0-9]* (' ' / '\t')*)?)> '}' _)> (leg.leg:157) */
 		nil,
-		/* 30 Close <- <(')' _)> */
-		nil,
-		/* 31 Dot <- <('.' _)> */
+		/* 39 Open <- <('(' _)> (leg.leg:158) */
+		func() bool {
+			position427, tokenIndex427, depth427 := position, tokenIndex, depth
+			{
+
+				position428 := position
+				depth++
+				if buffer[position] != rune('(') {
+					goto l427
+				}
+				position++
+				if !rules[Rule_]() {
+					goto l427
+				}
+				depth--
+				add(RuleOpen, position428)
+			}
+			return true
+		l427:
+			position, tokenIndex, depth = position427, tokenIndex427, depth427
+			return false
+		},
+		/* 40 Close <- <(')' _)> (leg.leg:159) */
+		func() bool {
+			position429, tokenIndex429, depth429 := position, tokenIndex, depth
+			{
+
+				position430 := position
+				depth++
+				if buffer[position] != rune(')') {
+					goto l429
+				}
+				position++
+				if !rules[Rule_]() {
+					goto l429
+				}
+				depth--
+				add(RuleClose, position430)
+			}
+			return true
+		l429:
+			position, tokenIndex, depth = position429, tokenIndex429, depth429
+			return false
+		},
+		/* 41 Comma <- <(',' _)> (leg.leg:160) */
+		func() bool {
+			position431, tokenIndex431, depth431 := position, tokenIndex, depth
+			{
+
+				position432 := position
+				depth++
+				if buffer[position] != rune(',') {
+					goto l431
+				}
+				position++
+				if !rules[Rule_]() {
+					goto l431
+				}
+				depth--
+				add(RuleComma, position432)
+			}
+			return true
+		l431:
+			position, tokenIndex, depth = position431, tokenIndex431, depth431
+			return false
+		},
+		/* 42 Dot <- <('.' _)> (leg.leg:161) */
 		nil,
-		/* 32 RPERCENT <- <('%' '}' _)> */
+		/* 43 RPERCENT <- <('%' '}' _)> (leg.leg:162) */
 		nil,
-		/* 33 _ <- <(Space / Comment)*> */
+		/* 44 _ <- <(Space / Comment)*> (leg.leg:163) */
 		func() bool {
 			{
 
-				position271 := position
+				position436 := position
 				depth++
-			l272:
+			l437:
 				{
 
-					position273, tokenIndex273, depth273 := position, tokenIndex, depth
+					position438, tokenIndex438, depth438 := position, tokenIndex, depth
 					{
 
-						position274, tokenIndex274, depth274 := position, tokenIndex, depth
+						position439, tokenIndex439, depth439 := position, tokenIndex, depth
 						{
 
-							position276 := position
+							position441 := position
 							depth++
 							{
 
 								switch buffer[position] {
 								case '\t':
 									if buffer[position] != rune('\t') {
-										goto l275
+										goto l440
 									}
 									position++
 									break
 								case ' ':
 									if buffer[position] != rune(' ') {
-										goto l275
+										goto l440
 									}
 									position++
 									break
 								default:
 									if !rules[RuleEndOfLine]() {
-										goto l275
+										goto l440
 									}
 									break
 								}
 							}
 
 							depth--
-							add(RuleSpace, position276)
+							add(RuleSpace, position441)
 						}
-						goto l274
-					l275:
-						position, tokenIndex, depth = position274, tokenIndex274, depth274
+						goto l439
+					l440:
+						position, tokenIndex, depth = position439, tokenIndex439, depth439
 						{
 
-							position278 := position
+							position443 := position
 							depth++
 							if buffer[position] != rune('#') {
-								goto l273
+								goto l438
 							}
 							position++
-						l279:
+						l444:
 							{
 
-								position280, tokenIndex280, depth280 := position, tokenIndex, depth
+								position445, tokenIndex445, depth445 := position, tokenIndex, depth
 								{
 
-									position281, tokenIndex281, depth281 := position, tokenIndex, depth
+									position446, tokenIndex446, depth446 := position, tokenIndex, depth
 									if !rules[RuleEndOfLine]() {
-										goto l281
+										goto l446
 									}
-									goto l280
-								l281:
-									position, tokenIndex, depth = position281, tokenIndex281, depth281
+									goto l445
+								l446:
+									position, tokenIndex, depth = position446, tokenIndex446, depth446
 								}
 								if !matchDot() {
-									goto l280
+									goto l445
 								}
-								goto l279
-							l280:
-								position, tokenIndex, depth = position280, tokenIndex280, depth280
+								goto l444
+							l445:
+								position, tokenIndex, depth = position445, tokenIndex445, depth445
 							}
 							if !rules[RuleEndOfLine]() {
-								goto l273
+								goto l438
 							}
 							depth--
-							add(RuleComment, position278)
+							add(RuleComment, position443)
 						}
 					}
-				l274:
-					goto l272
-				l273:
-					position, tokenIndex, depth = position273, tokenIndex273, depth273
+				l439:
+					goto l437
+				l438:
+					position, tokenIndex, depth = position438, tokenIndex438, depth438
 				}
 				depth--
-				add(Rule_, position271)
+				add(Rule_, position436)
 			}
 			return true
 		},
-		/* 34 Comment <- <('#' (!EndOfLine .)* EndOfLine)> */
+		/* 45 Comment <- <('#' (!EndOfLine .)* EndOfLine)> (leg.leg:164) */
 		nil,
-		/* 35 Space <- <((&('\t') '\t') | (&(' ') ' ') | (&('\n' | '\r') EndOfLine))> */
+		/* 46 Space <- <((&('\t') '\t') | (&(' ') ' ') | (&('\n' | '\r') EndOfLine))> (leg.leg:165) */
 		nil,
-		/* 36 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		/* 47 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> (leg.leg:166) */
 		func() bool {
-			position284, tokenIndex284, depth284 := position, tokenIndex, depth
+			position449, tokenIndex449, depth449 := position, tokenIndex, depth
 			{
 
-				position285 := position
+				position450 := position
 				depth++
 				{
 
-					position286, tokenIndex286, depth286 := position, tokenIndex, depth
+					position451, tokenIndex451, depth451 := position, tokenIndex, depth
 					if buffer[position] != rune('\r') {
-						goto l287
+						goto l452
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l287
+						goto l452
 					}
 					position++
-					goto l286
-				l287:
-					position, tokenIndex, depth = position286, tokenIndex286, depth286
+					goto l451
+				l452:
+					position, tokenIndex, depth = position451, tokenIndex451, depth451
 					if buffer[position] != rune('\n') {
-						goto l288
+						goto l453
 					}
 					position++
-					goto l286
-				l288:
-					position, tokenIndex, depth = position286, tokenIndex286, depth286
+					goto l451
+				l453:
+					position, tokenIndex, depth = position451, tokenIndex451, depth451
 					if buffer[position] != rune('\r') {
-						goto l284
+						goto l449
 					}
 					position++
 				}
-			l286:
+			l451:
 				depth--
-				add(RuleEndOfLine, position285)
+				add(RuleEndOfLine, position450)
 			}
 			return true
-		l284:
-			position, tokenIndex, depth = position284, tokenIndex284, depth284
+		l449:
+			position, tokenIndex, depth = position449, tokenIndex449, depth449
+			return false
+		},
+		/* 48 EndOfFile <- <!.> (leg.leg:167) */
+		nil,
+		/* 49 Begin <- <('<' _)> (leg.leg:168) */
+		func() bool {
+			position455, tokenIndex455, depth455 := position, tokenIndex, depth
+			{
+
+				position456 := position
+				depth++
+				if buffer[position] != rune('<') {
+					goto l455
+				}
+				position++
+				if !rules[Rule_]() {
+					goto l455
+				}
+				depth--
+				add(RuleBegin, position456)
+			}
+			return true
+		l455:
+			position, tokenIndex, depth = position455, tokenIndex455, depth455
+			return false
+		},
+		/* 50 End <- <('>' _)> (leg.leg:169) */
+		func() bool {
+			position457, tokenIndex457, depth457 := position, tokenIndex, depth
+			{
+
+				position458 := position
+				depth++
+				if buffer[position] != rune('>') {
+					goto l457
+				}
+				position++
+				if !rules[Rule_]() {
+					goto l457
+				}
+				depth--
+				add(RuleEnd, position458)
+			}
+			return true
+		l457:
+			position, tokenIndex, depth = position457, tokenIndex457, depth457
 			return false
 		},
-		/* 37 EndOfFile <- <!.> */
+		/* 52 Action0 <- <{ p.AddPackage(buffer[begin:end]) }> (leg.leg:31) */
+		nil,
+		/* 53 Action1 <- <{ p.AddYYSType(buffer[begin:end]) }> (leg.leg:32) */
+		nil,
+		/* 54 Action2 <- <{ p.AddLeg(buffer[begin:end]) }> (leg.leg:33) */
+		nil,
+		/* 55 Action3 <- <{ p.AddState(buffer[begin:end]) }> (leg.leg:34) */
+		nil,
+		/* 56 Action4 <- <{ p.AddImportPrefix(buffer[begin:end]) }> (leg.leg:37) */
+		nil,
+		nil,
+		/* 58 Action5 <- <{ p.AddImport(buffer[begin:end]) }> (leg.leg:38) */
+		nil,
+		/* 59 Action6 <- <{  p.At(begin); p.AddDeclaration(buffer[begin:end])  }> (leg.leg:40) */
+		nil,
+		/* 60 Action7 <- <{ p.At(begin); p.AddTrailer(buffer[begin:end]) }> (leg.leg:41) */
+		nil,
+		/* 61 Action8 <- <{ p.AddHighlight(buffer[begin:end]) }> (leg.leg:42) */
+		nil,
+		/* 62 Action9 <- <{ p.At(begin); p.AddHighlightRule(buffer[begin:end]) }> (leg.leg:43) */
+		nil,
+		/* 63 Action10 <- <{ p.At(begin); p.AddRule(buffer[begin:end]) }> (leg.leg:46) */
+		nil,
+		/* 64 Action11 <- <{ p.At(begin); p.AddRule(buffer[begin:end]) }> (leg.leg:48) */
+		nil,
+		/* 65 Action12 <- <{ p.AddExpression() }> (leg.leg:49) */
+		nil,
+		/* 66 Action13 <- <{ p.AddParameter(buffer[begin:end]) }> (leg.leg:50) */
+		nil,
+		/* 67 Action14 <- <{ p.AddAlternate() }> (leg.leg:51) */
+		nil,
+		/* 68 Action15 <- <{ p.AddNil(); p.AddAlternate() }> (leg.leg:52) */
+		nil,
+		/* 69 Action16 <- <{ p.AddNil() }> (leg.leg:54) */
+		nil,
+		/* 70 Action17 <- <{ p.AddSequence() }> (leg.leg:55) */
+		nil,
+		/* 71 Action18 <- <{ p.At(begin); p.AddPredicate(buffer[begin:end]) }> (leg.leg:57) */
+		nil,
+		/* 72 Action19 <- <{ p.AddPeekFor() }> (leg.leg:58) */
+		nil,
+		/* 73 Action20 <- <{ p.AddPeekNot() }> (leg.leg:59) */
+		nil,
+		/* 74 Action21 <- <{ p.AddQuery() }> (leg.leg:61) */
+		nil,
+		/* 75 Action22 <- <{ p.AddStar() }> (leg.leg:62) */
+		nil,
+		/* 76 Action23 <- <{ p.AddPlus() }> (leg.leg:63) */
 		nil,
-		/* 38 Begin <- <('<' _)> */
+		/* 77 Action24 <- <{ p.Span(begin-1, end+1); p.AddRepeat(buffer[begin:end]) }> (leg.leg:64) */
 		nil,
-		/* 39 End <- <('>' _)> */
+		/* 78 Action25 <- <{ p.At(begin); p.AddVariable(buffer[begin:end]) }> (leg.leg:66) */
 		nil,
-		/* 41 Action0 <- <{ p.AddPackage(buffer[begin:end]) }> */
+		/* 79 Action26 <- <{ p.At(begin); p.AddName(buffer[begin:end]) }> (leg.leg:67) */
 		nil,
-		/* 42 Action1 <- <{ p.AddYYSType(buffer[begin:end]) }> */
+		/* 80 Action27 <- <{ p.At(begin); p.AddVariable(buffer[begin:end]) }> (leg.leg:68) */
 		nil,
-		/* 43 Action2 <- <{ p.AddLeg(buffer[begin:end]) }> */
+		/* 81 Action28 <- <{ p.AddPush(); p.AddLabel() }> (leg.leg:69) */
 		nil,
-		/* 44 Action3 <- <{ p.AddState(buffer[begin:end]) }> */
+		/* 82 Action29 <- <{ p.At(begin); p.AddBackReference(buffer[begin:end]) }> (leg.leg:70) */
 		nil,
+		/* 83 Action30 <- <{ p.At(begin); p.AddCall(buffer[begin:end]) }> (leg.leg:71) */
 		nil,
-		/* 46 Action4 <- <{  p.AddDeclaration(buffer[begin:end])  }> */
+		/* 84 Action31 <- <{ p.AddArgument() }> (leg.leg:72) */
 		nil,
-		/* 47 Action5 <- <{ p.AddTrailer(buffer[begin:end]) }> */
+		/* 85 Action32 <- <{ p.AddArgument() }> (leg.leg:73) */
 		nil,
-		/* 48 Action6 <- <{ p.AddRule(buffer[begin:end]) }> */
+		/* 86 Action33 <- <{ p.At(begin); p.AddName(buffer[begin:end]) }> (leg.leg:75) */
 		nil,
-		/* 49 Action7 <- <{ p.AddExpression() }> */
+		/* 87 Action34 <- <{ p.AddDot() }> (leg.leg:79) */
 		nil,
-		/* 50 Action8 <- <{ p.AddAlternate() }> */
+		/* 88 Action35 <- <{ p.At(begin); p.AddAction(buffer[begin:end]) }> (leg.leg:80) */
 		nil,
-		/* 51 Action9 <- <{ p.AddNil(); p.AddAlternate() }> */
+		/* 89 Action36 <- <{ p.AddPush() }> (leg.leg:81) */
 		nil,
-		/* 52 Action10 <- <{ p.AddNil() }> */
+		/* 90 Action37 <- <{ p.AddSequence() }> (leg.leg:87) */
 		nil,
-		/* 53 Action11 <- <{ p.AddSequence() }> */
+		/* 91 Action38 <- <{ p.AddSequence() }> (leg.leg:89) */
 		nil,
-		/* 54 Action12 <- <{ p.AddPredicate(buffer[begin:end]) }> */
+		/* 92 Action39 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> (leg.leg:91) */
 		nil,
-		/* 55 Action13 <- <{ p.AddPeekFor() }> */
+		/* 93 Action40 <- <{ p.AddClassComplement() }> (leg.leg:94) */
 		nil,
-		/* 56 Action14 <- <{ p.AddPeekNot() }> */
+		/* 94 Action41 <- <{ p.AddClassText(buffer[begin:end]) }> (leg.leg:96) */
 		nil,
-		/* 57 Action15 <- <{ p.AddQuery() }> */
+		/* 95 Action42 <- <{ p.AddClassDifference() }> (leg.leg:98) */
 		nil,
-		/* 58 Action16 <- <{ p.AddStar() }> */
+		/* 96 Action43 <- <{ p.AddClassIntersection() }> (leg.leg:99) */
 		nil,
-		/* 59 Action17 <- <{ p.AddPlus() }> */
+		/* 97 Action44 <- <{ p.AddAlternate() }> (leg.leg:101) */
 		nil,
-		/* 60 Action18 <- <{ p.AddVariable(buffer[begin:end]) }> */
+		/* 98 Action45 <- <{ p.AddAlternate() }> (leg.leg:103) */
 		nil,
-		/* 61 Action19 <- <{ p.AddName(buffer[begin:end]) }> */
+		/* 99 Action46 <- <{ p.AddClassComplement() }> (leg.leg:105) */
 		nil,
-		/* 62 Action20 <- <{ p.AddName(buffer[begin:end]) }> */
+		/* 100 Action47 <- <{ p.AddClassDifference() }> (leg.leg:108) */
 		nil,
-		/* 63 Action21 <- <{ p.AddDot() }> */
+		/* 101 Action48 <- <{ p.AddClassIntersection() }> (leg.leg:109) */
 		nil,
-		/* 64 Action22 <- <{ p.AddAction(buffer[begin:end]) }> */
+		/* 102 Action49 <- <{ p.AddAlternate() }> (leg.leg:111) */
 		nil,
-		/* 65 Action23 <- <{ p.AddPush() }> */
+		/* 103 Action50 <- <{ p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]) }> (leg.leg:113) */
 		nil,
-		/* 66 Action24 <- <{ p.AddSequence() }> */
+		/* 104 Action51 <- <{ p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]); p.AddClassComplement() }> (leg.leg:114) */
 		nil,
-		/* 67 Action25 <- <{ p.AddSequence() }> */
+		/* 105 Action52 <- <{ p.AddRange() }> (leg.leg:115) */
 		nil,
-		/* 68 Action26 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> */
+		/* 106 Action53 <- <{ p.AddDoubleRange() }> (leg.leg:117) */
 		nil,
-		/* 69 Action27 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> */
+		/* 107 Action54 <- <{ p.At(begin); p.AddCharacter(buffer[begin:end]) }> (leg.leg:120) */
 		nil,
-		/* 70 Action28 <- <{ p.AddAlternate() }> */
+		/* 108 Action55 <- <{ p.At(begin); p.AddDoubleCharacter(buffer[begin:end]) }> (leg.leg:122) */
 		nil,
-		/* 71 Action29 <- <{ p.AddAlternate() }> */
+		/* 109 Action56 <- <{ p.At(begin); p.AddCharacter(buffer[begin:end]) }> (leg.leg:123) */
 		nil,
-		/* 72 Action30 <- <{ p.AddRange() }> */
+		/* 110 Action57 <- <{ p.AddCharacter("\a") }> (leg.leg:125) */
 		nil,
-		/* 73 Action31 <- <{ p.AddDoubleRange() }> */
+		/* 111 Action58 <- <{ p.AddCharacter("\b") }> (leg.leg:126) */
 		nil,
-		/* 74 Action32 <- <{ p.AddCharacter(buffer[begin:end]) }> */
+		/* 112 Action59 <- <{ p.AddCharacter("\x1B") }> (leg.leg:127) */
 		nil,
-		/* 75 Action33 <- <{ p.AddDoubleCharacter(buffer[begin:end]) }> */
+		/* 113 Action60 <- <{ p.AddCharacter("\f") }> (leg.leg:128) */
 		nil,
-		/* 76 Action34 <- <{ p.AddCharacter(buffer[begin:end]) }> */
+		/* 114 Action61 <- <{ p.AddCharacter("\n") }> (leg.leg:129) */
 		nil,
-		/* 77 Action35 <- <{ p.AddCharacter("\a") }> */
+		/* 115 Action62 <- <{ p.AddCharacter("\r") }> (leg.leg:130) */
 		nil,
-		/* 78 Action36 <- <{ p.AddCharacter("\b") }> */
+		/* 116 Action63 <- <{ p.AddCharacter("\t") }> (leg.leg:131) */
 		nil,
-		/* 79 Action37 <- <{ p.AddCharacter("\x1B") }> */
+		/* 117 Action64 <- <{ p.AddCharacter("\v") }> (leg.leg:132) */
 		nil,
-		/* 80 Action38 <- <{ p.AddCharacter("\f") }> */
+		/* 118 Action65 <- <{ p.AddCharacter("'") }> (leg.leg:133) */
 		nil,
-		/* 81 Action39 <- <{ p.AddCharacter("\n") }> */
+		/* 119 Action66 <- <{ p.AddCharacter("\"") }> (leg.leg:134) */
 		nil,
-		/* 82 Action40 <- <{ p.AddCharacter("\r") }> */
+		/* 120 Action67 <- <{ p.AddCharacter("[") }> (leg.leg:135) */
 		nil,
-		/* 83 Action41 <- <{ p.AddCharacter("\t") }> */
+		/* 121 Action68 <- <{ p.AddCharacter("]") }> (leg.leg:136) */
 		nil,
-		/* 84 Action42 <- <{ p.AddCharacter("\v") }> */
+		/* 122 Action69 <- <{ p.AddCharacter("-") }> (leg.leg:137) */
 		nil,
-		/* 85 Action43 <- <{ p.AddCharacter("'") }> */
+		/* 123 Action70 <- <{ p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }> (leg.leg:138) */
 		nil,
-		/* 86 Action44 <- <{ p.AddCharacter("\"") }> */
+		/* 124 Action71 <- <{ p.Span(begin-3, end+1); p.AddHexCharacter(buffer[begin:end]) }> (leg.leg:139) */
 		nil,
-		/* 87 Action45 <- <{ p.AddCharacter("[") }> */
+		/* 125 Action72 <- <{ p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }> (leg.leg:140) */
 		nil,
-		/* 88 Action46 <- <{ p.AddCharacter("]") }> */
+		/* 126 Action73 <- <{ p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }> (leg.leg:142) */
 		nil,
-		/* 89 Action47 <- <{ p.AddCharacter("-") }> */
+		/* 127 Action74 <- <{ p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }> (leg.leg:143) */
 		nil,
-		/* 90 Action48 <- <{ p.AddOctalCharacter(buffer[begin:end]) }> */
+		/* 128 Action75 <- <{ p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }> (leg.leg:144) */
 		nil,
-		/* 91 Action49 <- <{ p.AddOctalCharacter(buffer[begin:end]) }> */
+		/* 129 Action76 <- <{ p.AddCharacter("\\") }> (leg.leg:145) */
 		nil,
-		/* 92 Action50 <- <{ p.AddCharacter("\\") }> */
+		/* 130 Action77 <- <{ p.Span(begin-1, end); p.AddInvalidEscape(buffer[begin:end]) }> (leg.leg:146) */
 		nil,
 	}
 	p.rules = rules
@@ -0,0 +1,174 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/* A string as Go source, between backquotes when it can be written there unchanged. */
+func builderString(s string, multiline bool) string {
+	if multiline {
+		if s != "" && strconv.CanBackquote(strings.Replace(s, "\n", "", -1)) {
+			return "`" + s + "`"
+		}
+	} else if s != "" && strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+/* Write the Go program which builds the tree the way the bootstrap parser is built, into the file target. */
+func (t *Tree) WriteBuilder(w io.Writer, target string) error {
+	var out bytes.Buffer
+	print := func(format string, a ...interface{}) { fmt.Fprintf(&out, format, a...) }
+	call := func(method string, a ...string) { print("\tt.%v(%v)\n", method, strings.Join(a, ", ")) }
+
+	print("// Code generated by leg -emit-builder. DO NOT EDIT.\n\n")
+	print("package main\n\nimport (\n\t\"log\"\n\t\"runtime\"\n)\n\n")
+	print("func main() {\n\truntime.GOMAXPROCS(2)\n\tt := New(%v, %v)\n\n", t.inline, t._switch)
+
+	/* the positions which the generated parser names, in its rule comments and //line directives */
+	at := func(n Node) {
+		if position := n.GetPosition(); position.Line > 0 {
+			call("AtPosition", strconv.Quote(filepath.Base(position.File)), strconv.Itoa(position.Line), strconv.Itoa(position.Column))
+		}
+	}
+	var emit func(n Node)
+	emit = func(n Node) {
+		switch n.GetType() {
+		case TypeName:
+			if v := n.Front(); v != nil && v.GetType() == TypeVariable {
+				call("AddVariable", strconv.Quote(v.String()))
+			}
+			call("AddName", strconv.Quote(n.String()))
+		case TypeDot:
+			call("AddDot")
+		case TypeCharacter:
+			call("AddCharacter", builderString(n.String(), false))
+		case TypeRange:
+			emit(n.Front())
+			emit(n.Front().Next())
+			call("AddRange")
+		case TypeClass:
+			elements := n.Slice()
+			if len(elements) == 0 {
+				call("AddNil")
+			}
+			for i, element := range elements {
+				emit(element)
+				if i > 0 {
+					call("AddAlternate")
+				}
+			}
+			call("AddClass")
+			if text := n.String(); text != "" {
+				call("AddClassText", strconv.Quote(text))
+			}
+		case TypePush:
+			if label := n.String(); label != "" {
+				call("AddVariable", strconv.Quote(label))
+				emit(n.Front())
+				call("AddPush")
+				call("AddLabel")
+				break
+			}
+			emit(n.Front())
+			call("AddPush")
+		case TypeRepeat:
+			emit(n.Front())
+			call("AddRepeat", strconv.Quote(n.String()))
+		case TypeBackReference:
+			call("AddBackReference", strconv.Quote(n.String()))
+		case TypePredicate:
+			at(n)
+			call("AddPredicate", builderString(n.String(), false))
+		case TypeAction:
+			at(n)
+			call("AddAction", builderString(n.String(), false))
+		case TypeNil:
+			call("AddNil")
+		case TypeAlternate, TypeSequence:
+			method := map[Type]string{TypeAlternate: "AddAlternate", TypeSequence: "AddSequence"}[n.GetType()]
+			elements := n.Slice()
+			emit(elements[0])
+			for _, element := range elements[1:] {
+				emit(element)
+				call(method)
+			}
+		case TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus:
+			emit(n.Front())
+			call(map[Type]string{TypePeekFor: "AddPeekFor", TypePeekNot: "AddPeekNot", TypeQuery: "AddQuery",
+				TypeStar: "AddStar", TypePlus: "AddPlus"}[n.GetType()])
+		default:
+			panic(fmt.Sprintf("%v cannot be built", n.GetType()))
+		}
+	}
+
+	var err error
+	func() {
+		defer func() {
+			if e := recover(); e != nil {
+				err = fmt.Errorf("%v", e)
+			}
+		}()
+		for _, element := range t.Slice() {
+			switch element.GetType() {
+			case TypePackage:
+				call("AddPackage", strconv.Quote(element.String()))
+			case TypeLeg:
+				call("AddLeg", strconv.Quote(element.String()))
+				if state := element.Front(); state != nil {
+					call("AddState", builderString(state.String(), true))
+				}
+				if t.YYSType != "" {
+					call("AddYYSType", strconv.Quote(t.YYSType))
+				}
+				class := ""
+				for i, highlight := range t.Highlights {
+					if i == 0 {
+						print("\n")
+					}
+					if i == 0 || highlight.Class != class {
+						class = highlight.Class
+						call("AddHighlight", strconv.Quote(class))
+					}
+					call("AddHighlightRule", strconv.Quote(highlight.Rule))
+				}
+				for _, declaration := range t.Declarations {
+					call("AddDeclaration", builderString(declaration, true))
+				}
+			case TypeRule:
+				var rule bytes.Buffer
+				printRule(&rule, element)
+				print("\n\t/* %v */\n", strings.Replace(rule.String(), "*/", "* /", -1))
+				at(element)
+				call("AddRule", strconv.Quote(element.String()))
+				emit(element.Front())
+				call("AddExpression")
+			}
+		}
+		if t.Trailer != "" {
+			call("AddTrailer", builderString(t.Trailer, true))
+		}
+	}()
+	if err != nil {
+		return err
+	}
+	print("\n\tif err := t.Compile(%q); err != nil {\n\t\tlog.Fatal(err)\n\t}\n}\n", target)
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(source)
+	return err
+}
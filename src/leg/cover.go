@@ -0,0 +1,557 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+)
+
+/* How often a node was tried and matched; skipped counts the times an optional, a loop or a lookahead did not take its operand. */
+type coverHit struct {
+	tries, matches, skipped int
+}
+
+/* Runs a grammar directly from its Tree, counting the hits of every node, keeping the rules matched when tree is set. */
+type coverage struct {
+	rules      map[string]Node
+	hits       map[Node]*coverHit
+	buffer     []rune
+	depth      int
+	predicates bool
+	tree       bool
+	nodes      []*parseNode
+	farthest   int
+	captures   []capture
+}
+
+/* The text matched by a labelled capture, for the back references to it in the same rule. */
+type capture struct {
+	label      string
+	begin, end int
+}
+
+/* A rule matched by a coverage run. */
+type parseNode struct {
+	rule       string
+	begin, end int
+	children   []*parseNode
+}
+
+/* Nesting beyond this many rules is taken to be left recursion. */
+const coverDepth = 1 << 16
+
+type coverError struct {
+	rule string
+}
+
+func newCoverage(t *Tree) *coverage {
+	c := &coverage{rules: make(map[string]Node), hits: make(map[Node]*coverHit)}
+	for _, n := range t.Slice() {
+		if _, ok := c.rules[n.String()]; n.GetType() == TypeRule && !ok {
+			c.rules[n.String()] = n
+		}
+	}
+	return c
+}
+
+func (c *coverage) hit(n Node) *coverHit {
+	h, ok := c.hits[n]
+	if !ok {
+		h = &coverHit{}
+		c.hits[n] = h
+	}
+	return h
+}
+
+/* Match a rule or any other node against the buffer from position, returning where the match ended. */
+func (c *coverage) run(n Node, buffer []rune, position int) (end int, ok bool, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			if e, isCover := e.(coverError); isCover {
+				err = fmt.Errorf("rule '%v' nests too deeply, is it left recursive?", e.rule)
+				return
+			}
+			panic(e)
+		}
+	}()
+	c.buffer, c.depth, c.nodes, c.farthest, c.captures = buffer, 0, nil, position, nil
+	end, ok = c.match(n, position)
+	return
+}
+
+func (c *coverage) match(n Node, position int) (int, bool) {
+	h := c.hit(n)
+	h.tries++
+	mark, captures := len(c.nodes), len(c.captures)
+	end, ok := c.evaluate(n, position, h)
+	if ok {
+		h.matches++
+	} else {
+		c.nodes, c.captures = c.nodes[:mark], c.captures[:captures]
+	}
+	if !ok && position > c.farthest {
+		c.farthest = position
+	}
+	return end, ok
+}
+
+func (c *coverage) evaluate(n Node, position int, h *coverHit) (int, bool) {
+	switch n.GetType() {
+	case TypeRule:
+		if c.depth++; c.depth > coverDepth {
+			panic(coverError{n.String()})
+		}
+		nodes, captures := c.nodes, c.captures
+		c.nodes, c.captures = nil, nil
+		end, ok := c.match(n.Front(), position)
+		children := c.nodes
+		c.nodes, c.captures = nodes, captures
+		if ok && c.tree {
+			c.nodes = append(c.nodes, &parseNode{rule: n.String(), begin: position, end: end, children: children})
+		}
+		c.depth--
+		return end, ok
+	case TypeName:
+		rule, ok := c.rules[n.String()]
+		if !ok {
+			return position, true
+		}
+		return c.match(rule, position)
+	case TypeDot:
+		if position < len(c.buffer) {
+			return position + 1, true
+		}
+	case TypeCharacter:
+		if r, ok := character(n); ok && position < len(c.buffer) && c.buffer[position] == r {
+			return position + 1, true
+		}
+	case TypeString:
+		text := []rune(n.String())
+		if position+len(text) <= len(c.buffer) && string(c.buffer[position:position+len(text)]) == n.String() {
+			return position + len(text), true
+		}
+	case TypeRange:
+		lower, _ := character(n.Front())
+		upper, _ := character(n.Front().Next())
+		if position < len(c.buffer) && c.buffer[position] >= lower && c.buffer[position] <= upper {
+			return position + 1, true
+		}
+	case TypeClass:
+		if r, _ := classRanges(n); position < len(c.buffer) && r.contains(c.buffer[position]) {
+			return position + 1, true
+		}
+	case TypePredicate:
+		/* the Go code of predicates cannot be run here, so they are taken to hold */
+		c.predicates = true
+		return position, true
+	case TypeAction, TypeNil, TypeCommit, TypeVariable:
+		return position, true
+	case TypeAlternate, TypeUnorderedAlternate:
+		for _, element := range n.Slice() {
+			if end, ok := c.match(element, position); ok {
+				return end, true
+			}
+		}
+	case TypeSequence:
+		for _, element := range n.Slice() {
+			end, ok := c.match(element, position)
+			if !ok {
+				return position, false
+			}
+			position = end
+		}
+		return position, true
+	case TypePeekFor, TypePeekNot:
+		mark := len(c.nodes)
+		_, ok := c.match(n.Front(), position)
+		c.nodes = c.nodes[:mark]
+		if !ok {
+			h.skipped++
+		}
+		return position, ok == (n.GetType() == TypePeekFor)
+	case TypeQuery:
+		if end, ok := c.match(n.Front(), position); ok {
+			return end, true
+		}
+		h.skipped++
+		return position, true
+	case TypeStar, TypePlus:
+		count := 0
+		for {
+			end, ok := c.match(n.Front(), position)
+			if !ok {
+				break
+			}
+			count++
+			if end == position {
+				break
+			}
+			position = end
+		}
+		if count == 0 {
+			h.skipped++
+		}
+		return position, count > 0 || n.GetType() == TypeStar
+	case TypeRepeat:
+		min, max := repeatBounds(n.String())
+		count := 0
+		for max < 0 || count < max {
+			end, ok := c.match(n.Front(), position)
+			if !ok {
+				break
+			}
+			count++
+			if end == position {
+				/* as in the generated parser, it goes on matching nothing as often as it has to */
+				if count < min {
+					count = min
+				}
+				break
+			}
+			position = end
+		}
+		if count == 0 {
+			h.skipped++
+		}
+		return position, count >= min
+	case TypePush, TypeImplicitPush:
+		end, ok := c.match(n.Front(), position)
+		if ok && n.GetType() == TypePush && n.String() != "" {
+			c.captures = append(c.captures, capture{label: n.String(), begin: position, end: end})
+		}
+		return end, ok
+	case TypeBackReference:
+		/* as in the generated parser, a capture not taken yet holds the empty string */
+		var text []rune
+		for i := len(c.captures) - 1; i >= 0; i-- {
+			if captured := c.captures[i]; captured.label == n.String() {
+				text = c.buffer[captured.begin:captured.end]
+				break
+			}
+		}
+		if position+len(text) <= len(c.buffer) && string(c.buffer[position:position+len(text)]) == string(text) {
+			return position + len(text), true
+		}
+		return position, false
+	}
+	return position, false
+}
+
+/* Whether a node may leave its operand out: an optional, a star, a lookahead or a repetition of at least none. */
+func skippable(n Node) bool {
+	switch n.GetType() {
+	case TypeQuery, TypeStar, TypePeekFor, TypePeekNot:
+		return true
+	case TypeRepeat:
+		min, _ := repeatBounds(n.String())
+		return min == 0
+	}
+	return false
+}
+
+/* The coverage points of a rule: the rule itself, each alternative, and both ways of each optional, loop and lookahead. */
+func (c *coverage) points(n Node) (covered, total int) {
+	count := func(ok bool) {
+		total++
+		if ok {
+			covered++
+		}
+	}
+	if _, _, ok := classOf(n); ok {
+		return
+	} else if _, _, ok := literalOf(n); ok {
+		return
+	}
+	h := c.hit(n)
+	switch {
+	case n.GetType() == TypeRule:
+		count(h.matches > 0)
+	case n.GetType() == TypeAlternate, n.GetType() == TypeUnorderedAlternate:
+		for _, element := range n.Slice() {
+			count(c.hit(element).matches > 0)
+		}
+	case skippable(n):
+		count(h.tries > h.skipped)
+		count(h.skipped > 0)
+	}
+	for _, element := range n.Slice() {
+		if n.GetType() != TypeRange && n.GetType() != TypeClass && n.GetType() != TypeName {
+			k, t := c.points(element)
+			covered, total = covered+k, total+t
+		}
+	}
+	return
+}
+
+/* The style of a node in the report: covered, partly covered or not covered. */
+func (c *coverage) class(n Node) string {
+	h := c.hit(n)
+	switch {
+	case skippable(n):
+		if h.tries > h.skipped && h.skipped > 0 {
+			return "covered"
+		} else if h.tries > 0 {
+			return "partial"
+		}
+	case h.matches > 0:
+		return "covered"
+	}
+	return "uncovered"
+}
+
+func (c *coverage) span(n Node, text string) string {
+	h := c.hit(n)
+	title := fmt.Sprintf("tried %v, matched %v", h.tries, h.matches)
+	switch n.GetType() {
+	case TypeQuery, TypeStar, TypePlus, TypeRepeat, TypePeekFor, TypePeekNot:
+		title = fmt.Sprintf("tried %v, taken %v, skipped %v", h.tries, h.tries-h.skipped, h.skipped)
+	}
+	return fmt.Sprintf("<span class=\"%v\" title=\"%v\">%v</span>", c.class(n), title, text)
+}
+
+/* Write a node in leg notation as HTML, with a span for each part that has coverage. */
+func (c *coverage) html(n Node) (string, int) {
+	/* leg shares the literal and class notation of pointlander peg */
+	e := &exporter{format: "pointlander-peg"}
+	if r, negated, ok := classOf(n); ok && (negated || n.GetType() != TypeCharacter) {
+		return c.span(n, html.EscapeString(e.class(r, negated))), precedencePrimary
+	}
+	if text, insensitive, ok := literalOf(n); ok {
+		return c.span(n, html.EscapeString(e.literal(text, insensitive))), precedencePrimary
+	}
+	operand := func(n Node, precedence int) string {
+		s, p := c.html(n)
+		if p < precedence {
+			return "(" + s + ")"
+		}
+		return s
+	}
+	switch n.GetType() {
+	case TypeRule:
+		return c.html(n.Front())
+	case TypeName:
+		name := html.EscapeString(n.String())
+		if v := n.Front(); v != nil && v.GetType() == TypeVariable {
+			name = html.EscapeString(v.String()) + ":" + name
+		}
+		return c.span(n, name), precedencePrimary
+	case TypeDot:
+		return c.span(n, "."), precedencePrimary
+	case TypeSequence:
+		/* a run of characters is one literal, matched when its last character is */
+		var elements []string
+		var last Node
+		run := ""
+		flush := func() {
+			if run != "" {
+				elements, run = append(elements, c.span(last, html.EscapeString(e.literal(run, false)))), ""
+			}
+		}
+		for _, element := range n.Slice() {
+			if r, ok := character(element); ok {
+				run, last = run+string(r), element
+				continue
+			}
+			flush()
+			elements = append(elements, operand(element, precedenceSequence))
+		}
+		flush()
+		if len(elements) == 1 {
+			return elements[0], precedencePrimary
+		}
+		return strings.Join(elements, " "), precedenceSequence
+	case TypeAlternate, TypeUnorderedAlternate:
+		var elements []string
+		for _, element := range n.Slice() {
+			elements = append(elements, operand(element, precedenceSequence))
+		}
+		return strings.Join(elements, " | "), precedenceAlternate
+	case TypeQuery, TypeStar, TypePlus:
+		suffix := map[Type]string{TypeQuery: "?", TypeStar: "*", TypePlus: "+"}[n.GetType()]
+		return operand(n.Front(), precedencePrimary) + c.span(n, suffix), precedenceSuffix
+	case TypeRepeat:
+		return operand(n.Front(), precedencePrimary) + c.span(n, "{"+n.String()+"}"), precedenceSuffix
+	case TypePeekFor, TypePeekNot:
+		prefix := "&amp;"
+		if n.GetType() == TypePeekNot {
+			prefix = "!"
+		}
+		return c.span(n, prefix) + operand(n.Front(), precedenceSuffix), precedencePrefix
+	case TypePredicate:
+		return "<span class=\"code\">&amp;{" + html.EscapeString(n.String()) + "}</span>", precedencePrimary
+	case TypeAction:
+		return "<span class=\"code\">{" + html.EscapeString(n.String()) + "}</span>", precedencePrimary
+	case TypePush, TypeImplicitPush:
+		label := ""
+		if n.GetType() == TypePush && n.String() != "" {
+			label = html.EscapeString(n.String()) + ":"
+		}
+		return label + "&lt;" + operand(n.Front(), precedenceAlternate) + "&gt;", precedencePrimary
+	case TypeBackReference:
+		return c.span(n, "="+html.EscapeString(n.String())), precedencePrimary
+	case TypeNil:
+		return "", precedencePrimary
+	}
+	return "", precedencePrimary
+}
+
+const coverStyle = `body { background: black; color: rgb(80, 80, 80); font-family: monospace; }
+h1, p { color: rgb(200, 200, 200); font-family: sans-serif; }
+.covered { color: rgb(44, 212, 149); }
+.partial { color: rgb(230, 200, 60); }
+.uncovered { color: rgb(192, 0, 0); }
+.code { color: rgb(120, 120, 120); }
+.rule { color: rgb(200, 200, 200); }
+`
+
+/* Write the grammar with every part colored by whether the corpus covered it. */
+func (c *coverage) writeHTML(w io.Writer, t *Tree, title string) error {
+	var out bytes.Buffer
+	covered, total := 0, 0
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule {
+			k, t := c.points(n)
+			covered, total = covered+k, total+t
+		}
+	}
+	fmt.Fprintf(&out, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%v</title>\n<style>\n%v</style>\n</head>\n<body>\n",
+		html.EscapeString(title), coverStyle)
+	fmt.Fprintf(&out, "<h1>%v</h1>\n<p>%v of %v points covered (%.1f%%). <span class=\"covered\">covered</span> "+
+		"<span class=\"partial\">taken or skipped only</span> <span class=\"uncovered\">not covered</span></p>\n<pre>\n",
+		html.EscapeString(title), covered, total, percent(covered, total))
+	for _, n := range t.Slice() {
+		if n.GetType() != TypeRule {
+			continue
+		}
+		body, _ := c.html(n)
+		fmt.Fprintf(&out, "<span class=\"rule\" id=\"rule-%v\">%v</span> = %v\n\n", n, c.span(n, n.String()), body)
+	}
+	fmt.Fprintf(&out, "</pre>\n</body>\n</html>\n")
+	_, err := out.WriteTo(w)
+	return err
+}
+
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return 100 * float64(covered) / float64(total)
+}
+
+/* Write the coverage of every rule and the total, like go tool cover -func. */
+func (c *coverage) writeSummary(w io.Writer, t *Tree) error {
+	out := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	covered, total := 0, 0
+	for _, n := range t.Slice() {
+		if n.GetType() != TypeRule {
+			continue
+		}
+		k, t := c.points(n)
+		covered, total = covered+k, total+t
+		fmt.Fprintf(out, "%v\t%.1f%%\n", n, percent(k, t))
+	}
+	fmt.Fprintf(out, "total:\t%.1f%%\n", percent(covered, total))
+	return out.Flush()
+}
+
+/* The files of the corpus, descending into directories. */
+func corpusFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func cover(arguments []string) {
+	flags := flag.NewFlagSet("cover", flag.ExitOnError)
+	output := flags.String("html", "", "write an annotated grammar to this html file")
+	start := flags.String("rule", "", "the rule to parse the corpus with, by default the first")
+	/* flags may come before, between or after the grammar and the corpus */
+	var paths []string
+	flags.Parse(arguments)
+	for flags.NArg() > 0 {
+		paths = append(paths, flags.Arg(0))
+		flags.Parse(flags.Args()[1:])
+	}
+	if len(paths) < 2 {
+		flags.Usage()
+		log.Fatalf("FILE CORPUS...: the leg file and the files or directories to parse")
+	}
+
+	t, err := parseGrammar(paths[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	c := newCoverage(t)
+	var rule Node
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule && (*start == "" || n.String() == strings.Replace(*start, "-", "_", -1)) {
+			rule = n
+			break
+		}
+	}
+	if rule == nil {
+		log.Fatalf("no rule %v in %v", *start, paths[0])
+	}
+
+	files, err := corpusFiles(paths[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, file := range files {
+		buffer, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		runes := []rune(string(buffer))
+		end, ok, err := c.run(rule, runes, 0)
+		if err != nil {
+			log.Fatalf("%v: %v", file, err)
+		} else if !ok {
+			fmt.Fprintf(os.Stderr, "%v: does not parse\n", file)
+		} else if end < len(runes) {
+			fmt.Fprintf(os.Stderr, "%v: parsed only %v of %v characters\n", file, end, len(runes))
+		}
+	}
+	if c.predicates {
+		fmt.Fprintf(os.Stderr, "predicates cannot be run and were taken to hold\n")
+	}
+
+	if err := c.writeSummary(os.Stdout, t); err != nil {
+		log.Fatal(err)
+	}
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		if err := c.writeHTML(f, t, "Coverage of "+filepath.Base(paths[0])); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
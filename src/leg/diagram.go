@@ -0,0 +1,391 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/* Railroad diagram layout in pixels; elements are entered and left along their baseline. */
+const (
+	railCharacter = 8
+	railHeight    = 24
+	railPadding   = 10
+	railGap       = 10
+	railArc       = 10
+	railMargin    = 20
+)
+
+type railroad interface {
+	size() (width, up, down int)
+	draw(w io.Writer, x, y int)
+}
+
+type railBox struct {
+	width, up, down int
+}
+
+func (b *railBox) size() (int, int, int) {
+	return b.width, b.up, b.down
+}
+
+func line(w io.Writer, x1, y1, x2, y2 int) {
+	if x1 != x2 || y1 != y2 {
+		fmt.Fprintf(w, "<path d=\"M%v %vL%v %v\"/>\n", x1, y1, x2, y2)
+	}
+}
+
+/* A literal, class or rule name drawn in a box, linked to its rule if it has one. */
+type railTerminal struct {
+	railBox
+	text, class, href, title string
+}
+
+func newRailTerminal(text, class, href, title string) *railTerminal {
+	width := len([]rune(text))*railCharacter + 2*railPadding
+	return &railTerminal{railBox{width, railHeight / 2, railHeight / 2}, text, class, href, title}
+}
+
+func (t *railTerminal) draw(w io.Writer, x, y int) {
+	if t.href != "" {
+		fmt.Fprintf(w, "<a href=\"%v\">", html.EscapeString(t.href))
+	}
+	fmt.Fprintf(w, "<g class=\"%v\">", t.class)
+	if t.title != "" {
+		fmt.Fprintf(w, "<title>%v</title>", html.EscapeString(t.title))
+	}
+	radius := 0
+	if t.class == "terminal" {
+		radius = railHeight / 2
+	}
+	fmt.Fprintf(w, "<rect x=\"%v\" y=\"%v\" width=\"%v\" height=\"%v\" rx=\"%v\"/>", x, y-t.up, t.width, railHeight, radius)
+	fmt.Fprintf(w, "<text x=\"%v\" y=\"%v\">%v</text></g>", x+t.width/2, y+4, html.EscapeString(t.text))
+	if t.href != "" {
+		fmt.Fprintf(w, "</a>")
+	}
+	fmt.Fprintln(w)
+}
+
+type railSequence struct {
+	railBox
+	items []railroad
+}
+
+func newRailSequence(items []railroad) *railSequence {
+	s := &railSequence{items: items}
+	for i, item := range items {
+		width, up, down := item.size()
+		if i > 0 {
+			s.width += railGap
+		}
+		s.width += width
+		if up > s.up {
+			s.up = up
+		}
+		if down > s.down {
+			s.down = down
+		}
+	}
+	return s
+}
+
+func (s *railSequence) draw(w io.Writer, x, y int) {
+	for i, item := range s.items {
+		if i > 0 {
+			line(w, x, y, x+railGap, y)
+			x += railGap
+		}
+		width, _, _ := item.size()
+		item.draw(w, x, y)
+		x += width
+	}
+}
+
+/* Alternatives are stacked below the first, which stays on the baseline. */
+type railChoice struct {
+	railBox
+	items   []railroad
+	offsets []int
+}
+
+func newRailChoice(items []railroad) *railChoice {
+	c := &railChoice{items: items, offsets: make([]int, len(items))}
+	for i, item := range items {
+		width, up, down := item.size()
+		if width+4*railArc > c.width {
+			c.width = width + 4*railArc
+		}
+		if i == 0 {
+			c.up, c.down = up, down
+			continue
+		}
+		c.offsets[i] = c.down + railGap + up
+		c.down = c.offsets[i] + down
+	}
+	return c
+}
+
+func (c *railChoice) draw(w io.Writer, x, y int) {
+	for i, item := range c.items {
+		width, _, _ := item.size()
+		if i == 0 {
+			line(w, x, y, x+2*railArc, y)
+			item.draw(w, x+2*railArc, y)
+			line(w, x+2*railArc+width, y, x+c.width, y)
+			continue
+		}
+		yi, left, right := y+c.offsets[i], x+railArc, x+c.width-railArc
+		fmt.Fprintf(w, "<path d=\"M%v %vQ%v %v %v %vL%v %vQ%v %v %v %v\"/>\n",
+			x, y, left, y, left, y+railArc, left, yi-railArc, left, yi, left+railArc, yi)
+		item.draw(w, left+railArc, yi)
+		line(w, left+railArc+width, yi, right-railArc, yi)
+		fmt.Fprintf(w, "<path d=\"M%v %vQ%v %v %v %vL%v %vQ%v %v %v %v\"/>\n",
+			right-railArc, yi, right, yi, right, yi-railArc, right, y+railArc, right, y, right+railArc, y)
+	}
+}
+
+/* One or more repetitions loop back underneath the item. */
+type railLoop struct {
+	railBox
+	item railroad
+}
+
+func newRailLoop(item railroad) *railLoop {
+	width, up, down := item.size()
+	return &railLoop{railBox{width + 4*railArc, up, down + railGap + railArc}, item}
+}
+
+func (l *railLoop) draw(w io.Writer, x, y int) {
+	width, _, down := l.item.size()
+	left, right, bottom := x+railArc, x+3*railArc+width, y+down+railGap+railArc
+	line(w, x, y, x+2*railArc, y)
+	l.item.draw(w, x+2*railArc, y)
+	line(w, x+2*railArc+width, y, x+l.width, y)
+	fmt.Fprintf(w, "<path d=\"M%v %vQ%v %v %v %vL%v %vQ%v %v %v %vL%v %vQ%v %v %v %vL%v %vQ%v %v %v %v\"/>\n",
+		right-railArc, y, right, y, right, y+railArc,
+		right, bottom-railArc, right, bottom, right-railArc, bottom,
+		left+railArc, bottom, left, bottom, left, bottom-railArc,
+		left, y+railArc, left, y, left+railArc, y)
+}
+
+/* Predicates and captures are drawn in a labelled frame around their expression. */
+type railGroup struct {
+	railBox
+	item  railroad
+	label string
+}
+
+func newRailGroup(item railroad, label string) *railGroup {
+	width, up, down := item.size()
+	return &railGroup{railBox{width + 2*railPadding, up + railPadding + railHeight/2, down + railPadding}, item, label}
+}
+
+func (g *railGroup) draw(w io.Writer, x, y int) {
+	width, _, _ := g.item.size()
+	fmt.Fprintf(w, "<rect class=\"group\" x=\"%v\" y=\"%v\" width=\"%v\" height=\"%v\"/>\n",
+		x, y-g.up+railHeight/2, g.width, g.up+g.down-railHeight/2)
+	fmt.Fprintf(w, "<text class=\"label\" x=\"%v\" y=\"%v\">%v</text>\n", x, y-g.up+railHeight/2-3, html.EscapeString(g.label))
+	line(w, x, y, x+railPadding, y)
+	g.item.draw(w, x+railPadding, y)
+	line(w, x+railPadding+width, y, x+g.width, y)
+}
+
+type railSkip struct {
+	railBox
+}
+
+func (s *railSkip) draw(w io.Writer, x, y int) {}
+
+/* The text of a node which can be drawn as a single terminal: a literal, a class or the dot. */
+func railText(n Node) (string, bool) {
+	switch n.GetType() {
+	case TypeDot:
+		return ".", true
+	case TypeCharacter:
+		return "'" + escape(n.String()) + "'", true
+	case TypeString:
+		return "'" + escape(n.String()) + "'", true
+	case TypeClass:
+		var text bytes.Buffer
+		printRule(&text, n)
+		return text.String(), true
+	case TypeSequence:
+		text := ""
+		for _, element := range n.Slice() {
+			if element.GetType() != TypeCharacter {
+				return "", false
+			}
+			text += escape(element.String())
+		}
+		return "'" + text + "'", true
+	case TypeRange:
+		return "[" + escape(n.Front().String()) + "-" + escape(n.Front().Next().String()) + "]", true
+	case TypeAlternate:
+		text := ""
+		for _, element := range n.Slice() {
+			switch element.GetType() {
+			case TypeCharacter:
+				text += escape(element.String())
+			case TypeRange:
+				text += escape(element.Front().String()) + "-" + escape(element.Front().Next().String())
+			default:
+				return "", false
+			}
+		}
+		return "[" + text + "]", true
+	}
+	return "", false
+}
+
+func railroadOf(n Node, defined map[string]bool) railroad {
+	if text, ok := railText(n); ok {
+		return newRailTerminal(text, "terminal", "", "")
+	}
+	switch n.GetType() {
+	case TypeRule:
+		return railroadOf(n.Front(), defined)
+	case TypeName:
+		if defined[n.String()] {
+			return newRailTerminal(n.String(), "nonterminal", "#rule-"+n.String(), "")
+		}
+		return newRailTerminal(n.String(), "undefined", "", "rule not defined")
+	case TypeSequence:
+		var items []railroad
+		for _, element := range n.Slice() {
+			if element.GetType() != TypeNil {
+				items = append(items, railroadOf(element, defined))
+			}
+		}
+		return newRailSequence(items)
+	case TypeAlternate, TypeUnorderedAlternate:
+		var items []railroad
+		for _, element := range n.Slice() {
+			items = append(items, railroadOf(element, defined))
+		}
+		return newRailChoice(items)
+	case TypeQuery:
+		return newRailChoice([]railroad{railroadOf(n.Front(), defined), &railSkip{}})
+	case TypeStar:
+		return newRailChoice([]railroad{newRailLoop(railroadOf(n.Front(), defined)), &railSkip{}})
+	case TypePlus:
+		return newRailLoop(railroadOf(n.Front(), defined))
+	case TypeRepeat:
+		min, max := repeatBounds(n.String())
+		label := fmt.Sprintf("%v to %v times", min, max)
+		switch {
+		case max < 0:
+			label = fmt.Sprintf("%v or more times", min)
+		case max == min:
+			label = fmt.Sprintf("%v times", min)
+		}
+		loop := newRailGroup(newRailLoop(railroadOf(n.Front(), defined)), label)
+		if min == 0 {
+			return newRailChoice([]railroad{loop, &railSkip{}})
+		}
+		return loop
+	case TypePeekFor:
+		return newRailGroup(railroadOf(n.Front(), defined), "followed by")
+	case TypePeekNot:
+		return newRailGroup(railroadOf(n.Front(), defined), "not followed by")
+	case TypePush, TypeImplicitPush:
+		if n.GetType() == TypePush && n.String() != "" {
+			return newRailGroup(railroadOf(n.Front(), defined), "capture "+n.String())
+		}
+		return newRailGroup(railroadOf(n.Front(), defined), "capture")
+	case TypeBackReference:
+		return newRailTerminal("="+n.String(), "terminal", "", "the text captured as "+n.String())
+	case TypePredicate:
+		return newRailTerminal("&{ }", "code", "", n.String())
+	case TypeAction:
+		return newRailTerminal("{ }", "code", "", n.String())
+	}
+	return &railSkip{}
+}
+
+const railStyle = `body { font-family: sans-serif; }
+svg path, svg rect { stroke: #333; stroke-width: 1.5; fill: none; }
+svg g.terminal rect { fill: #ffc; }
+svg g.nonterminal rect { fill: #cdf; }
+svg g.undefined rect { fill: #fcc; }
+svg g.code rect { fill: #eee; stroke-dasharray: 2 2; }
+svg rect.group { stroke: #999; stroke-dasharray: 4 3; }
+svg text { font-family: monospace; font-size: 13px; text-anchor: middle; }
+svg text.label { font-family: sans-serif; font-size: 10px; text-anchor: start; fill: #666; }
+svg a:hover rect { stroke: #06c; }
+pre { color: #666; }
+`
+
+/* Write every rule of the grammar as a railroad diagram on one HTML page. */
+func (t *Tree) WriteDiagrams(w io.Writer, title string) error {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%v</title>\n<style>\n%v</style>\n</head>\n<body>\n<h1>%v</h1>\n",
+		html.EscapeString(title), railStyle, html.EscapeString(title))
+	defined := make(map[string]bool)
+	for _, rule := range t.Slice() {
+		if rule.GetType() == TypeRule {
+			defined[rule.String()] = true
+		}
+	}
+	for _, rule := range t.Slice() {
+		if rule.GetType() != TypeRule {
+			continue
+		}
+		diagram := railroadOf(rule, defined)
+		width, up, down := diagram.size()
+		x, y := railMargin+railArc, railMargin+up
+		fmt.Fprintf(&out, "<h2 id=\"rule-%v\"><a href=\"#rule-%v\">%v</a></h2>\n", rule, rule, rule)
+		fmt.Fprintf(&out, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%v\" height=\"%v\">\n",
+			width+2*railMargin+2*railArc, up+down+2*railMargin)
+		fmt.Fprintf(&out, "<path d=\"M%v %vL%v %vM%v %vL%v %v\"/>\n", railMargin, y-railArc, railMargin, y+railArc, railMargin, y, x, y)
+		diagram.draw(&out, x, y)
+		fmt.Fprintf(&out, "<path d=\"M%v %vL%v %vM%v %vL%v %v\"/>\n", x+width, y, x+width+railArc, y,
+			x+width+railArc, y-railArc, x+width+railArc, y+railArc)
+		fmt.Fprintf(&out, "</svg>\n<pre>")
+		var form bytes.Buffer
+		printRule(&form, rule)
+		fmt.Fprintf(&out, "%v</pre>\n", html.EscapeString(form.String()))
+	}
+	fmt.Fprintf(&out, "</body>\n</html>\n")
+	_, err := out.WriteTo(w)
+	return err
+}
+
+/* leg diagram: draw the rules of a grammar as railroad diagrams */
+func diagram(arguments []string) {
+	flags := flag.NewFlagSet("diagram", flag.ExitOnError)
+	output := flags.String("o", "", "write the HTML page to this file instead of stdout")
+	flags.Parse(arguments)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		log.Fatalf("FILE: the leg file to draw")
+	}
+	file := flags.Arg(0)
+
+	t, err := parseGrammar(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	title := strings.TrimSuffix(filepath.Base(file), ".leg")
+	if err := t.WriteDiagrams(out, title); err != nil {
+		log.Fatal(err)
+	}
+}
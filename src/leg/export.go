@@ -0,0 +1,689 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/* Precedence of the expressions, loosest first. */
+const (
+	precedenceAlternate = iota
+	precedenceSequence
+	precedencePrefix
+	precedenceSuffix
+	precedencePrimary
+)
+
+var exportFormats = []string{"ebnf", "abnf", "pigeon", "pointlander-peg"}
+
+/* Writes a grammar out in the notation of another parser generator. */
+type exporter struct {
+	format string
+	notes  []string
+	/* whether the rule being written labels any of its names with a variable */
+	variables bool
+	/* whether notes go with the rule rather than where they were taken */
+	quiet bool
+}
+
+func character(n Node) (rune, bool) {
+	if n.GetType() != TypeCharacter {
+		return 0, false
+	}
+	runes := []rune(n.String())
+	if len(runes) != 1 {
+		return 0, false
+	}
+	return runes[0], true
+}
+
+/* A double quoted character is an alternate of its lower and upper case. */
+func insensitiveCharacter(n Node) (rune, bool) {
+	if n.GetType() != TypeAlternate || n.Len() != 2 {
+		return 0, false
+	}
+	a, ok := character(n.Front())
+	if !ok {
+		return 0, false
+	}
+	b, ok := character(n.Front().Next())
+	if !ok || a == b || unicode.ToLower(a) != unicode.ToLower(b) {
+		return 0, false
+	}
+	return unicode.ToLower(a), true
+}
+
+/* The text of a literal, which may be case insensitive. */
+func literalOf(n Node) (text string, insensitive bool, ok bool) {
+	if c, ok := character(n); ok {
+		return string(c), false, true
+	}
+	if c, ok := insensitiveCharacter(n); ok {
+		return string(c), true, true
+	}
+	if n.GetType() == TypeString {
+		return n.String(), false, true
+	}
+	return "", false, false
+}
+
+/* The ranges of a character class, and whether it is negated. */
+func classOf(n Node) (r ranges, negated bool, ok bool) {
+	switch n.GetType() {
+	case TypeCharacter:
+		if c, ok := character(n); ok {
+			return ranges{{c, c}}, false, true
+		}
+	case TypeRange:
+		lower, lok := character(n.Front())
+		upper, uok := character(n.Front().Next())
+		if lok && uok {
+			return ranges{{lower, upper}}, false, true
+		}
+	case TypeAlternate:
+		for _, element := range n.Slice() {
+			s, negated, ok := classOf(element)
+			if !ok || negated {
+				return nil, false, false
+			}
+			r = append(r, s...)
+		}
+		return r, false, true
+	case TypeClass:
+		r, ok := classRanges(n)
+		if ok && r.contains(unicode.MaxRune) {
+			return r.complement(), true, true
+		}
+		return r, false, ok
+	case TypeSequence:
+		/* [^...] is parsed as !class . */
+		elements := n.Slice()
+		if len(elements) == 2 && elements[0].GetType() == TypePeekNot && elements[1].GetType() == TypeDot {
+			if r, negated, ok := classOf(elements[0].Front()); ok && !negated {
+				return r, true, true
+			}
+		}
+	}
+	return nil, false, false
+}
+
+/* Whether a rule labels any of its names with a variable, as in l:Sum. */
+func declaresVariables(n Node) bool {
+	if n.GetType() == TypeName && n.Front() != nil && n.Front().GetType() == TypeVariable {
+		return true
+	}
+	for _, element := range n.Slice() {
+		if declaresVariables(element) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *exporter) name(name string) string {
+	if e.format != "abnf" {
+		return name
+	}
+	/* ABNF rule names are ALPHA *(ALPHA / DIGIT / "-") */
+	name = strings.Replace(name, "_", "-", -1)
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "r" + name
+	}
+	return name
+}
+
+func (e *exporter) literal(text string, insensitive bool) string {
+	switch e.format {
+	case "ebnf":
+		var parts []string
+		quoted := ""
+		flush := func() {
+			if quoted != "" {
+				quote := "\""
+				if strings.Contains(quoted, "\"") {
+					quote = "'"
+				}
+				parts, quoted = append(parts, quote+quoted+quote), ""
+			}
+		}
+		for _, c := range text {
+			if unicode.IsPrint(c) && !(c == '"' && strings.Contains(quoted, "'")) && !(c == '\'' && strings.Contains(quoted, "\"")) {
+				quoted += string(c)
+				continue
+			}
+			flush()
+			parts = append(parts, fmt.Sprintf("#x%X", c))
+		}
+		flush()
+		if insensitive {
+			for i, part := range parts {
+				parts[i] = e.insensitive(part)
+			}
+		}
+		return strings.Join(parts, " ")
+	case "abnf":
+		printable := true
+		for _, c := range text {
+			if c < 0x20 || c > 0x7E || c == '"' {
+				printable = false
+			}
+		}
+		if printable && insensitive {
+			return "\"" + text + "\""
+		} else if printable {
+			return "%s\"" + text + "\""
+		}
+		var codes []string
+		for _, c := range text {
+			if insensitive && unicode.ToLower(c) != unicode.ToUpper(c) {
+				return e.insensitive(text)
+			}
+			codes = append(codes, fmt.Sprintf("%X", c))
+		}
+		return "%x" + strings.Join(codes, ".")
+	case "pigeon":
+		s := strconv.Quote(text)
+		if insensitive {
+			s += "i"
+		}
+		return s
+	default:
+		quote := "'"
+		if insensitive {
+			quote = "\""
+		}
+		s := ""
+		for _, c := range text {
+			s += escapePEG(c, quote)
+		}
+		return quote + s + quote
+	}
+}
+
+/* Spell out a case insensitive EBNF or ABNF literal one class per letter. */
+func (e *exporter) insensitive(text string) string {
+	if e.format == "ebnf" && (strings.HasPrefix(text, "#x") || len(text) < 3) {
+		return text
+	}
+	if e.format == "ebnf" {
+		text = text[1 : len(text)-1]
+	}
+	var parts []string
+	for _, c := range text {
+		if unicode.ToLower(c) == unicode.ToUpper(c) {
+			parts = append(parts, e.literal(string(c), false))
+		} else {
+			parts = append(parts, e.class(ranges{{unicode.ToLower(c), unicode.ToLower(c)}, {unicode.ToUpper(c), unicode.ToUpper(c)}}, false))
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func escapePEG(c rune, quote string) string {
+	switch c {
+	case '\a':
+		return "\\a"
+	case '\b':
+		return "\\b"
+	case '\x1B':
+		return "\\e"
+	case '\f':
+		return "\\f"
+	case '\n':
+		return "\\n"
+	case '\r':
+		return "\\r"
+	case '\t':
+		return "\\t"
+	case '\v':
+		return "\\v"
+	case '\\':
+		return "\\\\"
+	case '[', ']', '-':
+		if quote == "" {
+			return "\\" + string(c)
+		}
+	}
+	if string(c) == quote {
+		return "\\" + quote
+	}
+	if !unicode.IsPrint(c) && c < 0400 {
+		return fmt.Sprintf("\\%03o", c)
+	}
+	return string(c)
+}
+
+func (e *exporter) class(r ranges, negated bool) string {
+	switch e.format {
+	case "ebnf":
+		s := "["
+		if negated {
+			s += "^"
+		}
+		code := func(c rune) string {
+			if unicode.IsPrint(c) && !strings.ContainsRune("]-^\\#", c) {
+				return string(c)
+			}
+			return fmt.Sprintf("#x%X", c)
+		}
+		for _, p := range r {
+			s += code(p[0])
+			if p[1] != p[0] {
+				s += "-" + code(p[1])
+			}
+		}
+		return s + "]"
+	case "abnf":
+		if negated {
+			r = r.complement()
+		}
+		var parts []string
+		for _, p := range r {
+			if p[0] == p[1] {
+				parts = append(parts, fmt.Sprintf("%%x%X", p[0]))
+			} else {
+				parts = append(parts, fmt.Sprintf("%%x%X-%X", p[0], p[1]))
+			}
+		}
+		if len(parts) == 1 {
+			return parts[0]
+		}
+		return "(" + strings.Join(parts, " / ") + ")"
+	default:
+		s := "["
+		if negated {
+			s += "^"
+		}
+		code := func(c rune) string {
+			if e.format == "pigeon" {
+				if strings.ContainsRune("]-^\\", c) {
+					return "\\" + string(c)
+				}
+				q := strconv.QuoteRune(c)
+				return q[1 : len(q)-1]
+			}
+			return escapePEG(c, "")
+		}
+		for _, p := range r {
+			s += code(p[0])
+			if p[1] != p[0] {
+				s += "-" + code(p[1])
+			}
+		}
+		return s + "]"
+	}
+}
+
+/* Note a part of a rule which has no equivalent in the target notation. */
+func (e *exporter) note(what string, n Node) string {
+	var form bytes.Buffer
+	printRule(&form, n)
+	text := strings.Replace(form.String(), "\n", " ", -1)
+	if e.quiet {
+		e.notes = append(e.notes, what+" "+text)
+		return ""
+	}
+	switch e.format {
+	case "ebnf":
+		return "/* " + what + " " + strings.Replace(text, "*/", "* /", -1) + " */"
+	case "pigeon":
+		return "/* " + strings.Replace(text, "*/", "* /", -1) + " */"
+	case "pointlander-peg":
+		return "# " + text + "\n"
+	}
+	e.notes = append(e.notes, what+" "+text)
+	return ""
+}
+
+func (e *exporter) expression(n Node) (string, int) {
+	if r, negated, ok := classOf(n); ok && (negated || n.GetType() != TypeCharacter) {
+		return e.class(r, negated), precedencePrimary
+	}
+	if text, insensitive, ok := literalOf(n); ok {
+		return e.literal(text, insensitive), precedencePrimary
+	}
+	operand := func(n Node, precedence int) string {
+		s, p := e.expression(n)
+		if p < precedence {
+			return "(" + s + ")"
+		}
+		return s
+	}
+	switch n.GetType() {
+	case TypeRule:
+		return e.expression(n.Front())
+	case TypeName:
+		name := e.name(n.String())
+		if v := n.Front(); v != nil && v.GetType() == TypeVariable && e.format == "pigeon" {
+			name = v.String() + ":" + name
+		}
+		return name, precedencePrimary
+	case TypeDot:
+		switch e.format {
+		case "ebnf":
+			return "[#x0-#x10FFFF]", precedencePrimary
+		case "abnf":
+			return "%x0-10FFFF", precedencePrimary
+		}
+		return ".", precedencePrimary
+	case TypeSequence:
+		/* runs of characters are joined back into literals */
+		var elements []string
+		precedence, run, insensitive, letters := precedencePrimary, "", false, false
+		flush := func() {
+			if run != "" {
+				elements, precedence, run = append(elements, e.literal(run, insensitive)), precedencePrimary, ""
+			}
+		}
+		for _, element := range n.Slice() {
+			text, caseless, ok := literalOf(element)
+			if ok && element.GetType() != TypeString && (run == "" || unicode.ToLower([]rune(text)[0]) == unicode.ToUpper([]rune(text)[0]) ||
+				!letters || insensitive == caseless) {
+				if run == "" {
+					insensitive, letters = false, false
+				}
+				if c := []rune(text)[0]; unicode.ToLower(c) != unicode.ToUpper(c) {
+					insensitive, letters = caseless, true
+				}
+				run += text
+				continue
+			}
+			flush()
+			s, p := e.expression(element)
+			if s == "" {
+				continue
+			}
+			if p < precedenceSequence {
+				s, p = "("+s+")", precedencePrimary
+			}
+			elements, precedence = append(elements, s), p
+		}
+		flush()
+		if len(elements) == 0 {
+			return "", precedencePrimary
+		} else if len(elements) == 1 {
+			return elements[0], precedence
+		}
+		return strings.Join(elements, " "), precedenceSequence
+	case TypeAlternate, TypeUnorderedAlternate:
+		var elements []string
+		for _, element := range n.Slice() {
+			if e.omitted(element) {
+				/* written out it would be an empty alternative, matching where the original may not */
+				quiet := e.quiet
+				e.quiet = true
+				e.expression(element)
+				e.quiet = quiet
+				continue
+			}
+			s := operand(element, precedenceSequence)
+			if s == "" {
+				s = e.empty()
+			}
+			elements = append(elements, s)
+		}
+		separator := " / "
+		if e.format == "ebnf" {
+			separator = " | "
+		}
+		return strings.Join(elements, separator), precedenceAlternate
+	case TypeQuery, TypeStar, TypePlus:
+		s := operand(n.Front(), precedencePrimary)
+		if s == "" {
+			return "", precedencePrimary
+		}
+		if e.format == "abnf" {
+			switch n.GetType() {
+			case TypeQuery:
+				return "[" + strings.TrimSuffix(strings.TrimPrefix(s, "("), ")") + "]", precedencePrimary
+			case TypeStar:
+				return "*" + s, precedenceSuffix
+			}
+			return "1*" + s, precedenceSuffix
+		}
+		return s + map[Type]string{TypeQuery: "?", TypeStar: "*", TypePlus: "+"}[n.GetType()], precedenceSuffix
+	case TypeRepeat:
+		s := operand(n.Front(), precedencePrimary)
+		min, max := repeatBounds(n.String())
+		if s == "" || max == 0 {
+			return "", precedencePrimary
+		}
+		if e.format == "abnf" {
+			switch {
+			case max == min:
+				return fmt.Sprintf("%v%v", min, s), precedenceSuffix
+			case max < 0 && min == 0:
+				return "*" + s, precedenceSuffix
+			case max < 0:
+				return fmt.Sprintf("%v*%v", min, s), precedenceSuffix
+			}
+			return fmt.Sprintf("%v*%v%v", min, max, s), precedenceSuffix
+		}
+		/* the other formats have no counts, so the element is written out that many times */
+		var elements []string
+		for i := 0; i < min; i++ {
+			elements = append(elements, s)
+		}
+		optional := ""
+		if max < 0 {
+			optional = s + "*"
+		}
+		for i := min; i < max; i++ {
+			if optional == "" {
+				optional = s + "?"
+			} else {
+				optional = "(" + s + " " + optional + ")?"
+			}
+		}
+		if optional != "" {
+			elements = append(elements, optional)
+		}
+		if len(elements) == 1 {
+			return elements[0], precedenceSuffix
+		}
+		return strings.Join(elements, " "), precedenceSequence
+	case TypePeekFor, TypePeekNot:
+		prefix := "&"
+		if n.GetType() == TypePeekNot {
+			prefix = "!"
+		}
+		if e.format == "pigeon" || e.format == "pointlander-peg" {
+			return prefix + operand(n.Front(), precedenceSuffix), precedencePrefix
+		}
+		return e.note("lookahead", n), precedencePrimary
+	case TypePredicate:
+		switch e.format {
+		case "pigeon":
+			return "&{ return " + strings.TrimSpace(n.String()) + ", nil }", precedencePrimary
+		case "pointlander-peg":
+			return "&{" + n.String() + "}", precedencePrimary
+		}
+		return e.note("predicate", n), precedencePrimary
+	case TypeAction:
+		switch e.format {
+		case "pigeon":
+			return e.note("action", n), precedencePrimary
+		case "pointlander-peg":
+			/* pointlander peg has neither $$ nor the variables the names are labelled with */
+			if e.variables || strings.Contains(n.String(), "$$") {
+				return e.note("action", n), precedencePrimary
+			}
+			return "{" + n.String() + "}", precedencePrimary
+		}
+		return "", precedencePrimary
+	case TypePush, TypeImplicitPush:
+		if e.format == "pointlander-peg" {
+			return "<" + operand(n.Front(), precedenceAlternate) + ">", precedencePrimary
+		}
+		return e.expression(n.Front())
+	case TypeBackReference:
+		/* none of the formats can match captured text again */
+		return e.note("back reference", n), precedencePrimary
+	case TypeNil:
+		return e.empty(), precedencePrimary
+	}
+	return "", precedencePrimary
+}
+
+/* Whether nothing of n but notes is left in the target notation. */
+func (e *exporter) omitted(n Node) bool {
+	grammar := e.format == "ebnf" || e.format == "abnf"
+	switch n.GetType() {
+	case TypePeekFor, TypePeekNot, TypePredicate:
+		return grammar
+	case TypeBackReference:
+		return true
+	case TypePush, TypeImplicitPush:
+		return e.omitted(n.Front())
+	case TypeSequence:
+		/* actions are dropped silently, but alone they match nothing more than an empty alternative */
+		dropped := false
+		for _, element := range n.Slice() {
+			if e.omitted(element) {
+				dropped = true
+			} else if !grammar || element.GetType() != TypeAction {
+				return false
+			}
+		}
+		return dropped
+	}
+	return false
+}
+
+func (e *exporter) empty() string {
+	if e.format == "pointlander-peg" {
+		return "''"
+	}
+	return "\"\""
+}
+
+func comment(format, text string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		switch format {
+		case "abnf":
+			lines = append(lines, "; "+line)
+		case "pointlander-peg":
+			lines = append(lines, "# "+line)
+		default:
+			lines = append(lines, "// "+line)
+		}
+	}
+	if format == "ebnf" {
+		return "/*\n" + strings.Replace(text, "*/", "* /", -1) + "\n*/\n"
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+/* Write the grammar in the given format: ebnf, abnf, pigeon or pointlander-peg. */
+func (t *Tree) Export(w io.Writer, format string) error {
+	e := &exporter{format: format}
+	var out bytes.Buffer
+	var packageName, structName, state string
+	for _, n := range t.Slice() {
+		switch n.GetType() {
+		case TypePackage:
+			packageName = n.String()
+		case TypeLeg:
+			structName = n.String()
+			if n.Front() != nil {
+				state = n.Front().String()
+			}
+		}
+	}
+
+	switch format {
+	case "ebnf", "abnf":
+		out.WriteString(comment(format, fmt.Sprintf("%v grammar, converted from leg", structName)))
+	case "pigeon":
+		fmt.Fprintf(&out, "{\npackage %v\n", packageName)
+		for _, declaration := range t.Declarations {
+			fmt.Fprintf(&out, "\n%v\n", strings.TrimSpace(declaration))
+		}
+		out.WriteString("}\n")
+	case "pointlander-peg":
+		fmt.Fprintf(&out, "package %v\n\ntype %v Peg {%v}\n", packageName, structName, state)
+		for _, declaration := range t.Declarations {
+			out.WriteString("\n" + comment(format, declaration))
+		}
+	default:
+		return fmt.Errorf("unknown format %v, use one of %v", format, strings.Join(exportFormats, ", "))
+	}
+
+	for _, n := range t.Slice() {
+		if n.GetType() != TypeRule {
+			continue
+		}
+		e.notes, e.variables = nil, declaresVariables(n)
+		body, _ := e.expression(n)
+		body = strings.TrimRight(body, "\n")
+		if body == "" {
+			body = e.empty()
+		}
+		out.WriteString("\n")
+		seen := make(map[string]bool)
+		for _, note := range e.notes {
+			if !seen[note] {
+				seen[note] = true
+				out.WriteString(comment(format, "not expressible: "+note))
+			}
+		}
+		switch format {
+		case "ebnf":
+			fmt.Fprintf(&out, "%v ::= %v\n", e.name(n.String()), body)
+		case "abnf":
+			fmt.Fprintf(&out, "%v = %v\n", e.name(n.String()), body)
+		default:
+			fmt.Fprintf(&out, "%v <- %v\n", e.name(n.String()), body)
+		}
+	}
+
+	if t.Trailer != "" {
+		out.WriteString("\n" + comment(format, t.Trailer))
+	}
+	_, err := out.WriteTo(w)
+	return err
+}
+
+/* leg export: write a grammar in the notation of another tool */
+func export(arguments []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", "ebnf", "the notation to write: "+strings.Join(exportFormats, ", "))
+	output := flags.String("o", "", "write the grammar to this file instead of stdout")
+	flags.Parse(arguments)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		log.Fatalf("FILE: the leg file to export")
+	}
+
+	t, err := parseGrammar(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := t.Export(out, *format); err != nil {
+		log.Fatal(err)
+	}
+}
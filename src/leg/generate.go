@@ -0,0 +1,161 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+var (
+	inline      = flag.Bool("inline", false, "parse rule inlining")
+	_switch     = flag.Bool("switch", false, "replace if-else if-else like blocks with switch blocks")
+	syntax      = flag.Bool("syntax", false, "print out the syntax tree")
+	highlight   = flag.Bool("highlight", false, "print the grammar with syntax highlighting")
+	test        = flag.Bool("test", false, "also write a _leg_test.go with a benchmark and a fuzz test of the parser")
+	print       = flag.Bool("print", false, "directly dump the syntax tree")
+	tree        = flag.String("tree", "", "write the syntax tree as json, sexp or dot")
+	trace       = flag.Bool("trace", false, "generate a parser reporting rule events to a Tracer, for tracing and profiling")
+	output      = flag.String("o", "", "write the parser to this file instead of FILE.go, - for stdout")
+	_package    = flag.String("package", "", "the package of the parser, instead of the one the grammar declares")
+	check       = flag.Bool("check", false, "write nothing, but exit with 1 when the parser file, or with -test its harness, is not what the grammar generates")
+	emitBuilder = flag.Bool("emit-builder", false, "write the Go program which builds the grammar's tree, as the bootstrap does, instead of the parser")
+)
+
+/* What the compiler needs of the parser a front end parsed the grammar with. */
+type grammarParser interface {
+	Print()
+	PrintSyntaxTree()
+	WriteJSON(w io.Writer) error
+	WriteSExpression(w io.Writer) error
+	WriteDOT(w io.Writer) error
+	HighlightANSI(w io.Writer, styles map[Rule]string, colors map[string]string) error
+}
+
+/* Parse the flags and read the grammar file they name, from stdin when it is -. */
+func readGrammar(dialect string) (string, string) {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		log.Fatalf("FILE: the %v file to compile, - for stdin", dialect)
+	}
+	file := flag.Arg(0)
+
+	var buffer []byte
+	var err error
+	if file == "-" {
+		buffer, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		buffer, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return file, string(buffer)
+}
+
+/* Write the parser of the tree t, which p parsed from file, or whatever else the flags ask for. */
+func generate(p grammarParser, t *Tree, file string) {
+	var err error
+	if *print {
+		p.Print()
+	}
+	if *syntax {
+		p.PrintSyntaxTree()
+	}
+	switch *tree {
+	case "":
+	case "json":
+		err = p.WriteJSON(os.Stdout)
+	case "sexp":
+		err = p.WriteSExpression(os.Stdout)
+	case "dot":
+		err = p.WriteDOT(os.Stdout)
+	default:
+		err = fmt.Errorf("unknown syntax tree format: %v", *tree)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *highlight {
+		if err := p.HighlightANSI(os.Stdout, nil, nil); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if t.Front() == nil || t.Front().GetType() != TypePackage {
+		log.Fatalf("%v: a grammar to compile starts with its package and parser declarations", file)
+	}
+	if *_package != "" {
+		t.Front().SetString(*_package)
+	}
+	if *emitBuilder {
+		out := os.Stdout
+		if *output != "" && *output != "-" {
+			if out, err = os.Create(*output); err != nil {
+				log.Fatal(err)
+			}
+			defer out.Close()
+		}
+		if err := t.WriteBuilder(out, "bootstrap"+filepath.Ext(file)+".go"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	filename := *output
+	if filename == "" {
+		filename = file + ".go"
+		if file == "-" {
+			filename = "-"
+		}
+	}
+	t.Trace = *trace
+	t.Harness = *test
+
+	switch {
+	case *check:
+		var generated bytes.Buffer
+		if err := t.Generate(&generated, filename); err != nil {
+			log.Fatal(err)
+		}
+		current, err := ioutil.ReadFile(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !bytes.Equal(current, generated.Bytes()) {
+			fmt.Fprintf(os.Stderr, "%v is out of date with %v\n", filename, file)
+			os.Exit(1)
+		}
+		if *test {
+			harness := HarnessFile(filename)
+			generated.Reset()
+			if err := t.GenerateHarness(&generated, harness); err != nil {
+				log.Fatal(err)
+			}
+			if current, err = ioutil.ReadFile(harness); err != nil {
+				log.Fatal(err)
+			}
+			if !bytes.Equal(current, generated.Bytes()) {
+				fmt.Fprintf(os.Stderr, "%v is out of date with %v\n", harness, file)
+				os.Exit(1)
+			}
+		}
+	case filename == "-":
+		var generated bytes.Buffer
+		if err := t.Generate(&generated, "-"); err != nil {
+			log.Fatal(err)
+		}
+		generated.WriteTo(os.Stdout)
+	default:
+		if err := t.Compile(filename); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+/* Parse a grammar file into its Tree as it is, leaving its imports unresolved. */
+func parseFile(file string) (*Tree, error) {
+	buffer, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	p := &Leg{Tree: New(false, false), Buffer: string(buffer)}
+	p.SetSource(file, p.Buffer)
+	p.Init()
+	if err := p.Parse(); err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	p.Execute()
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return p.Tree, nil
+}
+
+/* Merge the rules of the files file imports into t, each file found relative to the one importing it. */
+func loadImports(t *Tree, file string) error {
+	return loadImportsFrom(t, file, nil)
+}
+
+/* The files importing file, outermost first, are in importers, so that cycles can be found. */
+func loadImportsFrom(t *Tree, file string, importers []string) error {
+	importers = append(importers, file)
+	for _, i := range t.Imports {
+		path := i.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+		for j, importer := range importers {
+			if filepath.Clean(importer) == path {
+				return fmt.Errorf("import cycle: %v imports %v", strings.Join(importers[j:], " imports "), path)
+			}
+		}
+		imported, err := parseFile(path)
+		if err != nil {
+			return err
+		}
+		if err := loadImportsFrom(imported, path, importers); err != nil {
+			return err
+		}
+		if err := t.Merge(imported, i, path); err != nil {
+			return fmt.Errorf("%v: %v", file, err)
+		}
+	}
+	return nil
+}
@@ -9,22 +9,39 @@ import (
     "fmt"
     "go/parser"
     "go/printer"
+    "go/scanner"
     "go/token"
+    "io"
+    "io/ioutil"
     "os"
+    "path/filepath"
+    "sort"
     "strconv"
     "strings"
     "text/template"
     "regexp"
+    "unicode"
+    "unicode/utf8"
 )
 
-const LEG_HEADER_TEMPLATE = `package {{.PackageName}}
+const LEG_HEADER_TEMPLATE = `// Code generated by leg. DO NOT EDIT.
+
+package {{.PackageName}}
 
 import (
-    /*"bytes"*/
+    "bytes"
+    "encoding/json"
     "fmt"
+    "html"
+    "io"
     "math"
     "sort"
     "strconv"
+    "strings"
+    {{- if .Trace}}
+    "text/tabwriter"
+    "time"
+    {{- end}}
 )
 
 const END_SYMBOL rune = {{.EndSymbol}}
@@ -63,11 +80,14 @@ type TokenTree interface {
     Print()
     PrintSyntax()
     PrintSyntaxTree(buffer string)
+    Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune))
+    ParseTree() *ParseNode
     Add(rule Rule, begin, end, next, depth int)
     Expand(index int) TokenTree
     Tokens() <-chan token32
     Error() []token32
     trim(length int)
+    token(index int) token32
 }
 
 {{range .Sizes}}
@@ -82,6 +102,11 @@ func (t *token{{.}}) isZero() bool {
     return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
 }
 
+/* Semantic variable stack operations are not part of the syntax tree. */
+func (t *token{{.}}) isStackOperation() bool {
+    return t.Rule == RuleActionPush || t.Rule == RuleActionPop || t.Rule == RuleActionSet
+}
+
 func (t *token{{.}}) isParentOf(u token{{.}}) bool {
     return t.begin <= u.begin && t.end >= u.end && t.next > u.next
 }
@@ -103,6 +128,10 @@ func (t *tokens{{.}}) trim(length int) {
     t.tree = t.tree[0:length]
 }
 
+func (t *tokens{{.}}) token(index int) token32 {
+    return t.tree[index].GetToken32()
+}
+
 func (t *tokens{{.}}) Print() {
     for _, token := range t.tree {
         fmt.Println(token.String())
@@ -120,6 +149,9 @@ func (t *tokens{{.}}) Order() [][]token{{.}} {
             t.tree = t.tree[:i]
             break
         }
+        if token.isStackOperation() {
+            continue
+        }
         depth := int(token.next)
         if length := len(depths); depth >= length {
             depths = depths[:depth + 1]
@@ -135,6 +167,9 @@ func (t *tokens{{.}}) Order() [][]token{{.}} {
     }
 
     for i, token := range t.tree {
+        if token.isStackOperation() {
+            continue
+        }
         depth := token.next
         token.next = int{{.}}(i)
         ordered[depth][depths[depth]] = token
@@ -273,6 +308,71 @@ func (t *tokens{{.}}) PrintSyntaxTree(buffer string) {
     }
 }
 
+/* Split the buffer into runs of text, each with the class of the innermost rule styled. */
+func (t *tokens{{.}}) Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune)) {
+    tokens, ordered := t.PreOrder()
+    position, mark, class := 0, 0, ""
+    run := func(c string, end int) {
+        if c != class {
+            if mark < position {
+                write(class, buffer[mark:position])
+            }
+            mark, class = position, c
+        }
+        position = end
+    }
+    for token := range tokens {
+        if !token.leaf {
+            continue
+        }
+        begin, end := int(token.begin), int(token.end)
+        if end > len(buffer) {
+            end = len(buffer)
+        }
+        if begin > position {
+            run("", begin)
+        }
+        if end <= position {
+            continue
+        }
+        c, ok := styles[token.Rule]
+        for i := int(token.next) - 1; !ok && i >= 0; i-- {
+            c, ok = styles[ordered[i][token.depths[i] - 1].Rule]
+        }
+        run(c, end)
+    }
+    run("", len(buffer))
+    if mark < position {
+        write(class, buffer[mark:position])
+    }
+}
+
+/* Build the parse tree; tokens are stored in post order, so children come before their parent. */
+func (t *tokens{{.}}) ParseTree() *ParseNode {
+    var levels [][]*ParseNode
+    for _, token := range t.tree {
+        if token.Rule == RuleUnknown {
+            break
+        }
+        if token.isStackOperation() {
+            continue
+        }
+        depth := int(token.next)
+        for len(levels) < depth + 2 {
+            levels = append(levels, nil)
+        }
+        n := &ParseNode{Rule: token.Rule, Begin: int(token.begin), End: int(token.end), Children: levels[depth + 1]}
+        levels[depth + 1], levels[depth] = nil, append(levels[depth], n)
+    }
+    if len(levels) == 0 {
+        return &ParseNode{}
+    } else if roots := levels[0]; len(roots) == 1 {
+        return roots[0]
+    } else {
+        return &ParseNode{Begin: roots[0].Begin, End: roots[len(roots) - 1].End, Children: roots}
+    }
+}
+
 func (t *tokens{{.}}) Add(rule Rule, begin, end, depth, index int) {
     t.tree[index] = token{{.}}{Rule: rule, begin: int{{.}}(begin), end: int{{.}}(end), next: int{{.}}(depth)}
 }
@@ -324,6 +424,113 @@ func (t *tokens32) Expand(index int) TokenTree {
     return nil
 }
 
+/* A node of the parse tree. */
+type ParseNode struct {
+    Rule
+    Begin, End int
+    Children []*ParseNode
+}
+
+func (n *ParseNode) text(buffer []rune) string {
+    begin, end := n.Begin, n.End
+    if end > len(buffer) {
+        end = len(buffer)
+    }
+    if begin > end {
+        begin = end
+    }
+    return string(buffer[begin:end])
+}
+
+/* Write the parse tree as JSON objects with rule, begin, end, text and children. */
+func (n *ParseNode) WriteJSON(w io.Writer, buffer string) error {
+    var encode func(n *ParseNode)
+    runes, out := []rune(buffer), &bytes.Buffer{}
+    encode = func(n *ParseNode) {
+        text, _ := json.Marshal(n.text(runes))
+        fmt.Fprintf(out, "{\"rule\":\"%v\",\"begin\":%v,\"end\":%v,\"text\":%s,\"children\":[", Rul3s[n.Rule], n.Begin, n.End, text)
+        for i, child := range n.Children {
+            if i > 0 {
+                out.WriteString(",")
+            }
+            encode(child)
+        }
+        out.WriteString("]}")
+    }
+    encode(n)
+    indented := &bytes.Buffer{}
+    if err := json.Indent(indented, out.Bytes(), "", "  "); err != nil {
+        return err
+    }
+    indented.WriteString("\n")
+    _, err := indented.WriteTo(w)
+    return err
+}
+
+/* Write the parse tree as S-expressions, with the matched text at the leaves. */
+func (n *ParseNode) WriteSExpression(w io.Writer, buffer string) error {
+    var write func(n *ParseNode, indent string) error
+    runes := []rune(buffer)
+    write = func(n *ParseNode, indent string) error {
+        if len(n.Children) == 0 {
+            _, err := fmt.Fprintf(w, "%v(%v %v %v %v)", indent, Rul3s[n.Rule], n.Begin, n.End, strconv.Quote(n.text(runes)))
+            return err
+        }
+        if _, err := fmt.Fprintf(w, "%v(%v %v %v", indent, Rul3s[n.Rule], n.Begin, n.End); err != nil {
+            return err
+        }
+        for _, child := range n.Children {
+            if _, err := io.WriteString(w, "\n"); err != nil {
+                return err
+            }
+            if err := write(child, indent + "  "); err != nil {
+                return err
+            }
+        }
+        _, err := io.WriteString(w, ")")
+        return err
+    }
+    if err := write(n, ""); err != nil {
+        return err
+    }
+    _, err := io.WriteString(w, "\n")
+    return err
+}
+
+/* Write the parse tree as a Graphviz DOT digraph. */
+func (n *ParseNode) WriteDOT(w io.Writer, buffer string) error {
+    var write func(n *ParseNode) (int, error)
+    runes, id := []rune(buffer), 0
+    quote := func(s string) string {
+        return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s)
+    }
+    write = func(n *ParseNode) (int, error) {
+        self := id
+        id++
+        if _, err := fmt.Fprintf(w, "  n%v [label=\"%v\\n%v\"];\n", self, quote(Rul3s[n.Rule]), quote(strconv.Quote(n.text(runes)))); err != nil {
+            return self, err
+        }
+        for _, child := range n.Children {
+            c, err := write(child)
+            if err != nil {
+                return self, err
+            }
+            if _, err := fmt.Fprintf(w, "  n%v -> n%v;\n", self, c); err != nil {
+                return self, err
+            }
+        }
+        return self, nil
+    }
+    if _, err := io.WriteString(w, "digraph {{.StructName}} {\n  node [shape=box];\n"); err != nil {
+        return err
+    }
+    if _, err := write(n); err != nil {
+        return err
+    }
+    _, err := io.WriteString(w, "}\n")
+    return err
+}
+
 type {{.StructName}} struct {
     {{.StructVariables}}
     Buffer      string
@@ -331,9 +538,166 @@ type {{.StructName}} struct {
     rules       [{{.RulesCount}}]func() bool
     Parse       func(rule ...int) error
     Reset       func()
-    TokenTree
+    {{if .Trace}}Tracer      Tracer
+    {{end}}TokenTree
+}
+{{if .Trace}}
+/* The events of a traced parse. */
+type TraceEvent uint8
+
+const (
+    TraceEnter TraceEvent = iota
+    TraceSuccess
+    TraceFail
+    TraceBacktrack
+)
+
+var traceEvents = [...]string {"enter", "success", "fail", "backtrack"}
+
+func (e TraceEvent) String() string {
+    return traceEvents[e]
+}
+
+/* Receives an event whenever a rule is entered, succeeds or fails, and whenever a rule backtracks
+   from end to begin. Success and fail events carry the positions the rule began and ended at. */
+type Tracer interface {
+    Trace(event TraceEvent, rule Rule, begin, end, depth int)
+}
+
+/* A Tracer writing one indented line per event. When rules are given, only
+   events inside those rules are written. */
+type TextTracer struct {
+    Writer io.Writer
+    Buffer []rune
+    Rules  map[Rule]bool
+    inside, base int
+    /* the offsets in Buffer at which its lines start */
+    lines []int
+}
+
+func NewTextTracer(w io.Writer, buffer string, rules ...string) *TextTracer {
+    t := &TextTracer{Writer: w, Buffer: []rune(buffer), Rules: make(map[Rule]bool)}
+    for _, name := range rules {
+        for r, n := range Rul3s {
+            if n == name {
+                t.Rules[Rule(r)] = true
+            }
+        }
+    }
+    return t
+}
+
+func (t *TextTracer) position(i int) string {
+    if t.lines == nil {
+        t.lines = []int{0}
+        for j, c := range t.Buffer {
+            if c == '\n' {
+                t.lines = append(t.lines, j + 1)
+            }
+        }
+    }
+    line := sort.Search(len(t.lines), func(j int) bool { return t.lines[j] > i })
+    return fmt.Sprintf("%v:%v", line, i - t.lines[line - 1] + 1)
+}
+
+func (t *TextTracer) Trace(event TraceEvent, rule Rule, begin, end, depth int) {
+    selected := t.Rules[rule]
+    if selected && event == TraceEnter {
+        if t.inside == 0 {
+            t.base = depth
+        }
+        t.inside++
+    }
+    if len(t.Rules) == 0 || t.inside > 0 {
+        line := fmt.Sprintf("%v%v %v %v", strings.Repeat("  ", depth - t.base), Rul3s[rule], event, t.position(begin))
+        if event != TraceEnter {
+            line += "-" + t.position(end)
+            if text := t.Buffer[begin:end]; len(text) > 32 {
+                line += " " + strconv.Quote(string(text[:32])) + "..."
+            } else if len(text) > 0 {
+                line += " " + strconv.Quote(string(text))
+            }
+        }
+        fmt.Fprintln(t.Writer, line)
+    }
+    if selected && (event == TraceSuccess || event == TraceFail) {
+        t.inside--
+    }
+}
+
+/* The statistics of a rule. Backtracked counts the characters the rule gave back, on
+   failure or by backtracking within it; Time includes the rules it called. */
+type RuleProfile struct {
+    Rule                      Rule
+    Calls, Successes, Failures int
+    Backtracked               int
+    Time                      time.Duration
+}
+
+/* A Tracer collecting a RuleProfile for every rule. */
+type Profiler struct {
+    Rules  [len(Rul3s)]RuleProfile
+    active [len(Rul3s)]int
+    start  [len(Rul3s)]time.Time
+}
+
+func NewProfiler() *Profiler {
+    p := &Profiler{}
+    for r := range p.Rules {
+        p.Rules[r].Rule = Rule(r)
+    }
+    return p
+}
+
+func (p *Profiler) Trace(event TraceEvent, rule Rule, begin, end, depth int) {
+    profile := &p.Rules[rule]
+    switch event {
+    case TraceEnter:
+        profile.Calls++
+        /* time recursive calls only once */
+        if p.active[rule] == 0 {
+            p.start[rule] = time.Now()
+        }
+        p.active[rule]++
+        return
+    case TraceSuccess:
+        profile.Successes++
+    case TraceFail:
+        profile.Failures++
+        profile.Backtracked += end - begin
+    case TraceBacktrack:
+        profile.Backtracked += end - begin
+        return
+    }
+    if p.active[rule]--; p.active[rule] == 0 {
+        profile.Time += time.Since(p.start[rule])
+    }
 }
 
+/* The profiles of the rules which were called, most time consuming first. */
+func (p *Profiler) Profiles() []RuleProfile {
+    var profiles []RuleProfile
+    for _, profile := range p.Rules {
+        if profile.Calls > 0 {
+            profiles = append(profiles, profile)
+        }
+    }
+    sort.SliceStable(profiles, func(i, j int) bool {
+        return profiles[i].Time > profiles[j].Time
+    })
+    return profiles
+}
+
+func (p *Profiler) Report(w io.Writer) error {
+    out := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintln(out, "rule\tcalls\tsuccesses\tfailures\tbacktracked\ttime\ttime/call\t")
+    for _, profile := range p.Profiles() {
+        fmt.Fprintf(out, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", Rul3s[profile.Rule], profile.Calls, profile.Successes,
+            profile.Failures, profile.Backtracked, profile.Time, profile.Time / time.Duration(profile.Calls))
+    }
+    return out.Flush()
+}
+{{end}}
 type textPosition struct {
     line, symbol int
 }
@@ -384,13 +748,92 @@ func (p *{{.StructName}}) PrintSyntaxTree() {
     p.TokenTree.PrintSyntaxTree(p.Buffer)
 }
 
+func (p *{{.StructName}}) WriteJSON(w io.Writer) error {
+    return p.ParseTree().WriteJSON(w, p.Buffer)
+}
+
+func (p *{{.StructName}}) WriteSExpression(w io.Writer) error {
+    return p.ParseTree().WriteSExpression(w, p.Buffer)
+}
+
+func (p *{{.StructName}}) WriteDOT(w io.Writer) error {
+    return p.ParseTree().WriteDOT(w, p.Buffer)
+}
+
 func (p *{{.StructName}}) Highlighter() {
     p.TokenTree.PrintSyntax()
 }
 
+/* The style classes given to rules with %highlight. */
+var Highlights = map[Rule]string {
+    {{range .Highlights}}Rule{{.Rule}}: "{{.Class}}",
+    {{end}}
+}
+
+/* The SGR parameters used for common style classes by HighlightANSI. */
+var HighlightColors = map[string]string {
+    "comment": "32",
+    "string": "33",
+    "number": "35",
+    "keyword": "1;34",
+    "identifier": "36",
+    "operator": "1",
+    "action": "2",
+    "error": "31",
+}
+
+/* Write the buffer with ANSI colors; nil styles and colors default to Highlights and HighlightColors. */
+func (p *{{.StructName}}) HighlightANSI(w io.Writer, styles map[Rule]string, colors map[string]string) (err error) {
+    if styles == nil {
+        styles = Highlights
+    }
+    if colors == nil {
+        colors = HighlightColors
+    }
+    p.TokenTree.Highlight([]rune(p.Buffer), styles, func(class string, text []rune) {
+        if err != nil {
+            return
+        }
+        if color, ok := colors[class]; ok {
+            _, err = fmt.Fprintf(w, "\x1B[%vm%v\x1B[m", color, string(text))
+        } else {
+            _, err = io.WriteString(w, string(text))
+        }
+    })
+    return
+}
+
+/* Write the buffer as HTML with styled text in spans of its class; nil styles default to Highlights. */
+func (p *{{.StructName}}) HighlightHTML(w io.Writer, styles map[Rule]string) (err error) {
+    if styles == nil {
+        styles = Highlights
+    }
+    p.TokenTree.Highlight([]rune(p.Buffer), styles, func(class string, text []rune) {
+        if err != nil {
+            return
+        }
+        if class != "" {
+            _, err = fmt.Fprintf(w, "<span class=\"%v\">%v</span>", html.EscapeString(class), html.EscapeString(string(text)))
+        } else {
+            _, err = io.WriteString(w, html.EscapeString(string(text)))
+        }
+    })
+    return
+}
+
 {{if .HasActions}}
 func (p *{{.StructName}}) Execute() {
     buffer, begin, end := p.Buffer, 0, 0
+    /* tokens count runes, but begin and end index the bytes of buffer */
+    offset := func(i int) int { return i }
+    if strings.IndexFunc(buffer, func(c rune) bool { return c >= 0x80 }) >= 0 {
+        offsets := make([]int, 0, len(buffer) + 1)
+        for i := range buffer {
+            offsets = append(offsets, i)
+        }
+        offsets = append(offsets, len(buffer))
+        offset = func(i int) int { return offsets[i] }
+    }
     {{if .HasVariable}}
         var yy {{.YYSType}}
         stack := make([]{{.YYSType}}, 1024)
@@ -399,10 +842,10 @@ func (p *{{.StructName}}) Execute() {
     for token := range p.TokenTree.Tokens() {
         switch (token.Rule) {
         case RulePegText:
-            begin, end = int(token.begin), int(token.end)
+            begin, end = offset(int(token.begin)), offset(int(token.end))
         {{range .Actions}}case RuleAction{{.GetId}}:
-            {{.String}}
-        {{end}}
+{{$.LineDirective .}}            {{$.LineCode .}}
+{{$.LineRestore .}}        {{end}}
         {{if .HasVariable}}
             case RuleActionPush:
                 stack_idx += 1
@@ -425,6 +868,9 @@ func (p *{{.StructName}}) Init() {
 
     var tree TokenTree = &tokens16{tree: make([]token16, math.MaxInt16)}
     position, depth, tokenIndex, buffer, rules := 0, 0, 0, p.buffer, p.rules
+    {{- if .Trace}}
+    traceDepth := 0
+    {{- end}}
 
     p.Parse = func(rule ...int) error {
         r := 1
@@ -440,9 +886,38 @@ func (p *{{.StructName}}) Init() {
         return &parseError{p}
     }
 
+    {{if .HasPredicate}}
+    var undos []func()
+    {{end}}
+
     p.Reset = func() {
         position, tokenIndex, depth = 0, 0, 0
+        {{- if .HasPredicate}}
+        undos = undos[:0]
+        {{- end}}
+        {{- if .Trace}}
+        traceDepth = 0
+        {{- end}}
+    }
+
+    {{if .Trace}}
+    trace := func(event TraceEvent, rule Rule, begin, end int) {
+        switch event {
+        case TraceSuccess, TraceFail:
+            traceDepth--
+        }
+        if p.Tracer != nil {
+            if event == TraceBacktrack {
+                p.Tracer.Trace(event, rule, begin, end, traceDepth + 1)
+            } else {
+                p.Tracer.Trace(event, rule, begin, end, traceDepth)
+            }
+        }
+        if event == TraceEnter {
+            traceDepth++
+        }
     }
+    {{end}}
 
     add := func(rule Rule, begin int) {
         if t := tree.Expand(tokenIndex); t != nil {
@@ -452,6 +927,30 @@ func (p *{{.StructName}}) Init() {
         tokenIndex++
     }
 
+    {{if .HasPredicate}}
+    /* The text last matched by rule, or by a <...> capture for RulePegText, which predicates can test. */
+    text := func(rule Rule) string {
+        for i := tokenIndex - 1; i >= 0; i-- {
+            if token := tree.token(i); token.Rule == rule {
+                return string(buffer[token.begin:token.end])
+            }
+        }
+        return ""
+    }
+    /* Keep f to undo a change made by a predicate when the parser backtracks over it. */
+    undo := func(f func()) bool {
+        undos = append(undos, f)
+        return true
+    }
+    rollback := func(length int) {
+        for len(undos) > length {
+            undos[len(undos) - 1]()
+            undos = undos[:len(undos) - 1]
+        }
+    }
+    _, _ = text, undo
+    {{end}}
+
     {{if .HasDot}}
     matchDot := func() bool {
         if buffer[position] != END_SYMBOL {
@@ -486,6 +985,35 @@ func (p *{{.StructName}}) Init() {
     }
     {{end}}
 
+    {{if .HasClass}}
+    matchClass := func(class []rune) bool {
+        c := buffer[position]
+        i := sort.Search(len(class)/2, func(i int) bool { return class[2*i+1] >= c })
+        if i < len(class)/2 && class[2*i] <= c {
+            position++
+            return true
+        }
+        return false
+    }
+    /* the classes may all be in rules which are not used */
+    _ = matchClass
+    {{end}}
+
+    {{if .HasBackReference}}
+    matchCapture := func(capture [2]int) bool {
+        i := position
+        for _, c := range buffer[capture[0]:capture[1]] {
+            if buffer[i] != c {
+                return false
+            }
+            i++
+        }
+        position = i
+        return true
+    }
+    _ = matchCapture
+    {{end}}
+
     {{if .HasRange}}
     /*matchRange := func(lower byte, upper byte) bool {
         if c := buffer[position]; c >= lower && c <= upper {
@@ -499,6 +1027,78 @@ func (p *{{.StructName}}) Init() {
     rules = [...]func() bool {
         nil,`
 
+const LEG_TEST_TEMPLATE = `// Code generated by leg. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+/* The files in testdata, leaving out the directories such as the fuzz cache. */
+func legCorpus(tb testing.TB) (inputs []string) {
+    files, err := filepath.Glob(filepath.Join("testdata", "*"))
+    if err != nil {
+        tb.Fatal(err)
+    }
+    for _, file := range files {
+        if info, err := os.Stat(file); err != nil || info.IsDir() {
+            continue
+        }
+        input, err := ioutil.ReadFile(file)
+        if err != nil {
+            tb.Fatal(err)
+        }
+        inputs = append(inputs, string(input))
+    }
+    return
+}
+
+func BenchmarkParse(b *testing.B) {
+    inputs := legCorpus(b)
+    if len(inputs) == 0 {
+        b.Skip("no inputs in testdata")
+    }
+    parsers, size := make([]*{{.StructName}}, len(inputs)), 0
+    for i, input := range inputs {
+        parsers[i] = &{{.StructName}}{Buffer: input}
+        parsers[i].Init()
+        size += len(input)
+    }
+    b.SetBytes(int64(size))
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        for _, p := range parsers {
+            p.Reset()
+            p.Parse()
+        }
+    }
+}
+
+func FuzzParse(f *testing.F) {
+    for _, input := range legCorpus(f) {
+        f.Add(input)
+    }
+    f.Fuzz(func(t *testing.T, input string) {
+        p := &{{.StructName}}{Buffer: input}
+        p.Init()
+        if err := p.Parse(); err != nil {
+            _ = err.Error()
+            return
+        }
+        length := len([]rune(p.Buffer))
+        for token := range p.Tokens() {
+            if token.begin < 0 || token.begin > token.end || int(token.end) > length {
+                t.Fatalf("token %v at %v-%v is outside of the buffer of %v characters", Rul3s[token.Rule], token.begin, token.end, length)
+            }
+        }
+    })
+}
+`
+
 type Type uint8
 
 const (
@@ -527,6 +1127,10 @@ const (
     TypePush
     TypeImplicitPush
     TypeNil
+    TypeCall
+    TypeClass
+    TypeBackReference
+    TypeRepeat
     TypeLast
 )
 
@@ -556,6 +1160,10 @@ var TypeMap = [...]string{
     "TypePush",
     "TypeImplicitPush",
     "TypeNil",
+    "TypeCall",
+    "TypeClass",
+    "TypeBackReference",
+    "TypeRepeat",
     "TypeLast"}
 
 func (t Type) GetType() Type {
@@ -575,6 +1183,8 @@ type Node interface {
     GetId() int
     SetId(id int)
 
+    GetPosition() Position
+
     HasVariable() int
     HasYY() bool
 
@@ -593,6 +1203,7 @@ type node struct {
     Type
     string
     id int
+    position Position
     hasVariable int
     hasYY bool
 
@@ -636,6 +1247,10 @@ func (n *node) SetId(id int) {
     n.id = id
 }
 
+func (n *node) GetPosition() Position {
+    return n.position
+}
+
 func (n *node) HasVariable() int {
     return n.hasVariable
 }
@@ -699,7 +1314,7 @@ func (n *node) Len() (c int) {
 }
 
 func (n *node) Copy() *node {
-    return &node{Type: n.Type, string: n.string, id: n.id, front: n.front, back: n.back, length: n.length}
+    return &node{Type: n.Type, string: n.string, id: n.id, position: n.position, front: n.front, back: n.back, length: n.length}
 }
 
 func (n *node) Slice() []*node {
@@ -729,26 +1344,162 @@ type Tree struct {
     RulesCount      int
     Bits            int
     HasActions      bool
+    HasPredicate    bool
     Actions         []Node
     HasCommit       bool
     HasDot          bool
     HasCharacter    bool
     HasString       bool
     HasRange        bool
+    HasClass        bool
+    HasBackReference bool
     HasVariable     bool
+    Trace           bool
+    Harness         bool
+    Highlights      []Highlight
+    highlight       string
+    Imports         []Import
+    importPrefix    string
+    RuleFiles       map[string]string
+    macros          map[string]*macro
+    parameters      []string
+    file            string
+    source          string
+    lines           []int
+    at              Position
+    end             Position
+    output          string
+    declarationsAt  []Position
+    trailerAt       Position
+    Diagnostics     []Diagnostic
+    Errors          []Diagnostic
+    quiet           bool
+}
+
+/* A style class given to a rule with %highlight. */
+type Highlight struct {
+    Rule, Class string
+    Position    Position
+}
+
+/* A grammar file whose rules are merged in with %import, named Prefix_Rule when it has a prefix. */
+type Import struct {
+    Prefix, File string
+}
+
+/* A place in a grammar file; the zero Position is nowhere. */
+type Position struct {
+    File         string
+    Line, Column int
+}
+
+func (p Position) String() string {
+    return fmt.Sprintf("%v:%v:%v", p.File, p.Line, p.Column)
+}
+
+/* A rule with parameters, instantiated by Expand for the arguments of each call. */
+type macro struct {
+    rule       *node
+    parameters []string
+}
+
+/* A problem found in the grammar while it is being compiled, at Position when it is known and in
+   File when the rule was imported; End is just past the text at fault, when the parser knows it. */
+type Diagnostic struct {
+    Rule     string
+    File     string
+    Position Position
+    End      Position
+    Message  string
+}
+
+func (d Diagnostic) String() string {
+    switch {
+    case d.Position.Line > 0:
+        return fmt.Sprintf("%v: %v", d.Position, d.Message)
+    case d.File != "":
+        return fmt.Sprintf("%v: %v", d.File, d.Message)
+    }
+    return d.Message
+}
+
+func (t *Tree) report(n Node, format string, a ...interface{}) {
+    d := Diagnostic{Rule: n.String(), File: t.RuleFiles[n.String()], Position: n.GetPosition(), Message: fmt.Sprintf(format, a...)}
+    t.Diagnostics = append(t.Diagnostics, d)
+    if !t.quiet {
+        fmt.Fprintln(os.Stderr, d)
+    }
+}
+
+/* Record an error in the grammar at the place the nodes are added; unlike a diagnostic, it stops compilation. */
+func (t *Tree) fail(format string, a ...interface{}) {
+    t.Errors = append(t.Errors, Diagnostic{Position: t.at, End: t.end, Message: fmt.Sprintf(format, a...)})
+}
+
+/* The errors found while the grammar was parsed, one per line, or nil. */
+func (t *Tree) Err() error {
+    if len(t.Errors) == 0 {
+        return nil
+    }
+    messages := make([]string, len(t.Errors))
+    for i, e := range t.Errors {
+        messages[i] = e.String()
+    }
+    return fmt.Errorf("%v", strings.Join(messages, "\n"))
 }
 
 func New(inline, _switch bool) *Tree {
     return &Tree{Rules: make(map[string]Node),
         Sizes:      [2]int{16, 32},
         rulesCount: make(map[string]uint),
+        RuleFiles:  make(map[string]string),
+        macros:     make(map[string]*macro),
         inline:     inline,
         _switch:    _switch}
 }
 
+/* Give the grammar being parsed, so that At can turn offsets into it into positions. */
+func (t *Tree) SetSource(file string, buffer string) {
+    t.file, t.source, t.lines = file, buffer, []int{0}
+    for i, c := range buffer {
+        if c == '\n' {
+            t.lines = append(t.lines, i+1)
+        }
+    }
+}
+
+/* The position of a byte offset in the grammar; columns count runes. */
+func (t *Tree) position(offset int) Position {
+    line := sort.Search(len(t.lines), func(i int) bool { return t.lines[i] > offset })
+    column := utf8.RuneCountInString(t.source[t.lines[line-1]:offset]) + 1
+    return Position{File: t.file, Line: line, Column: column}
+}
+
+/* Set the position of the nodes added next to the byte offset in the grammar. */
+func (t *Tree) At(offset int) {
+    if t.lines == nil {
+        return
+    }
+    t.at, t.end = t.position(offset), Position{}
+}
+
+/* Set the position of the nodes added next to the byte offset begin in the grammar, and report
+   errors in them over the text up to end, such as the whole of an escape. */
+func (t *Tree) Span(begin, end int) {
+    if t.lines == nil {
+        return
+    }
+    t.at, t.end = t.position(begin), t.position(end)
+}
+
+/* Set the position of the nodes added next to a line and column of file, as the builder of a tree does. */
+func (t *Tree) AtPosition(file string, line, column int) {
+    t.at = Position{File: file, Line: line, Column: column}
+}
+
 func (t *Tree) AddRule(name string) {
     name = strings.Replace(name, "-", "_", -1)
-    t.PushFront(&node{Type: TypeRule, string: name, id: t.RulesCount})
+    t.PushFront(&node{Type: TypeRule, string: name, id: t.RulesCount, position: t.at})
     t.RulesCount++
 }
 
@@ -756,45 +1507,89 @@ func (t *Tree) AddExpression() {
     expression := t.PopFront()
     rule := t.PopFront()
     rule.PushBack(expression)
+    if len(t.parameters) > 0 {
+        /* only the instances of a rule with parameters are rules of the parser */
+        t.macros[rule.String()] = &macro{rule: rule, parameters: t.parameters}
+        t.parameters = nil
+        t.RulesCount--
+        return
+    }
     t.PushBack(rule)
 }
 
+func (t *Tree) AddParameter(text string) {
+    t.parameters = append(t.parameters, strings.Replace(text, "-", "_", -1))
+}
+
+func (t *Tree) AddCall(text string) {
+    t.PushFront(&node{Type: TypeCall, string: strings.Replace(text, "-", "_", -1), position: t.at})
+}
+
+func (t *Tree) AddArgument() {
+    argument := t.PopFront()
+    t.Front().PushBack(argument)
+}
+
 func (t *Tree) AddName(text string) {
     text = strings.Replace(text, "-", "_", -1)
     var v *node
     if t.Front().GetType() == TypeVariable {
         v = t.PopFront()
     }
-    t.PushFront(&node{Type: TypeName, string: text})
+    t.PushFront(&node{Type: TypeName, string: text, position: t.at})
     if v != nil {
         t.Front().PushBack(v)
     }
 }
 
-func (t *Tree) AddDot() { t.PushFront(&node{Type: TypeDot, string: "."}) }
+func (t *Tree) AddDot() { t.PushFront(&node{Type: TypeDot, string: ".", position: t.at}) }
 func (t *Tree) AddCharacter(text string) {
-    t.PushFront(&node{Type: TypeCharacter, string: text})
+    t.PushFront(&node{Type: TypeCharacter, string: text, position: t.at})
 }
 func (t *Tree) AddDoubleCharacter(text string) {
-    t.PushFront(&node{Type: TypeCharacter, string: strings.ToLower(text)})
-    t.PushFront(&node{Type: TypeCharacter, string: strings.ToUpper(text)})
+    t.PushFront(&node{Type: TypeCharacter, string: strings.ToLower(text), position: t.at})
+    t.PushFront(&node{Type: TypeCharacter, string: strings.ToUpper(text), position: t.at})
     t.AddAlternate()
 }
 func (t *Tree) AddOctalCharacter(text string) {
-    octal, _ := strconv.ParseInt(text, 8, 8)
-    t.PushFront(&node{Type: TypeCharacter, string: string(octal)})
-}
-func (t *Tree) AddPredicate(text string) { t.PushFront(&node{Type: TypePredicate, string: text}) }
-func (t *Tree) AddNil()                  { t.PushFront(&node{Type: TypeNil, string: "<nil>"}) }
-func (t *Tree) AddAction(text string)    { t.PushFront(&node{Type: TypeAction, string: text}) }
-func (t *Tree) AddVariable(text string) { t.PushFront(&node{Type: TypeVariable, string: text}) }
-func (t *Tree) AddPackage(text string)   { t.PushBack(&node{Type: TypePackage, string: text}) }
-func (t *Tree) AddDeclaration(text string)   { t.Declarations = append(t.Declarations, text) }
-func (t *Tree) AddTrailer(text string) { t.Trailer = text }
+    octal, _ := strconv.ParseInt(text, 8, 32)
+    t.PushFront(&node{Type: TypeCharacter, string: string(rune(octal)), position: t.at})
+}
+func (t *Tree) AddHexCharacter(text string) {
+    code, err := strconv.ParseUint(text, 16, 32)
+    if err != nil || !utf8.ValidRune(rune(code)) {
+        t.fail("invalid escape: U+%v is not a Unicode character", strings.ToUpper(text))
+        code = utf8.RuneError
+    }
+    t.PushFront(&node{Type: TypeCharacter, string: string(rune(code)), position: t.at})
+}
+func (t *Tree) AddInvalidEscape(text string) {
+    t.fail("invalid escape \\%v", text)
+    t.PushFront(&node{Type: TypeCharacter, string: text, position: t.at})
+}
+func (t *Tree) AddPredicate(text string) { t.PushFront(&node{Type: TypePredicate, string: text, position: t.at}) }
+func (t *Tree) AddNil()                  { t.PushFront(&node{Type: TypeNil, string: "<nil>", position: t.at}) }
+func (t *Tree) AddAction(text string)    { t.PushFront(&node{Type: TypeAction, string: text, position: t.at}) }
+func (t *Tree) AddVariable(text string) { t.PushFront(&node{Type: TypeVariable, string: text, position: t.at}) }
+func (t *Tree) AddPackage(text string)   { t.PushBack(&node{Type: TypePackage, string: text, position: t.at}) }
+func (t *Tree) AddDeclaration(text string)   {
+    t.Declarations = append(t.Declarations, text)
+    t.declarationsAt = append(t.declarationsAt, t.at)
+}
+func (t *Tree) AddTrailer(text string) { t.Trailer, t.trailerAt = text, t.at }
 func (t *Tree) AddYYSType(text string) { t.YYSType = text }
+func (t *Tree) AddHighlight(text string) { t.highlight = text }
+func (t *Tree) AddHighlightRule(text string) {
+    t.Highlights = append(t.Highlights, Highlight{Rule: strings.Replace(text, "-", "_", -1), Class: t.highlight, Position: t.at})
+}
+func (t *Tree) AddImportPrefix(text string) { t.importPrefix = strings.Replace(text, "-", "_", -1) }
+func (t *Tree) AddImport(text string) {
+    t.Imports = append(t.Imports, Import{Prefix: t.importPrefix, File: text})
+    t.importPrefix = ""
+}
 func (t *Tree) AddState(text string) {
     leg := t.PopFront()
-    leg.PushBack(&node{Type: TypeState, string: text})
+    leg.PushBack(&node{Type: TypeState, string: text, position: t.at})
     t.PushBack(leg)
 }
 
@@ -805,7 +1600,7 @@ func (t *Tree) addList(listType Type) {
     if b.GetType() == listType {
         l = b
     } else {
-        l = &node{Type: listType}
+        l = &node{Type: listType, position: b.position}
         l.PushBack(b)
     }
     l.PushBack(a)
@@ -829,11 +1624,130 @@ func (t *Tree) AddDoubleRange() {
     t.AddAlternate()
 }
 
-func (t *Tree) addFix(fixType Type) {
-    n := &node{Type: fixType}
-    n.PushBack(t.PopFront())
-    t.PushFront(n)
-}
+/* The ranges of a class expression: characters, ranges, classes, alternates of them and [^...]. */
+func classRanges(n Node) (ranges, bool) {
+    switch n.GetType() {
+    case TypeCharacter:
+        if c := []rune(n.String()); len(c) == 1 {
+            return ranges{{c[0], c[0]}}, true
+        }
+    case TypeRange:
+        lower, upper := []rune(n.Front().String()), []rune(n.Front().Next().String())
+        if len(lower) == 1 && len(upper) == 1 {
+            return ranges{{lower[0], upper[0]}}, true
+        }
+    case TypeNil:
+        return ranges{}, true
+    case TypeAlternate, TypeUnorderedAlternate, TypeClass:
+        var r ranges
+        for _, element := range n.Slice() {
+            s, ok := classRanges(element)
+            if !ok {
+                return nil, false
+            }
+            r = append(r, s...)
+        }
+        return r.normalize(), true
+    case TypeSequence:
+        /* [^...] without set operations is parsed as !class . */
+        elements := n.Slice()
+        if len(elements) == 2 && elements[0].GetType() == TypePeekNot && elements[1].GetType() == TypeDot {
+            if r, ok := classRanges(elements[0].Front()); ok {
+                return r.complement(), true
+            }
+        }
+    }
+    return nil, false
+}
+
+/* A class node holding the ranges r, each a range or a character. */
+func classNode(r ranges, position Position) *node {
+    class := &node{Type: TypeClass, position: position}
+    for _, p := range r.normalize() {
+        if p[0] == p[1] {
+            class.PushBack(&node{Type: TypeCharacter, string: string(p[0]), position: position})
+            continue
+        }
+        lower := &node{Type: TypeCharacter, string: string(p[0]), position: position}
+        upper := &node{Type: TypeCharacter, string: string(p[1]), position: position}
+        r := &node{Type: TypeRange, position: position}
+        r.PushBack(lower)
+        r.PushBack(upper)
+        class.PushBack(r)
+    }
+    return class
+}
+
+func (t *Tree) popClass() (ranges, Position) {
+    n := t.PopFront()
+    r, ok := classRanges(n)
+    if !ok {
+        t.fail("%v is not a character class", n)
+    }
+    return r, n.GetPosition()
+}
+
+func (t *Tree) AddClass() {
+    r, position := t.popClass()
+    t.PushFront(classNode(r, position))
+}
+/* Keep how the class at the front was written, which is how rules print it. */
+func (t *Tree) AddClassText(text string) {
+    if t.Front().GetType() == TypeClass && t.Front().String() == "" {
+        t.Front().SetString(text)
+    }
+}
+func (t *Tree) AddClassComplement() {
+    r, position := t.popClass()
+    t.PushFront(classNode(r.complement(), position))
+}
+func (t *Tree) AddClassDifference() {
+    b, _ := t.popClass()
+    a, position := t.popClass()
+    t.PushFront(classNode(a.difference(b), position))
+}
+func (t *Tree) AddClassIntersection() {
+    b, _ := t.popClass()
+    a, position := t.popClass()
+    t.PushFront(classNode(a.intersection(b), position))
+}
+func (t *Tree) AddProperty(name string) {
+    table, ok := unicode.Categories[name]
+    if !ok {
+        table, ok = unicode.Scripts[name]
+    }
+    if !ok {
+        table, ok = unicode.Properties[name]
+    }
+    var r ranges
+    add := func(lo, hi, stride uint32) {
+        if stride == 1 {
+            r = append(r, [2]rune{rune(lo), rune(hi)})
+            return
+        }
+        for c := lo; c <= hi; c += stride {
+            r = append(r, [2]rune{rune(c), rune(c)})
+        }
+    }
+    if !ok {
+        t.fail("unknown Unicode property %v", name)
+    } else {
+        for _, p := range table.R16 {
+            add(uint32(p.Lo), uint32(p.Hi), uint32(p.Stride))
+        }
+        for _, p := range table.R32 {
+            add(p.Lo, p.Hi, p.Stride)
+        }
+    }
+    t.PushFront(classNode(r, t.at))
+}
+
+func (t *Tree) addFix(fixType Type) {
+    n := &node{Type: fixType}
+    n.PushBack(t.PopFront())
+    n.position = n.Front().position
+    t.PushFront(n)
+}
 func (t *Tree) AddPeekFor() { t.addFix(TypePeekFor) }
 func (t *Tree) AddPeekNot() { t.addFix(TypePeekNot) }
 func (t *Tree) AddQuery()   { t.addFix(TypeQuery) }
@@ -841,7 +1755,297 @@ func (t *Tree) AddStar()    { t.addFix(TypeStar) }
 func (t *Tree) AddPlus()    { t.addFix(TypePlus) }
 func (t *Tree) AddPush()    { t.addFix(TypePush) }
 
-func (t *Tree) AddLeg(text string) { t.PushFront(&node{Type: TypeLeg, string: text}) }
+/* A counted repetition, {n}, {n,} or {n,m}; text is what is between the braces, where spaces do not count. */
+func (t *Tree) AddRepeat(text string) {
+    text = strings.Join(strings.Fields(text), "")
+    bounds := strings.SplitN(text, ",", 2)
+    for _, bound := range bounds {
+        if _, err := strconv.Atoi(bound); bound != "" && err != nil {
+            t.fail("invalid repetition {%v}: %v is too large", text, bound)
+        }
+    }
+    if min, max := repeatBounds(text); max >= 0 && max < min {
+        t.fail("invalid repetition {%v}: %v is less than %v", text, max, min)
+    }
+    t.addFix(TypeRepeat)
+    t.Front().SetString(text)
+}
+
+/* The bounds of a counted repetition; max is -1 when there is none. */
+func repeatBounds(text string) (min, max int) {
+    bounds := strings.SplitN(text, ",", 2)
+    min, _ = strconv.Atoi(bounds[0])
+    max = min
+    if len(bounds) == 2 {
+        max = -1
+        if bounds[1] != "" {
+            max, _ = strconv.Atoi(bounds[1])
+        }
+    }
+    return
+}
+
+/* Name the capture on the stack after the variable under it, so that back references can match it again. */
+func (t *Tree) AddLabel() {
+    push := t.PopFront()
+    push.SetString(t.PopFront().String())
+    t.PushFront(push)
+}
+func (t *Tree) AddBackReference(text string) {
+    t.PushFront(&node{Type: TypeBackReference, string: text, position: t.at})
+}
+
+func (t *Tree) AddLeg(text string) { t.PushFront(&node{Type: TypeLeg, string: text, position: t.at}) }
+
+/* Merge the rules of an imported grammar into the tree. With a prefix, the imported rules and the
+   references between them are renamed prefix_Rule; references to other rules are left to the
+   importing grammar. A rule already in the tree is an error, unless it came from the same file. */
+func (t *Tree) Merge(imported *Tree, i Import, file string) error {
+    rename := func(name string) string { return name }
+    if i.Prefix != "" {
+        defined := make(map[string]bool)
+        for _, element := range imported.Slice() {
+            if element.GetType() == TypeRule {
+                defined[element.String()] = true
+            }
+        }
+        for name := range imported.macros {
+            defined[name] = true
+        }
+        rename = func(name string) string {
+            if defined[name] {
+                return i.Prefix + "_" + name
+            }
+            return name
+        }
+    }
+    var renameNames func(n *node, parameters []string)
+    renameNames = func(n *node, parameters []string) {
+        for element := n.Front(); element != nil; element = element.Next() {
+            if element.GetType() == TypeName && !element_exists(parameters, element.String()) || element.GetType() == TypeCall {
+                element.SetString(rename(element.String()))
+            }
+            renameNames(element, parameters)
+        }
+    }
+
+    defined, skipped := make(map[string]bool), make(map[string]bool)
+    for _, element := range t.Slice() {
+        if element.GetType() == TypeRule {
+            defined[element.String()] = true
+        }
+    }
+    for name := range t.macros {
+        defined[name] = true
+    }
+    rules := imported.Slice()
+    var macros []string
+    for name := range imported.macros {
+        macros = append(macros, name)
+    }
+    sort.Strings(macros)
+    for _, name := range macros {
+        rules = append(rules, imported.macros[name].rule)
+    }
+    for _, element := range rules {
+        if element.GetType() != TypeRule {
+            continue
+        }
+        m := imported.macros[element.String()]
+        if m != nil && m.rule != element {
+            m = nil
+        }
+        name, from := rename(element.String()), file
+        if f, ok := imported.RuleFiles[element.String()]; ok {
+            from = f
+        }
+        if defined[name] {
+            if t.RuleFiles[name] == from {
+                skipped[name] = true
+                continue
+            }
+            origin := t.RuleFiles[name]
+            if origin == "" {
+                origin = "the importing grammar"
+            }
+            return fmt.Errorf("rule '%v' imported from %v is already defined in %v", name, from, origin)
+        }
+        defined[name] = true
+        element.SetString(name)
+        element.next = nil
+        t.RuleFiles[name] = from
+        if m != nil {
+            renameNames(element, m.parameters)
+            t.macros[name] = m
+            continue
+        }
+        element.SetId(t.RulesCount)
+        t.RulesCount++
+        renameNames(element, nil)
+        t.PushBack(element)
+    }
+    /* a file imported twice brings its highlights and code in once */
+    for _, highlight := range imported.Highlights {
+        if !skipped[rename(highlight.Rule)] {
+            t.Highlights = append(t.Highlights, Highlight{Rule: rename(highlight.Rule), Class: highlight.Class, Position: highlight.Position})
+        }
+    }
+    declarations := make(map[string]bool)
+    for _, declaration := range t.Declarations {
+        declarations[declaration] = true
+    }
+    for i, declaration := range append(imported.Declarations, imported.Trailer) {
+        if declaration != "" && !declarations[declaration] {
+            t.Declarations = append(t.Declarations, declaration)
+            if i < len(imported.Declarations) {
+                t.declarationsAt = append(t.declarationsAt, imported.declarationsAt[i])
+            } else {
+                t.declarationsAt = append(t.declarationsAt, imported.trailerAt)
+            }
+        }
+    }
+    return nil
+}
+
+/* Replace the calls of rules with parameters by rules of their own, one for each distinct list of
+   arguments, named after the rule and its arguments. */
+func (t *Tree) Expand() error {
+    names := make(map[string]bool)
+    for _, element := range t.Slice() {
+        if element.GetType() == TypeRule {
+            names[element.String()] = true
+        }
+    }
+    instances, counts := make(map[string]string), make(map[string]int)
+    /* the rules with parameters each instance was made from, outermost first */
+    origins := make(map[string][]string)
+
+    var substitute func(n *node, arguments map[string]*node) *node
+    substitute = func(n *node, arguments map[string]*node) *node {
+        if argument, ok := arguments[n.String()]; ok && n.GetType() == TypeName {
+            return substitute(argument, nil)
+        }
+        c := &node{Type: n.Type, string: n.string, id: n.id, position: n.position}
+        for element := n.Front(); element != nil; element = element.Next() {
+            c.PushBack(substitute(element, arguments))
+        }
+        return c
+    }
+
+    var expand func(rule, n *node) error
+    expand = func(rule, n *node) error {
+        for element := n.Front(); element != nil; element = element.Next() {
+            if err := expand(rule, element); err != nil {
+                return err
+            }
+        }
+        if n.GetType() != TypeCall {
+            return nil
+        }
+        m, ok := t.macros[n.String()]
+        switch {
+        case !ok && names[n.String()]:
+            return fmt.Errorf("rule '%v': rule '%v' has no parameters", rule, n)
+        case !ok:
+            return fmt.Errorf("rule '%v': no rule '%v' with parameters", rule, n)
+        case n.Len() != len(m.parameters):
+            return fmt.Errorf("rule '%v': rule '%v' takes %v arguments, not %v", rule, n, len(m.parameters), n.Len())
+        }
+
+        var key bytes.Buffer
+        printRule(&key, n)
+        name, ok := instances[key.String()]
+        if !ok {
+            /* a call with new arguments inside an instance of the same rule would make instances forever */
+            if element_exists(origins[rule.String()], n.String()) {
+                return fmt.Errorf("rule '%v': rule '%v' calls itself with other arguments", rule, n)
+            }
+            /* name the instance after its arguments when they are all rules, else number it */
+            counts[n.String()]++
+            parts, arguments := []string{n.String()}, make(map[string]*node)
+            for i, argument := range n.Slice() {
+                arguments[m.parameters[i]] = argument
+                if parts != nil && argument.GetType() == TypeName {
+                    parts = append(parts, argument.String())
+                } else {
+                    parts = nil
+                }
+            }
+            name = strings.Join(parts, "_")
+            if parts == nil {
+                name = fmt.Sprintf("%v_%v", n, counts[n.String()])
+            }
+            for base, i := name, 2; names[name]; i++ {
+                name = fmt.Sprintf("%v_%v", base, i)
+            }
+            names[name], instances[key.String()] = true, name
+            origins[name] = append(append([]string(nil), origins[rule.String()]...), n.String())
+
+            instance := &node{Type: TypeRule, string: name, id: t.RulesCount, position: m.rule.position}
+            t.RulesCount++
+            instance.PushBack(substitute(m.rule.Front(), arguments))
+            t.PushBack(instance)
+            if file, ok := t.RuleFiles[m.rule.String()]; ok {
+                t.RuleFiles[name] = file
+            }
+        }
+        n.Init()
+        n.SetType(TypeName)
+        n.SetString(name)
+        return nil
+    }
+
+    for element := t.Front(); element != nil; element = element.Next() {
+        t.ungroup(element)
+    }
+    for _, m := range t.macros {
+        t.ungroup(m.rule)
+    }
+    /* instances are pushed to the back, so their own calls are expanded in turn */
+    for element := t.Front(); element != nil; element = element.Next() {
+        if element.GetType() == TypeRule {
+            if err := expand(element, element); err != nil {
+                return err
+            }
+        }
+    }
+    return nil
+}
+
+/* Before rules had parameters, B(C) was the rule B followed by a group, and a call of a rule without
+   parameters still is: a prefix goes with the rule and a suffix with the group, as they did then. */
+func (t *Tree) ungroup(n *node) {
+    inner, prefix, suffix := n, (*node)(nil), (*node)(nil)
+    switch inner.GetType() {
+    case TypePeekFor, TypePeekNot:
+        prefix, inner = inner, inner.Front()
+    }
+    switch inner.GetType() {
+    case TypeQuery, TypeStar, TypePlus, TypeRepeat:
+        suffix, inner = inner, inner.Front()
+    }
+    if _, ok := t.macros[inner.String()]; inner.GetType() == TypeCall && !ok && inner.Len() == 1 {
+        name, group := &node{Type: TypeName, string: inner.string, position: inner.position}, inner.Front()
+        if prefix != nil {
+            p := &node{Type: prefix.Type, position: prefix.position}
+            p.PushBack(name)
+            name = p
+        }
+        if suffix != nil {
+            s := &node{Type: suffix.Type, string: suffix.string, position: suffix.position}
+            s.PushBack(group)
+            group = s
+        }
+        n.Init()
+        n.SetType(TypeSequence)
+        n.SetString("")
+        n.PushBack(name)
+        n.PushBack(group)
+    }
+    for element := n.Front(); element != nil; element = element.Next() {
+        t.ungroup(element)
+    }
+}
 
 func join(tasks []func()) {
     length := len(tasks)
@@ -875,261 +2079,385 @@ func element_exists(list []string, key string) bool{
     return false
 }
 
-func (t *Tree) Compile(file string) {
-    t.EndSymbol = '\u0004'
+/* Print a rule in PEG notation. */
+func printRule(w io.Writer, n Node) {
+    print := func(format string, a ...interface{}) { fmt.Fprintf(w, format, a...) }
+    switch n.GetType() {
+    case TypeRule:
+        print("%v <- ", n)
+        printRule(w, n.Front())
+    case TypeDot:
+        print(".")
+    case TypeName:
+        print("%v", n)
+    case TypeCharacter:
+        print("'%v'", escape(n.String()))
+    case TypeString:
+        s := escape(n.String())
+        print("'%v'", s[1:len(s)-1])
+    case TypeRange:
+        element := n.Front()
+        lower := element
+        element = element.Next()
+        upper := element
+        print("[%v-%v]", escape(lower.String()), escape(upper.String()))
+    case TypeClass:
+        if n.String() != "" {
+            print("%v", n)
+            break
+        }
+        r, _ := classRanges(n)
+        if r.contains(unicode.MaxRune) {
+            /* a complement reads better as one */
+            print("[^")
+            r = r.complement()
+        } else {
+            print("[")
+        }
+        for _, p := range r {
+            if p[0] == p[1] {
+                print("%v", escape(string(p[0])))
+            } else {
+                print("%v-%v", escape(string(p[0])), escape(string(p[1])))
+            }
+        }
+        print("]")
+    case TypePredicate:
+        print("&{%v}", n)
+    case TypeAction:
+        print("{%v}", n)
+    case TypeCommit:
+        print("commit")
+    case TypeCall:
+        print("%v(", n)
+        for i, element := range n.Slice() {
+            if i > 0 {
+                print(", ")
+            }
+            printRule(w, element)
+        }
+        print(")")
+    case TypeAlternate:
+        print("(")
+        elements := n.Slice()
+        printRule(w, elements[0])
+        for _, element := range elements[1:] {
+            print(" / ")
+            printRule(w, element)
+        }
+        print(")")
+    case TypeUnorderedAlternate:
+        print("(")
+        elements := n.Slice()
+        printRule(w, elements[0])
+        for _, element := range elements[1:] {
+            print(" | ")
+            printRule(w, element)
+        }
+        print(")")
+    case TypeSequence:
+        print("(")
+        elements := n.Slice()
+        printRule(w, elements[0])
+        for _, element := range elements[1:] {
+            print(" ")
+            printRule(w, element)
+        }
+        print(")")
+    case TypePeekFor:
+        print("&")
+        printRule(w, n.Front())
+    case TypePeekNot:
+        print("!")
+        printRule(w, n.Front())
+    case TypeQuery:
+        printRule(w, n.Front())
+        print("?")
+    case TypeStar:
+        printRule(w, n.Front())
+        print("*")
+    case TypePlus:
+        printRule(w, n.Front())
+        print("+")
+    case TypeRepeat:
+        printRule(w, n.Front())
+        print("{%v}", n)
+    case TypePush, TypeImplicitPush:
+        if n.GetType() == TypePush && n.String() != "" {
+            print("%v:", n)
+        }
+        print("<")
+        printRule(w, n.Front())
+        print(">")
+    case TypeBackReference:
+        print("=%v", n)
+    case TypeNil:
+    default:
+        fmt.Fprintf(os.Stderr, "illegal node type: %v\n", n.GetType())
+    }
+}
+
+/* Link the rules, turning actions and undefined names into rules of their own. */
+func (t *Tree) link() (counts [TypeLast]uint) {
     t.RulesCount++
 
     hasVariable := false
     hasYY := false
-    counts := [TypeLast]uint{}
-    {
-        var rule *node
-        var traverse_var_cnt func(node Node) int
-        var traverse_var_replace func(node Node)
-        var link func(node Node)
-
-
-        // Modify actions which use named semantic variables
-        // Use DFS traversal to find TypeVariable and TypeAction
-        var_stack := make([]string, 0)
-        traverse_var_cnt = func(n Node) int {
-            variableCount := 0
-            next_level_count := 0
-            leaf := n.Front()
-            if leaf == nil {
-                return 0
-            }
-            for {
-                switch leaf.GetType() {
-                case TypeName:
-                    if leaf.Front()!=nil && leaf.Front().GetType()==TypeVariable {
-                        if element_exists(var_stack, leaf.Front().String()) == false {
-                            hasVariable = true
-                            variableCount++
-                            var_stack = append(var_stack, leaf.Front().String())
-                        }
-                    }
-                case TypeAction:
-                    if strings.Contains(leaf.String(), "$$") {
-                        hasYY = true
-                        leaf.SetString(strings.Replace(leaf.String(),"$$","yy",-1))
+    var rule *node
+    var traverse_var_cnt func(node Node) int
+    var traverse_var_replace func(node Node)
+    var link func(node Node)
+
+
+    // Modify actions which use named semantic variables
+    // Use DFS traversal to find TypeVariable and TypeAction
+    var_stack := make([]string, 0)
+    traverse_var_cnt = func(n Node) int {
+        variableCount := 0
+        next_level_count := 0
+        leaf := n.Front()
+        if leaf == nil {
+            return 0
+        }
+        for {
+            switch leaf.GetType() {
+            case TypeName:
+                if leaf.Front()!=nil && leaf.Front().GetType()==TypeVariable {
+                    if element_exists(var_stack, leaf.Front().String()) == false {
+                        hasVariable = true
+                        variableCount++
+                        var_stack = append(var_stack, leaf.Front().String())
                     }
-                    leaf.SetString(strings.Replace(leaf.String(), "YYSTYPE", t.YYSType,-1))
-
-                // List types
-                case TypeSequence:
-                    variableCount += traverse_var_cnt(leaf)
-                case TypeAlternate:
-                    variableCount += traverse_var_cnt(leaf)
-
-                // Fix types
-                case TypePeekFor:
-                    fallthrough
-                case TypePeekNot:
-                    fallthrough
-                case TypeQuery:
-                    fallthrough
-                case TypeStar:
-                    fallthrough
-                case TypePlus:
-                    fallthrough
-                case TypePush:
-                    variableCount += traverse_var_cnt(leaf)
                 }
-
-                if leaf.Next()==nil {
-                    break
+            case TypeAction:
+                if strings.Contains(leaf.String(), "$$") {
+                    hasYY = true
+                    leaf.SetString(strings.Replace(leaf.String(),"$$","yy",-1))
                 }
-                leaf = leaf.Next()
+                leaf.SetString(strings.Replace(leaf.String(), "YYSTYPE", t.YYSType,-1))
+
+            // List types
+            case TypeSequence:
+                variableCount += traverse_var_cnt(leaf)
+            case TypeAlternate:
+                variableCount += traverse_var_cnt(leaf)
+
+            // Fix types
+            case TypePeekFor:
+                fallthrough
+            case TypePeekNot:
+                fallthrough
+            case TypeQuery:
+                fallthrough
+            case TypeStar:
+                fallthrough
+            case TypePlus:
+                fallthrough
+            case TypeRepeat:
+                fallthrough
+            case TypePush:
+                variableCount += traverse_var_cnt(leaf)
             }
-            return variableCount + next_level_count
-        }
 
-        traverse_var_replace = func(n Node) {
-            leaf := n.Front()
-            if leaf == nil {
-                return
+            if leaf.Next()==nil {
+                break
             }
+            leaf = leaf.Next()
+        }
+        return variableCount + next_level_count
+    }
 
-            for {
-                switch leaf.GetType() {
-                case TypeName:
-                    // Store relative stack index of variable for later use
-                    if leaf.Front()!=nil && leaf.Front().GetType()==TypeVariable {
-                        for i, var_element := range var_stack {
-                            if var_element == leaf.Front().String() {
-                                leaf.Front().hasVariable = len(var_stack)-i-1
-                                break
-                            }
+    traverse_var_replace = func(n Node) {
+        leaf := n.Front()
+        if leaf == nil {
+            return
+        }
+
+        for {
+            switch leaf.GetType() {
+            case TypeName:
+                // Store relative stack index of variable for later use
+                if leaf.Front()!=nil && leaf.Front().GetType()==TypeVariable {
+                    for i, var_element := range var_stack {
+                        if var_element == leaf.Front().String() {
+                            leaf.Front().hasVariable = len(var_stack)-i-1
+                            break
                         }
                     }
-                case TypeAction:
-                    // Use regular expression to extract every variable and replace them
-                    re := regexp.MustCompile("[a-zA-Z_][a-zA-Z0-9_]*")
-                    str := leaf.String()
-                    tempStr := make([]string, 0)
-                    lastIndex := 0
-                    for _, element := range re.FindAllStringIndex(str, -1) {
-                        varname := str[element[0]:element[1]]
-                        tempStr = append(tempStr, str[lastIndex:element[0]])
-                        lastIndex = element[1]
-                        hasReplaced := false
-                        for i, var_element := range var_stack {
-                            if var_element == varname {
-                                tempStr = append(tempStr,fmt.Sprintf("stack[stack_idx-%d]", len(var_stack)-i-1))
-                                hasReplaced = true
-                                break
-                            }
-                        }
-                        if !hasReplaced {
-                            tempStr = append(tempStr, str[element[0]:element[1]])
+                }
+            case TypeAction:
+                // Use regular expression to extract every variable and replace them
+                re := regexp.MustCompile("[a-zA-Z_][a-zA-Z0-9_]*")
+                str := leaf.String()
+                tempStr := make([]string, 0)
+                lastIndex := 0
+                for _, element := range re.FindAllStringIndex(str, -1) {
+                    varname := str[element[0]:element[1]]
+                    tempStr = append(tempStr, str[lastIndex:element[0]])
+                    lastIndex = element[1]
+                    hasReplaced := false
+                    for i, var_element := range var_stack {
+                        if var_element == varname {
+                            tempStr = append(tempStr,fmt.Sprintf("stack[stack_idx-%d]", len(var_stack)-i-1))
+                            hasReplaced = true
+                            break
                         }
                     }
-                    tempStr = append(tempStr, str[lastIndex:])
-                    leaf.SetString(strings.Join(tempStr,""))
-                    str = leaf.String()
-                    rule = leaf
-
-                // List types
-                case TypeSequence:
-                    traverse_var_replace(leaf)
-                case TypeAlternate:
-                    traverse_var_replace(leaf)
-
-                // Fix types
-                case TypePeekFor:
-                    fallthrough
-                case TypePeekNot:
-                    fallthrough
-                case TypeQuery:
-                    fallthrough
-                case TypeStar:
-                    fallthrough
-                case TypePlus:
-                    fallthrough
-                case TypePush:
-                    traverse_var_replace(leaf)
-                }
-                if leaf.Next()==nil {
-                    break
+                    if !hasReplaced {
+                        tempStr = append(tempStr, str[element[0]:element[1]])
+                    }
                 }
-                leaf = leaf.Next()
-            }
-        }
+                tempStr = append(tempStr, str[lastIndex:])
+                leaf.SetString(strings.Join(tempStr,""))
+                str = leaf.String()
+                rule = leaf
 
-        traverse_node := t.Front()
-        for {
-            hasVariable = false
-            hasYY = false
-            var_stack = make([]string, 0)
-            variableCount := traverse_var_cnt(traverse_node)
-            if hasVariable {
-                traverse_node.hasVariable = variableCount
-                traverse_var_replace(traverse_node)
-                t.HasVariable = true
-            }
-            if hasYY {
-                traverse_node.hasYY = true
-            }
-            rule = nil
-            if traverse_node.Next() == nil {
+            // List types
+            case TypeSequence:
+                traverse_var_replace(leaf)
+            case TypeAlternate:
+                traverse_var_replace(leaf)
+
+            // Fix types
+            case TypePeekFor:
+                fallthrough
+            case TypePeekNot:
+                fallthrough
+            case TypeQuery:
+                fallthrough
+            case TypeStar:
+                fallthrough
+            case TypePlus:
+                fallthrough
+            case TypeRepeat:
+                fallthrough
+            case TypePush:
+                traverse_var_replace(leaf)
+            }
+            if leaf.Next()==nil {
                 break
             }
-            traverse_node = traverse_node.Next()
+            leaf = leaf.Next()
         }
+    }
 
-        link = func(n Node) {
-            nodeType := n.GetType()
-            id := counts[nodeType]
-            counts[nodeType]++
-            switch nodeType {
-            case TypeAction:
-                n.SetId(int(id))
-                copy, name := n.Copy(), fmt.Sprintf("Action%v", id)
-                t.Actions = append(t.Actions, copy)
-                n.Init()
-                n.SetType(TypeName)
-                n.SetString(name)
-                n.SetId(t.RulesCount)
+    traverse_node := t.Front()
+    for {
+        hasVariable = false
+        hasYY = false
+        var_stack = make([]string, 0)
+        variableCount := traverse_var_cnt(traverse_node)
+        if hasVariable {
+            traverse_node.hasVariable = variableCount
+            traverse_var_replace(traverse_node)
+            t.HasVariable = true
+        }
+        if hasYY {
+            traverse_node.hasYY = true
+        }
+        rule = nil
+        if traverse_node.Next() == nil {
+            break
+        }
+        traverse_node = traverse_node.Next()
+    }
 
+    link = func(n Node) {
+        nodeType := n.GetType()
+        id := counts[nodeType]
+        counts[nodeType]++
+        switch nodeType {
+        case TypeAction:
+            n.SetId(int(id))
+            copy, name := n.Copy(), fmt.Sprintf("Action%v", id)
+            t.Actions = append(t.Actions, copy)
+            n.Init()
+            n.SetType(TypeName)
+            n.SetString(name)
+            n.SetId(t.RulesCount)
+
+            emptyRule := &node{Type: TypeRule, string: name, id: t.RulesCount, position: copy.position}
+            implicitPush := &node{Type: TypeImplicitPush}
+            emptyRule.PushBack(implicitPush)
+            implicitPush.PushBack(copy)
+            implicitPush.PushBack(emptyRule.Copy())
+            t.PushBack(emptyRule)
+            t.RulesCount++
+
+            t.Rules[name] = emptyRule
+            t.RuleNames = append(t.RuleNames, emptyRule)
+        case TypeName:
+            name := n.String()
+            if _, ok := t.Rules[name]; !ok {
+                t.report(n, "rule '%v' used but not defined", n)
                 emptyRule := &node{Type: TypeRule, string: name, id: t.RulesCount}
                 implicitPush := &node{Type: TypeImplicitPush}
                 emptyRule.PushBack(implicitPush)
-                implicitPush.PushBack(copy)
+                implicitPush.PushBack(&node{Type: TypeNil, string: "<nil>"})
                 implicitPush.PushBack(emptyRule.Copy())
                 t.PushBack(emptyRule)
                 t.RulesCount++
 
                 t.Rules[name] = emptyRule
                 t.RuleNames = append(t.RuleNames, emptyRule)
-            case TypeName:
-                name := n.String()
-                if _, ok := t.Rules[name]; !ok {
-                    emptyRule := &node{Type: TypeRule, string: name, id: t.RulesCount}
-                    implicitPush := &node{Type: TypeImplicitPush}
-                    emptyRule.PushBack(implicitPush)
-                    implicitPush.PushBack(&node{Type: TypeNil, string: "<nil>"})
-                    implicitPush.PushBack(emptyRule.Copy())
-                    t.PushBack(emptyRule)
-                    t.RulesCount++
-
-                    t.Rules[name] = emptyRule
-                    t.RuleNames = append(t.RuleNames, emptyRule)
-                }
-            case TypePush:
-                copy, name := rule.Copy(), "PegText"
-                copy.SetString(name)
-                if _, ok := t.Rules[name]; !ok {
-                    emptyRule := &node{Type: TypeRule, string: name, id: t.RulesCount}
-                    emptyRule.PushBack(&node{Type: TypeNil, string: "<nil>"})
-                    t.PushBack(emptyRule)
-                    t.RulesCount++
-
-                    t.Rules[name] = emptyRule
-                    t.RuleNames = append(t.RuleNames, emptyRule)
-                }
-                n.PushBack(copy)
-                fallthrough
-            case TypeImplicitPush:
-                link(n.Front())
-            case TypeRule, TypeAlternate, TypeUnorderedAlternate, TypeSequence,
-                TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus:
-                for _, node := range n.Slice() {
-                    link(node)
-                }
             }
-        }
-        /* first pass */
-        for _, node := range t.Slice() {
-            switch node.GetType() {
-            case TypePackage:
-                t.PackageName = node.String()
-            case TypeLeg:
-                t.StructName = node.String()
-                t.StructVariables = node.Front().String()
-            case TypeRule:
-                if _, ok := t.Rules[node.String()]; !ok {
-                    expression := node.Front()
-                    copy := expression.Copy()
-                    expression.Init()
-                    expression.SetType(TypeImplicitPush)
-                    expression.PushBack(copy)
-                    expression.PushBack(node.Copy())
-
-                    t.Rules[node.String()] = node
-                    t.RuleNames = append(t.RuleNames, node)
-                }
+        case TypePush:
+            copy, name := rule.Copy(), "PegText"
+            copy.SetString(name)
+            if _, ok := t.Rules[name]; !ok {
+                emptyRule := &node{Type: TypeRule, string: name, id: t.RulesCount}
+                emptyRule.PushBack(&node{Type: TypeNil, string: "<nil>"})
+                t.PushBack(emptyRule)
+                t.RulesCount++
+
+                t.Rules[name] = emptyRule
+                t.RuleNames = append(t.RuleNames, emptyRule)
             }
-        }
-        /* second pass */
-        for _, node := range t.Slice() {
-            if node.GetType() == TypeRule {
-                rule = node
+            n.PushBack(copy)
+            fallthrough
+        case TypeImplicitPush:
+            link(n.Front())
+        case TypeRule, TypeAlternate, TypeUnorderedAlternate, TypeSequence,
+            TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus, TypeRepeat:
+            for _, node := range n.Slice() {
                 link(node)
             }
         }
     }
+    /* first pass */
+    for _, node := range t.Slice() {
+        switch node.GetType() {
+        case TypePackage:
+            t.PackageName = node.String()
+        case TypeLeg:
+            t.StructName = node.String()
+            t.StructVariables = node.Front().String()
+        case TypeRule:
+            if _, ok := t.Rules[node.String()]; !ok {
+                expression := node.Front()
+                copy := expression.Copy()
+                expression.Init()
+                expression.SetType(TypeImplicitPush)
+                expression.PushBack(copy)
+                expression.PushBack(node.Copy())
+
+                t.Rules[node.String()] = node
+                t.RuleNames = append(t.RuleNames, node)
+            }
+        }
+    }
+    /* second pass */
+    for _, node := range t.Slice() {
+        if node.GetType() == TypeRule {
+            rule = node
+            link(node)
+        }
+    }
+    return
+}
 
+/* Check the linked rules for left recursion and report rules which are never used. */
+func (t *Tree) check() {
     join([]func(){
         func() {
             var countRules func(node Node)
@@ -1153,7 +2481,7 @@ func (t *Tree) Compile(file string) {
                 case TypeImplicitPush, TypePush:
                     countRules(node.Front())
                 case TypeAlternate, TypeUnorderedAlternate, TypeSequence,
-                    TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus:
+                    TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus, TypeRepeat:
                     for _, element := range node.Slice() {
                         countRules(element)
                     }
@@ -1174,7 +2502,7 @@ func (t *Tree) Compile(file string) {
                 case TypeRule:
                     id := node.GetId()
                     if ruleReached[id] {
-                        fmt.Fprintf(os.Stderr, "possible infinite left recursion in rule '%v'\n", node)
+                        t.report(node, "possible infinite left recursion in rule '%v'", node)
                         return false
                     }
                     ruleReached[id] = true
@@ -1198,9 +2526,14 @@ func (t *Tree) Compile(file string) {
                     return checkRecursion(t.Rules[node.String()])
                 case TypePlus, TypePush, TypeImplicitPush:
                     return checkRecursion(node.Front())
+                case TypeRepeat:
+                    /* the body is tried first even when it may be left out, but then it need not consume */
+                    if min, max := repeatBounds(node.String()); max != 0 {
+                        return checkRecursion(node.Front()) && min > 0
+                    }
                 case TypeCharacter, TypeString:
                     return len(node.String()) > 0
-                case TypeDot, TypeRange:
+                case TypeDot, TypeRange, TypeClass:
                     return true
                 }
                 return false
@@ -1212,6 +2545,277 @@ func (t *Tree) Compile(file string) {
             }
         }})
 
+    highlights := t.Highlights[:0]
+    for _, highlight := range t.Highlights {
+        if _, ok := t.Rules[highlight.Rule]; !ok {
+            t.report(&node{Type: TypeName, string: highlight.Rule, position: highlight.Position}, "rule '%v' highlighted but not defined", highlight.Rule)
+            continue
+        }
+        highlights = append(highlights, highlight)
+    }
+    t.Highlights = highlights
+
+    for _, element := range t.Slice() {
+        if element.GetType() != TypeRule {
+            continue
+        }
+        if element.Front().GetType() == TypeNil {
+            continue
+        }
+        if _, ok := t.RuleFiles[element.String()]; ok {
+            /* grammars import more rules than they use */
+            continue
+        }
+        if _, ok := t.rulesCount[element.String()]; !ok {
+            t.report(element, "rule '%v' defined but not used", element)
+        }
+    }
+}
+
+/* Write Go source formatted; nothing is written when it does not parse. */
+func writeFormatted(out io.Writer, file string, buffer *bytes.Buffer) error {
+    fileSet := token.NewFileSet()
+    code, error := parser.ParseFile(fileSet, file, buffer, parser.ParseComments)
+    if error != nil {
+        return error
+    }
+    formatter := printer.Config{Mode: printer.TabIndent | printer.UseSpaces, Tabwidth: 8}
+    return formatter.Fprint(out, fileSet, code)
+}
+
+/* The labels of the captures in a rule, numbered in order; back references can only refer to these. */
+func captureLabels(rule Node) map[string]int {
+    labels := make(map[string]int)
+    var visit func(n Node)
+    visit = func(n Node) {
+        switch n.GetType() {
+        case TypePush, TypeImplicitPush:
+            if _, ok := labels[n.String()]; n.GetType() == TypePush && n.String() != "" && !ok {
+                labels[n.String()] = len(labels)
+            }
+            /* once linked, a push also holds the rule of its token */
+            visit(n.Front())
+            return
+        case TypeName, TypeRule:
+            return
+        }
+        for _, element := range n.Slice() {
+            visit(element)
+        }
+    }
+    visit(rule.Front())
+    return labels
+}
+
+/* Parse each piece of Go code in the grammar on its own, so that an error in one is reported
+   against its rule and place in the grammar instead of somewhere in the generated parser. */
+func (t *Tree) validate() error {
+    var errors []string
+    check := func(what string, position Position, prefix, code, suffix string) {
+        directive := ""
+        if position.Line > 0 {
+            directive = fmt.Sprintf("/*line %v:%v:%v*/", position.File, position.Line, position.Column)
+        }
+        _, error := parser.ParseFile(token.NewFileSet(), "", prefix+directive+code+suffix, 0)
+        list, ok := error.(scanner.ErrorList)
+        switch {
+        case error == nil:
+        case !ok:
+            errors = append(errors, fmt.Sprintf("%v: %v", what, error))
+        case position.Line > 0:
+            /* where the code ends and the text added around it to make a file starts */
+            line, column := position.Line+strings.Count(code, "\n"), position.Column+len(code)
+            if i := strings.LastIndex(code, "\n"); i >= 0 {
+                column = len(code) - i
+            }
+            for _, e := range list {
+                if e.Pos.Line > line || e.Pos.Line == line && e.Pos.Column >= column {
+                    e.Pos.Line, e.Pos.Column = position.Line, position.Column
+                }
+                errors = append(errors, fmt.Sprintf("%v: %v: %v", e.Pos, what, e.Msg))
+            }
+        default:
+            for _, e := range list {
+                errors = append(errors, fmt.Sprintf("%v: %v", what, e.Msg))
+            }
+        }
+    }
+
+    var visit func(rule, n Node)
+    visit = func(rule, n Node) {
+        switch n.GetType() {
+        case TypeAction:
+            check(fmt.Sprintf("action in rule '%v'", rule), n.GetPosition(), "package p; func _() {",
+                strings.Replace(n.String(), "$$", "yy", -1), "\n}")
+        case TypePredicate:
+            check(fmt.Sprintf("predicate in rule '%v'", rule), n.GetPosition(), "package p; var _ = (", n.String(), ")")
+        case TypeBackReference:
+            if _, ok := captureLabels(rule)[n.String()]; !ok {
+                errors = append(errors, Diagnostic{Position: n.GetPosition(),
+                    Message: fmt.Sprintf("back reference in rule '%v' to undefined capture '%v'", rule, n)}.String())
+            }
+        }
+        for _, element := range n.Slice() {
+            visit(rule, element)
+        }
+    }
+    for _, n := range t.Slice() {
+        if n.GetType() == TypeRule {
+            visit(n, n)
+        }
+    }
+    for i, declaration := range t.Declarations {
+        var position Position
+        if i < len(t.declarationsAt) {
+            position = t.declarationsAt[i]
+        }
+        check("declaration", position, "package p;", declaration, "")
+    }
+    if t.Trailer != "" {
+        check("trailer", t.trailerAt, "package p;", t.Trailer, "")
+    }
+
+    if len(errors) > 0 {
+        return fmt.Errorf("%v", strings.Join(errors, "\n"))
+    }
+    return nil
+}
+
+/* The //line directive giving the grammar position of the code of an action or predicate, relative
+   to the generated file; none when the grammar came from stdin, which has no name to point at. */
+func (t *Tree) LineDirective(n Node) string {
+    position := n.GetPosition()
+    if position.Line == 0 || position.File == "-" {
+        return ""
+    }
+    file := position.File
+    if grammar, error := filepath.Abs(file); error == nil {
+        if output, error := filepath.Abs(t.output); error == nil {
+            if relative, error := filepath.Rel(filepath.Dir(output), grammar); error == nil {
+                file = relative
+            }
+        }
+    }
+    /* the position of the first character of the code, which formatting moves to the start of a line */
+    line, column := position.Line, position.Column
+    for _, c := range n.String() {
+        if c == '\n' {
+            line, column = line+1, 1
+        } else if c == ' ' || c == '\t' || c == '\r' {
+            column++
+        } else {
+            break
+        }
+    }
+    return fmt.Sprintf("//line %v:%v:%v\n", filepath.ToSlash(file), line, column)
+}
+
+/* The code of an action or predicate, starting where its //line directive puts it. */
+func (t *Tree) LineCode(n Node) string {
+    if n.GetPosition().Line == 0 {
+        return n.String()
+    }
+    return strings.TrimLeft(n.String(), " \t\r\n")
+}
+
+var lineDirective = regexp.MustCompile(`^//line (.+):([0-9]+):([0-9]+)$`)
+
+/* The placeholder directive after the code of an action or predicate with a directive, which
+   restoreLines points back at the generated file; none when that goes to stdout, which has no name. */
+func (t *Tree) LineRestore(n Node) string {
+    if t.output == "-" || t.LineDirective(n) == "" {
+        return ""
+    }
+    return fmt.Sprintf("//line %v:1\n", filepath.Base(t.output))
+}
+
+/* Point the placeholder directives after actions and predicates at the lines of the generated file
+   which follow them, so that only the code from the grammar is attributed to it. */
+func (t *Tree) restoreLines(code []byte) []byte {
+    placeholder := []byte(fmt.Sprintf("//line %v:1", filepath.Base(t.output)))
+    lines := bytes.Split(code, []byte("\n"))
+    for i, line := range lines {
+        if bytes.Equal(line, placeholder) {
+            lines[i] = []byte(fmt.Sprintf("//line %v:%v", filepath.Base(t.output), i+2))
+            continue
+        }
+        /* the column of a directive is that of the code, not of the indentation in front of it */
+        directive := lineDirective.FindSubmatch(line)
+        if directive == nil || i+1 == len(lines) {
+            continue
+        }
+        column, _ := strconv.Atoi(string(directive[3]))
+        column -= len(lines[i+1]) - len(bytes.TrimLeft(lines[i+1], " \t"))
+        if column < 1 {
+            lines[i] = []byte(fmt.Sprintf("//line %s:%s", directive[1], directive[2]))
+        } else {
+            lines[i] = []byte(fmt.Sprintf("//line %s:%s:%v", directive[1], directive[2], column))
+        }
+    }
+    return bytes.Join(lines, []byte("\n"))
+}
+
+/* The file of the benchmark and fuzz harness of the parser in file: grammar.leg.go gets grammar_leg_test.go
+   and grammar.peg.go gets grammar_peg_test.go. */
+func HarnessFile(file string) string {
+    name := strings.TrimSuffix(file, ".go")
+    for _, extension := range []string{".leg", ".peg"} {
+        if strings.HasSuffix(name, extension) {
+            name = strings.TrimSuffix(name, extension) + "_" + extension[1:]
+        }
+    }
+    return name + "_test.go"
+}
+
+/* Write the benchmark and fuzz harness of the parser to out; name names it in errors. */
+func (t *Tree) GenerateHarness(out io.Writer, name string) error {
+    var buffer bytes.Buffer
+    if error := template.Must(template.New("test").Parse(LEG_TEST_TEMPLATE)).Execute(&buffer, t); error != nil {
+        panic(error)
+    }
+    return writeFormatted(out, name, &buffer)
+}
+
+/* Write the benchmark and fuzz harness of the parser in file next to it. */
+func (t *Tree) compileHarness(file string) error {
+    name := HarnessFile(file)
+    var generated bytes.Buffer
+    if error := t.GenerateHarness(&generated, name); error != nil {
+        return fmt.Errorf("%v: %v", name, error)
+    }
+    return ioutil.WriteFile(name, generated.Bytes(), 0644)
+}
+
+/* Write the parser to file, and its harness next to it when asked for. */
+func (t *Tree) Compile(file string) error {
+    /* the file is only written once the whole parser has come out right */
+    var generated bytes.Buffer
+    if error := t.Generate(&generated, file); error != nil {
+        return error
+    }
+    if error := ioutil.WriteFile(file, generated.Bytes(), 0644); error != nil {
+        return error
+    }
+    if t.Harness {
+        return t.compileHarness(file)
+    }
+    return nil
+}
+
+/* Write the parser to out; file names it in errors and directives, - for stdout. */
+func (t *Tree) Generate(out io.Writer, file string) (err error) {
+    if err := t.validate(); err != nil {
+        return err
+    }
+    if t.Trace {
+        /* every rule needs a closure of its own to be traced */
+        t.inline = false
+    }
+    t.EndSymbol = '\u0004'
+
+    counts := t.link()
+    t.check()
+
     if t._switch {
         var optimizeAlternates func(node Node) (consumes bool, s *set)
         cache, firstPass := make([]struct {
@@ -1240,15 +2844,38 @@ func (t *Tree) Compile(file string) {
                 s.complement()
             case TypeString, TypeCharacter:
                 consumes, s = true, &set{}
-                s.add(n.String()[0])
+                if c, _ := utf8.DecodeRuneInString(n.String()); c > 0xFF {
+                    /* the set holds bytes only, so take them all */
+                    s.complement()
+                } else {
+                    s.add(uint8(c))
+                }
             case TypeRange:
                 consumes, s = true, &set{}
                 element := n.Front()
-                lower := element.String()[0]
+                lower, _ := utf8.DecodeRuneInString(element.String())
                 element = element.Next()
-                upper := element.String()[0]
+                upper, _ := utf8.DecodeRuneInString(element.String())
+                if upper > 0xFF {
+                    s.complement()
+                    break
+                }
                 for c := lower; c <= upper; c++ {
-                    s.add(c)
+                    s.add(uint8(c))
+                }
+            case TypeClass:
+                consumes, s = true, &set{}
+                r, _ := classRanges(n)
+                for _, p := range r {
+                    if p[1] > 0xFF {
+                        /* the set holds bytes only, so take them all */
+                        s = &set{}
+                        s.complement()
+                        break
+                    }
+                    for c := p[0]; c <= p[1]; c++ {
+                        s.add(uint8(c))
+                    }
                 }
             case TypeAlternate:
                 consumes, s = true, &set{}
@@ -1296,7 +2923,7 @@ func (t *Tree) Compile(file string) {
                         class := &node{Type: TypeUnorderedAlternate}
                         for d := 0; d < 256; d++ {
                             if properties[c].s.has(uint8(d)) {
-                                class.PushBack(&node{Type: TypeCharacter, string: string(d)})
+                                class.PushBack(&node{Type: TypeCharacter, string: string(rune(d))})
                             }
                         }
 
@@ -1363,6 +2990,15 @@ func (t *Tree) Compile(file string) {
                 _, s = optimizeAlternates(n.Front())
             case TypePlus, TypePush, TypeImplicitPush:
                 consumes, s = optimizeAlternates(n.Front())
+            case TypeRepeat:
+                consumes, s = optimizeAlternates(n.Front())
+                if min, _ := repeatBounds(n.String()); min == 0 {
+                    consumes = false
+                }
+            case TypeBackReference:
+                /* it can match whatever was captured */
+                consumes, s = true, &set{}
+                s.complement()
             case TypeAction, TypeNil:
                 s = &set{}
             }
@@ -1387,35 +3023,42 @@ func (t *Tree) Compile(file string) {
         }
     }
 
-    out, error := os.OpenFile(file, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
-    if error != nil {
-        fmt.Printf("%v: %v\n", file, error)
-        return
-    }
-    defer out.Close()
-
     var buffer bytes.Buffer
+    t.output = file
     defer func() {
-        fileSet := token.NewFileSet()
-        code, error := parser.ParseFile(fileSet, file, &buffer, parser.ParseComments)
-        if error != nil {
-            buffer.WriteTo(out)
-            fmt.Printf("%v: %v\n", file, error)
+        var formatted bytes.Buffer
+        if err = writeFormatted(&formatted, file, &buffer); err != nil {
+            err = fmt.Errorf("%v: %v", file, err)
             return
         }
-        formatter := printer.Config{Mode: printer.TabIndent | printer.UseSpaces, Tabwidth: 8}
-        error = formatter.Fprint(out, fileSet, code)
-        if error != nil {
-            buffer.WriteTo(out)
-            fmt.Printf("%v: %v\n", file, error)
-            return
-        }
-
+        _, err = out.Write(t.restoreLines(formatted.Bytes()))
     }()
 
     print := func(format string, a ...interface{}) { fmt.Fprintf(&buffer, format, a...) }
-    printSave := func(n uint) { print("\n   position%d, tokenIndex%d, depth%d := position, tokenIndex, depth", n, n, n) }
-    printRestore := func(n uint) { print("\n   position, tokenIndex, depth = position%d, tokenIndex%d, depth%d", n, n, n) }
+    /* the labels of the captures of the rule being compiled */
+    var captures map[string]int
+    printSave := func(n uint) {
+        print("\n   position%d, tokenIndex%d, depth%d := position, tokenIndex, depth", n, n, n)
+        if t.HasPredicate {
+            print("\n   undos%d := len(undos)", n)
+        }
+        if len(captures) > 0 {
+            print("\n   captures%d := captures", n)
+        }
+    }
+    var traced string
+    printRestore := func(n uint) {
+        if t.Trace {
+            print("\n   if position != position%d {\n   trace(TraceBacktrack, Rule%v, position%d, position)\n   }", n, traced, n)
+        }
+        print("\n   position, tokenIndex, depth = position%d, tokenIndex%d, depth%d", n, n, n)
+        if t.HasPredicate {
+            print("\n   rollback(undos%d)", n)
+        }
+        if len(captures) > 0 {
+            print("\n   captures = captures%d", n)
+        }
+    }
     printTemplate := func(s string) {
         if error := template.Must(template.New("leg").Parse(s)).Execute(&buffer, t); error != nil {
             panic(error)
@@ -1423,14 +3066,17 @@ func (t *Tree) Compile(file string) {
     }
 
     t.HasActions = counts[TypeAction] > 0
+    t.HasPredicate = counts[TypePredicate] > 0
     t.HasCommit = counts[TypeCommit] > 0
     t.HasDot = counts[TypeDot] > 0
     t.HasCharacter = counts[TypeCharacter] > 0
     t.HasString = counts[TypeString] > 0
     t.HasRange = counts[TypeRange] > 0
+    t.HasClass = counts[TypeClass] > 0
+    t.HasBackReference = counts[TypeBackReference] > 0
 
-    var printRule func(n Node)
     var compile func(expression Node, ko uint)
+    var classes []string
     var label uint
     labels := make(map[uint]bool)
     printBegin := func() { print("\n   {\n") }
@@ -1445,83 +3091,6 @@ func (t *Tree) Compile(file string) {
         print("\n   goto l%d", n)
         labels[n] = true
     }
-    printRule = func(n Node) {
-        switch n.GetType() {
-        case TypeRule:
-            print("%v <- ", n)
-            printRule(n.Front())
-        case TypeDot:
-            print(".")
-        case TypeName:
-            print("%v", n)
-        case TypeCharacter:
-            print("'%v'", escape(n.String()))
-        case TypeString:
-            s := escape(n.String())
-            print("'%v'", s[1:len(s)-1])
-        case TypeRange:
-            element := n.Front()
-            lower := element
-            element = element.Next()
-            upper := element
-            print("[%v-%v]", lower, upper)
-        case TypePredicate:
-            print("&{%v}", n)
-        case TypeAction:
-            print("{%v}", n)
-        case TypeCommit:
-            print("commit")
-        case TypeAlternate:
-            print("(")
-            elements := n.Slice()
-            printRule(elements[0])
-            for _, element := range elements[1:] {
-                print(" / ")
-                printRule(element)
-            }
-            print(")")
-        case TypeUnorderedAlternate:
-            print("(")
-            elements := n.Slice()
-            printRule(elements[0])
-            for _, element := range elements[1:] {
-                print(" | ")
-                printRule(element)
-            }
-            print(")")
-        case TypeSequence:
-            print("(")
-            elements := n.Slice()
-            printRule(elements[0])
-            for _, element := range elements[1:] {
-                print(" ")
-                printRule(element)
-            }
-            print(")")
-        case TypePeekFor:
-            print("&")
-            printRule(n.Front())
-        case TypePeekNot:
-            print("!")
-            printRule(n.Front())
-        case TypeQuery:
-            printRule(n.Front())
-            print("?")
-        case TypeStar:
-            printRule(n.Front())
-            print("*")
-        case TypePlus:
-            printRule(n.Front())
-            print("+")
-        case TypePush, TypeImplicitPush:
-            print("<")
-            printRule(n.Front())
-            print(">")
-        case TypeNil:
-        default:
-            fmt.Fprintf(os.Stderr, "illegal node type: %v\n", n.GetType())
-        }
-    }
     printClearStack := func(n Node) {
         print("\n   for i:=0; i < variableTotal; i++ {")
         print("\n      add(RuleActionPop, position)")
@@ -1540,7 +3109,7 @@ func (t *Tree) Compile(file string) {
         case TypeName:
             name := n.String()
             rule := t.Rules[name]
-            if t.inline && t.rulesCount[name] == 1 {
+            if t.inline && t.rulesCount[name] == 1 && len(captureLabels(rule)) == 0 {
                 compile(rule.Front(), ko)
                 return
             }
@@ -1553,7 +3122,7 @@ func (t *Tree) Compile(file string) {
             if n.Front() != nil && n.Front().GetType() == TypeVariable {
                 // Rewind stack index to this variable
                 print("\n   variableIdx = ")
-                print(strconv.Itoa(n.Front().HasVariable()))
+                print("%d", n.Front().HasVariable())
                 print("\n   for i:=0; i < variableIdx ; i++ {")
                 print("\n       add(RuleActionPop, position)")
                 print("\n   }")
@@ -1573,6 +3142,26 @@ func (t *Tree) Compile(file string) {
             print("\n   if c := buffer[position]; c < rune('%v') || c > rune('%v') {", escape(lower.String()), escape(upper.String()))
             printJump(ko)
             print("}\nposition++")
+        case TypeClass:
+            r, _ := classRanges(n)
+            r = r.difference(ranges{{t.EndSymbol, t.EndSymbol}})
+            table := make([]string, 0, 2*len(r))
+            for _, p := range r {
+                table = append(table, fmt.Sprintf("%#x, %#x", p[0], p[1]))
+            }
+            /* classes with the same ranges share a table */
+            class := len(classes)
+            for i, c := range classes {
+                if c == strings.Join(table, ", ") {
+                    class = i
+                }
+            }
+            if class == len(classes) {
+                classes = append(classes, strings.Join(table, ", "))
+            }
+            print("\n   if !matchClass(class%d) {", class)
+            printJump(ko)
+            print("}")
         case TypeCharacter:
             /*print("\n   if !matchChar('%v') {", escape(n.String()))*/
             print("\n   if buffer[position] != rune('%v') {", escape(n.String()))
@@ -1583,9 +3172,19 @@ func (t *Tree) Compile(file string) {
             printJump(ko)
             print("}")
         case TypePredicate:
+            if directive := t.LineDirective(n); directive != "" {
+                print("\n   if !(\n%v%v) {\n%v", directive, t.LineCode(n), t.LineRestore(n))
+                printJump(ko)
+                print("}")
+                break
+            }
             print("\n   if !(%v) {", n)
             printJump(ko)
             print("}")
+        case TypeBackReference:
+            print("\n   if !matchCapture(captures[%d]) {", captures[n.String()])
+            printJump(ko)
+            print("}")
         case TypeAction:
         case TypeCommit:
         case TypePush:
@@ -1603,6 +3202,9 @@ func (t *Tree) Compile(file string) {
                 compile(element, ko)
                 print("\ndepth--")
                 print("\nadd(Rule%v, position%d)", rule, ok)
+                if label := n.String(); n.GetType() == TypePush && label != "" {
+                    print("\ncaptures[%d] = [2]int{position%d, position}", captures[label], ok)
+                }
             }
             printEnd()
         case TypeAlternate:
@@ -1728,6 +3330,48 @@ func (t *Tree) Compile(file string) {
             printLabel(out)
             printRestore(out)
             printEnd()
+        case TypeRepeat:
+            min, max := repeatBounds(n.String())
+            if max == 0 {
+                break
+            }
+            again := label
+            label++
+            out := label
+            label++
+            done := label
+            label++
+            /* {0,} is a star and needs no count */
+            counted := min > 0 || max > 0
+            printBegin()
+            if counted {
+                print("\n   count%d := 0", again)
+            }
+            printLabel(again)
+            printBegin()
+            printSave(out)
+            compile(n.Front(), out)
+            if counted {
+                print("\n   count%d++", again)
+            }
+            if max > 0 {
+                print("\n   if count%d < %d {", again, max)
+                printJump(again)
+                print("}")
+                printJump(done)
+            } else {
+                printJump(again)
+            }
+            printLabel(out)
+            printRestore(out)
+            printEnd()
+            printLabel(done)
+            if min > 0 {
+                print("\n   if count%d < %d {", again, min)
+                printJump(ko)
+                print("}")
+            }
+            printEnd()
         case TypeNil:
         default:
             fmt.Fprintf(os.Stderr, "illegal node type: %v\n", n.GetType())
@@ -1748,52 +3392,66 @@ func (t *Tree) Compile(file string) {
         label++
         if count, ok := t.rulesCount[element.String()]; !ok {
             continue
-        } else if t.inline && count == 1 && ko != 0 {
+        } else if t.inline && count == 1 && ko != 0 && len(captureLabels(element)) == 0 {
             continue
         }
+        captures = captureLabels(element)
         compile(expression, ko)
     }
-    print, label = printTemp, 0
+    print, label, classes = printTemp, 0, nil
 
     /* now for the real compile pass */
     printTemplate(LEG_HEADER_TEMPLATE)
     for _, element := range t.Slice() {
-        hasVariable = false
         if element.GetType() != TypeRule {
             continue
         }
         expression := element.Front()
         if expression.GetType() == TypeNil {
-            fmt.Fprintf(os.Stderr, "rule '%v' used but not defined\n", element)
             print("\n  nil,")
             continue
         }
         ko := label
         label++
         print("\n  /* %v ", element.GetId())
-        printRule(element)
+        printRule(&buffer, element)
+        if position := element.GetPosition(); position.Line > 0 {
+            print(" (%v:%v)", filepath.Base(position.File), position.Line)
+        }
         print(" */")
         if count, ok := t.rulesCount[element.String()]; !ok {
-            fmt.Fprintf(os.Stderr, "rule '%v' defined but not used\n", element)
             print("\n  nil,")
             continue
-        } else if t.inline && count == 1 && ko != 0 {
+        } else if t.inline && count == 1 && ko != 0 && len(captureLabels(element)) == 0 {
             print("\n  nil,")
             continue
         }
         print("\n  func() bool {")
+        /* captures are kept for each call of the rule, and restored with the position */
+        if captures = captureLabels(element); len(captures) > 0 {
+            print("\n   var captures [%d][2]int", len(captures))
+        }
         if labels[ko] {
             printSave(ko)
         }
+        /* the rules made of actions only add a token and are left out */
+        traced = element.String()
+        tracing := t.Trace
+        if expression.GetType() == TypeImplicitPush && expression.Front().GetType() == TypeAction {
+            tracing = false
+        }
+        if tracing {
+            print("\n   traceBegin := position")
+            print("\n   trace(TraceEnter, Rule%v, position, position)", traced)
+        }
         if element.HasVariable()>0 {
             print("\n   variableIdx := 0")
             print("\n   variableTotal := ")
-            print(strconv.Itoa(element.HasVariable()))
+            print("%d", element.HasVariable())
             // Preserve enough stack space for the rule
             print("\n   for i:=0; i < variableTotal; i++ {")
             print("\n       add(RuleActionPush, position)")
             print("\n   }")
-            hasVariable = true            
         }
         compile(expression, ko)
         if element.HasVariable()>0 {
@@ -1802,16 +3460,32 @@ func (t *Tree) Compile(file string) {
         // if element.HasYY() {
         //     print("\n   add(RuleActionPush, position)") 
         // }
+        if tracing {
+            print("\n   trace(TraceSuccess, Rule%v, traceBegin, position)", traced)
+        }
         print("\n   return true")
         if labels[ko] {
             printLabel(ko)
-            printRestore(ko)
+            if tracing {
+                print("\n   trace(TraceFail, Rule%v, traceBegin, position)", traced)
+            }
+            print("\n   position, tokenIndex, depth = position%d, tokenIndex%d, depth%d", ko, ko, ko)
+            if t.HasPredicate {
+                print("\n   rollback(undos%d)", ko)
+            }
+            if len(captures) > 0 {
+                print("\n   captures = captures%d", ko)
+            }
             print("\n   return false")
         }
         print("\n  },")
     }
     print("\n }\n p.rules = rules")
     print("\n}\n")
-    print(t.Trailer)
+    for i, class := range classes {
+        print("\nvar class%d = []rune{%v}\n", i, class)
+    }
+    print("%s", t.Trailer)
     print("\n\n")
+    return
 }
@@ -0,0 +1,613 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const lineGrammar = `package main
+
+YYSTYPE int
+
+type G Peg {
+}
+
+S = < 'a' > {
+	fmt.Printf("%d\n", buffer[begin:end])
+	panic(buffer[begin:end])
+}
+`
+
+const lineMain = `package main
+
+func main() {
+	p := &G{Buffer: "a"}
+	p.Init()
+	if err := p.Parse(); err != nil {
+		panic(err)
+	}
+	p.Execute()
+}
+`
+
+/* Vet and panics in an action point at its lines in the grammar. */
+func TestLineDirectives(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go tool")
+	}
+	dir, err := ioutil.TempDir("", "leg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	grammar := filepath.Join(dir, "g.leg")
+	if err := ioutil.WriteFile(grammar, []byte(lineGrammar), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(lineMain), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := parseGrammar(grammar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Compile(filepath.Join(dir, "g.leg.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(arguments ...string) string {
+		command := exec.Command(goTool, arguments...)
+		command.Dir = dir
+		command.Env = append(os.Environ(), "GO111MODULE=off")
+		output, _ := command.CombinedOutput()
+		return string(output)
+	}
+	if output := run("vet", "."); !strings.Contains(output, "g.leg:9:") {
+		t.Errorf("go vet does not report grammar line 9:\n%v", output)
+	}
+	if output := run("run", "."); !strings.Contains(output, "g.leg:10 ") {
+		t.Errorf("the panic is not reported at grammar line 10:\n%v", output)
+	}
+
+	/* with -o - the directives cannot name the file the parser goes to */
+	if tree, err = parseGrammar(grammar); err != nil {
+		t.Fatal(err)
+	}
+	var generated bytes.Buffer
+	if err := tree.Generate(&generated, "-"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(generated.String(), "//line -") || strings.Contains(generated.String(), "//line stdout") {
+		t.Errorf("the parser written to stdout has directives naming stdout:\n%v", generated.String())
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "g.leg.go"), generated.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if output := run("vet", "."); !strings.Contains(output, "g.leg:9:") {
+		t.Errorf("go vet does not report grammar line 9 of the parser written to stdout:\n%v", output)
+	}
+}
+
+/* The calculator exports to every format, and pointlander peg gets no actions using variables it drops. */
+func TestExport(t *testing.T) {
+	for _, format := range exportFormats {
+		tree, err := parseGrammar("../../grammars/leg/calculator/calculator.leg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var exported bytes.Buffer
+		if err := tree.Export(&exported, format); err != nil {
+			t.Errorf("%v: %v", format, err)
+			continue
+		}
+		for _, rule := range []string{"Stmt", "Sum", "Product", "EOL"} {
+			if !strings.Contains(exported.String(), "\n"+rule+" ") {
+				t.Errorf("%v: rule %v is missing:\n%v", format, rule, exported.String())
+			}
+		}
+		if format != "pointlander-peg" {
+			continue
+		}
+		for _, line := range strings.Split(exported.String(), "\n") {
+			if i := strings.Index(line, "#"); i >= 0 {
+				line = line[:i]
+			}
+			for _, code := range []string{"$$", "l += r", "\"ans: \", e"} {
+				if strings.Contains(line, code) {
+					t.Errorf("%v: action code %q is not commented out: %v", format, code, line)
+				}
+			}
+		}
+	}
+}
+
+/* An alternative of nothing but a lookahead is noted, not written out as an empty alternative. */
+func TestExportLookahead(t *testing.T) {
+	dir, grammar := writeRules(t, "A = 'a' | !'b' { x } | 'c'\n")
+	defer os.RemoveAll(dir)
+	for format, rule := range map[string]string{
+		"ebnf": "*/\nA ::= \"a\" | \"c\"\n",
+		"abnf": "; not expressible: lookahead !'b'\nA = %s\"a\" / %s\"c\"\n",
+	} {
+		tree, err := parseGrammar(grammar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var exported bytes.Buffer
+		if err := tree.Export(&exported, format); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(exported.String(), rule) || !strings.Contains(exported.String(), "not expressible: lookahead !'b'") {
+			t.Errorf("%v: got\n%v\nwant rule\n%v", format, exported.String(), rule)
+		}
+	}
+}
+
+/* A repetition of at least none is covered only once it was both taken and skipped. */
+func TestCoverage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "leg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	grammar := filepath.Join(dir, "g.leg")
+	if err := ioutil.WriteFile(grammar, []byte("package main\nYYSTYPE int\ntype G Peg {\n}\n\nS = 'a'{0,2} 'b'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := parseGrammar(grammar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var repeat Node
+	var find func(n Node)
+	find = func(n Node) {
+		if n.GetType() == TypeRepeat {
+			repeat = n
+		}
+		for _, element := range n.Slice() {
+			find(element)
+		}
+	}
+	rule := tree.Front()
+	for rule.GetType() != TypeRule {
+		rule = rule.Next()
+	}
+	find(rule)
+
+	c := newCoverage(tree)
+	for _, corpus := range []struct {
+		input          string
+		covered, total int
+		class          string
+	}{
+		{"b", 2, 3, "partial"},
+		{"aab", 3, 3, "covered"},
+	} {
+		if _, ok, err := c.run(rule, []rune(corpus.input), 0); err != nil || !ok {
+			t.Fatalf("%q does not parse: %v", corpus.input, err)
+		}
+		if covered, total := c.points(rule); covered != corpus.covered || total != corpus.total {
+			t.Errorf("after %q: %v of %v points covered, want %v of %v", corpus.input, covered, total, corpus.covered, corpus.total)
+		}
+		if class := c.class(repeat); class != corpus.class {
+			t.Errorf("after %q: {0,2} is %v, want %v", corpus.input, class, corpus.class)
+		}
+	}
+}
+
+const acceptMain = `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	for _, input := range os.Args[1:] {
+		p := &G{Buffer: input}
+		p.Init()
+		fmt.Println(p.Parse() == nil)
+	}
+}
+`
+
+/* Write a grammar of the rules for the parser G into a new directory, which the caller removes. */
+func writeRules(t *testing.T, rules string) (dir, grammar string) {
+	dir, err := ioutil.TempDir("", "leg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grammar = filepath.Join(dir, "g.leg")
+	if err := ioutil.WriteFile(grammar, []byte("package main\n\nYYSTYPE int\n\ntype G Peg {\n}\n\n"+rules), 0644); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return dir, grammar
+}
+
+/* A grammar of rules for the parser G, with inputs it should accept or reject. */
+type acceptance struct {
+	rules  string
+	inputs map[string]bool
+}
+
+/* Compile each grammar and run its parser on the inputs. */
+func testAcceptance(t *testing.T, grammars []acceptance) {
+	testAcceptanceWith(t, nil, grammars)
+}
+
+/* Compile each grammar, with the files it imports written next to it by name, and run its parser on the inputs. */
+func testAcceptanceWith(t *testing.T, files map[string]string, grammars []acceptance) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go tool")
+	}
+	for _, g := range grammars {
+		dir, grammar := writeRules(t, g.rules)
+		defer os.RemoveAll(dir)
+		for name, text := range files {
+			os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+			if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(acceptMain), 0644); err != nil {
+			t.Fatal(err)
+		}
+		tree, err := parseGrammar(grammar)
+		if err != nil {
+			t.Errorf("%v: %v", g.rules, err)
+			continue
+		}
+		if err := tree.Compile(filepath.Join(dir, "g.leg.go")); err != nil {
+			t.Errorf("%v: %v", g.rules, err)
+			continue
+		}
+		var inputs []string
+		for input := range g.inputs {
+			inputs = append(inputs, input)
+		}
+		command := exec.Command(goTool, append([]string{"run", "."}, inputs...)...)
+		command.Dir = dir
+		command.Env = append(os.Environ(), "GO111MODULE=off")
+		output, err := command.CombinedOutput()
+		if err != nil {
+			t.Errorf("%v: %v\n%s", g.rules, err, output)
+			continue
+		}
+		for i, result := range strings.Fields(string(output)) {
+			if accepted := result == "true"; accepted != g.inputs[inputs[i]] {
+				t.Errorf("%v: %q accepted %v, want %v", g.rules, inputs[i], accepted, g.inputs[inputs[i]])
+			}
+		}
+	}
+}
+
+/* A name followed directly by a parenthesis is still a name and a group when the rule has no parameters. */
+func TestCallOfPlainRule(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{"A = B(C)* !.\nB = 'b'\nC = 'c'\n", map[string]bool{"b": true, "bcc": true, "bcbc": false, "": false}},
+		{"A = !B(C) . !.\nB = 'b'\nC = 'c'\n", map[string]bool{"cc": true, "bc": false}},
+		{"A = List(B, C) !.\nB = 'b'\nC = 'c'\nList(x, sep) = x(sep x)*\n", map[string]bool{"bcbcb": true, "bcb": true, "bc": false}},
+	})
+}
+
+/* A repetition recurses on its first try even when it may be left out. */
+func TestRepeatLeftRecursion(t *testing.T) {
+	for _, rules := range []string{"A = A{0,3} 'x'\n", "A = (A 'y'){0,2} 'x'\n", "A = A{2} 'x'\n"} {
+		dir, grammar := writeRules(t, rules)
+		defer os.RemoveAll(dir)
+		tree, err := parseGrammar(grammar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree.quiet = true
+		tree.link()
+		tree.check()
+		reported := false
+		for _, d := range tree.Diagnostics {
+			reported = reported || strings.Contains(d.Message, "left recursion")
+		}
+		if !reported {
+			t.Errorf("%v: no left recursion reported in %v", rules, tree.Diagnostics)
+		}
+	}
+}
+
+/* Spaces around the counts of a repetition leave it a repetition, not an action. */
+func TestRepeatSpaces(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{"A = 'a'{ 2, 3 } !.\n", map[string]bool{"a": false, "aa": true, "aaa": true, "aaaa": false}},
+		{"A = 'a'{ 2 } !.\n", map[string]bool{"a": false, "aa": true, "aaa": false}},
+	})
+}
+
+/* Grammars which do not parse or compile, with a part of the error each gives. */
+func testGrammarErrors(t *testing.T, grammars map[string]string) {
+	for rules, message := range grammars {
+		dir, grammar := writeRules(t, rules)
+		defer os.RemoveAll(dir)
+		tree, err := parseGrammar(grammar)
+		if err == nil {
+			err = tree.Compile(filepath.Join(dir, "g.leg.go"))
+		}
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("%v: error %v, want one with %q", rules, err, message)
+		}
+	}
+}
+
+/* Escapes stand for their characters in literals and classes; a double quoted escape keeps its case. */
+func TestEscapes(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{`A = '\x41' 'é' '\u{1F600}' '\U0001F600' '\101' !.` + "\n", map[string]bool{"Aé😀😀A": true, "Aé😀😀": false, "AeAAA": false}},
+		{`A = [\x30-\x39\u{3B1}]+ !.` + "\n", map[string]bool{"09": true, "α1": true, "a": false}},
+		{`A = "\x41b" !.` + "\n", map[string]bool{"AB": true, "Ab": true, "ab": false}},
+	})
+	testGrammarErrors(t, map[string]string{
+		`A = '\u{110000}'` + "\n": "U+110000 is not a Unicode character",
+		`A = '\uD800'` + "\n":     "U+D800 is not a Unicode character",
+		`A = '\q'` + "\n":         `invalid escape \q`,
+	})
+}
+
+/* Unicode properties and the set operations of classes. */
+func TestClassSets(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{"A = [a-z--aeiou]+ !.\n", map[string]bool{"bcd": true, "a": false, "bad": false}},
+		{"A = [a-z&&[c-e]] !.\n", map[string]bool{"d": true, "b": false, "f": false}},
+		{"A = [\\p{L}&&[^\\p{Lu}]]+ !.\n", map[string]bool{"abé": true, "aB": false, "1": false}},
+		{"A = [\\P{L}]+ !.\n", map[string]bool{"1 !": true, "1a": false}},
+		{"A = [\\p{Greek}] !.\n", map[string]bool{"α": true, "a": false}},
+		{"A = [^a-z--x] !.\n", map[string]bool{"x": true, "A": true, "a": false}},
+		{"A = [([{]+ !.\n", map[string]bool{"([{": true, "]": false}},
+	})
+	testGrammarErrors(t, map[string]string{
+		"A = [\\p{Nope}]\n": "unknown Unicode property Nope",
+	})
+}
+
+const declareRules = `%{
+var declared = map[*G]map[string]bool{}
+
+func declare(p *G, name string) func() {
+	if declared[p] == nil {
+		declared[p] = make(map[string]bool)
+	}
+	declared[p][name] = true
+	return func() { delete(declared[p], name) }
+}
+%}
+
+S = (Def | Skip | Use)* !.
+Def = 'def ' < [a-z]+ > &{ undo(declare(p, text(RulePegText))) } ';'
+Skip = 'def ' [a-z]+ '?'
+Use = 'use ' < [a-z]+ > &{ declared[p][text(RulePegText)] } ';'
+`
+
+/* Predicates see the captures while parsing, and their changes are undone on backtracking. */
+func TestPredicates(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{declareRules, map[string]bool{"def x;use x;": true, "use x;": false, "def x?use x;": false, "def x;def y?use x;": true}},
+		{"A = < [0-9]+ > &{ len(text(RulePegText)) == 2 } !.\n", map[string]bool{"12": true, "123": false}},
+	})
+}
+
+/* Back references match the text of a labelled capture again, as it was before any backtracking. */
+func TestBackReferences(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{"H = '<<' delim:<[A-Z]+> '\\n' (!('\\n' =delim) .)* '\\n' =delim !.\n",
+			map[string]bool{"<<EOF\nhi\nEOF": true, "<<EOF\nEO\nEOF": true, "<<EOF\nhi\nEND": false}},
+		{"R = d:<'a'> (d:<[a-z]> 'x' | [a-z] 'y') =d !.\n", map[string]bool{"abya": true, "abyb": false, "abxb": true}},
+		{"R = =d d:<'a'> =d !.\n", map[string]bool{"aa": true, "a": false}},
+	})
+	testGrammarErrors(t, map[string]string{
+		"A = 'a' (=x)\n": "undefined capture 'x'",
+	})
+}
+
+/* Counted repetitions match between their bounds. */
+func TestRepeat(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{"A = [0-9]{2,3} !.\n", map[string]bool{"1": false, "12": true, "123": true, "1234": false}},
+		{"A = [0-9]{4} '-' [0-9]{2} !.\n", map[string]bool{"2024-01": true, "202-01": false, "2024-1": false}},
+		{"A = 'a'{2,} !.\n", map[string]bool{"a": false, "aa": true, "aaaaa": true}},
+		{"A = ('a' 'b'?){0,2} 'c' !.\n", map[string]bool{"c": true, "abac": true, "aaac": false}},
+		{"A = 'a'{0} 'b' !.\n", map[string]bool{"b": true, "ab": false}},
+	})
+	testGrammarErrors(t, map[string]string{
+		"A = 'a'{3,1}\n":                  "invalid repetition {3,1}: 1 is less than 3",
+		"A = 'a'{99999999999999999999}\n": "is too large",
+	})
+}
+
+const listRules = `Numbers = List(Number, Comma) ';' Words !.
+Words = List([a-z]+, ' ')?
+Number = [0-9]+
+Comma = ','
+List(x, sep) = x (sep x)*
+`
+
+/* Each call of a rule with parameters becomes a rule of its own, named after its arguments when it can be. */
+func TestParameters(t *testing.T) {
+	testAcceptance(t, []acceptance{
+		{listRules, map[string]bool{"1,2,3;": true, "1;ab cd": true, "1,;": false, ";": false, "1;ab,cd": false}},
+	})
+	dir, grammar := writeRules(t, listRules)
+	defer os.RemoveAll(dir)
+	tree, err := parseGrammar(grammar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Compile(filepath.Join(dir, "g.leg.go")); err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, name := range tree.RuleNames {
+		names[name.String()] = true
+	}
+	for name, want := range map[string]bool{"List_Number_Comma": true, "List_2": true, "List": false} {
+		if names[name] != want {
+			t.Errorf("rule %v is in RuleNames: %v, want %v", name, names[name], want)
+		}
+	}
+
+	testGrammarErrors(t, map[string]string{
+		"A = List('a')\nList(x, sep) = x (sep x)*\n": "rule 'List' takes 2 arguments, not 1",
+		"A = B('a', 'b')\nB = 'b'\n":                 "rule 'B' has no parameters",
+		"A = R('a')\nR(x) = x R(x x)?\n":             "calls itself with other arguments",
+	})
+}
+
+const lexicalRules = `Number = [0-9]+
+Space = ' '*
+`
+
+/* Imported rules are merged in, renamed after the prefix of their import. */
+func TestImports(t *testing.T) {
+	testAcceptanceWith(t, map[string]string{"lexical.leg": lexicalRules, "lib/lexical.leg": lexicalRules}, []acceptance{
+		{"%import \"lexical.leg\"\nA = Number Space Number !.\n", map[string]bool{"1 2": true, "1  2": true, "12": false}},
+		{"%import lex \"lib/lexical.leg\"\nA = lex_Number (',' lex_Number)* !.\n", map[string]bool{"1,2": true, "1,": false}},
+	})
+	testGrammarErrors(t, map[string]string{
+		"%import \"missing.leg\"\nA = 'a'\n": "missing.leg",
+	})
+}
+
+const dateABNF = `date = year "-" month [ "T" hour ]
+year = 4DIGIT
+month = 2DIGIT
+hour = 1*2DIGIT
+keyword = "if" / %s"Then" / %x41-43 %d33.33
+`
+
+/* An imported ABNF grammar accepts what the ABNF does, case insensitive strings included. */
+func TestImportABNF(t *testing.T) {
+	var grammar bytes.Buffer
+	if _, err := ImportABNF(&grammar, []byte(dateABNF), "date.abnf", "main", "G"); err != nil {
+		t.Fatal(err)
+	}
+	rules := grammar.String()
+	rules = rules[strings.Index(rules, "type G Peg {\n}\n")+len("type G Peg {\n}\n"):]
+	testAcceptance(t, []acceptance{
+		{"S = date !.\n" + rules, map[string]bool{"2024-01": true, "2024-01t7": true, "2024-01T12": true, "2024-1": false, "2024-01T123": false}},
+		{"S = keyword !.\n" + rules, map[string]bool{"IF": true, "iF": true, "Then": true, "then": false, "B!!": true, "D!!": false}},
+	})
+}
+
+/* The harness is named after the parser, whichever of leg and peg wrote it. */
+func TestHarnessFile(t *testing.T) {
+	for file, harness := range map[string]string{
+		"grammar.leg.go":     "grammar_leg_test.go",
+		"grammar.peg.go":     "grammar_peg_test.go",
+		"dir/parser.go":      "dir/parser_test.go",
+		"bootstrap.peg.go":   "bootstrap_peg_test.go",
+		"grammar.leg.gen.go": "grammar.leg.gen_test.go",
+	} {
+		if name := HarnessFile(file); name != harness {
+			t.Errorf("%v: got harness %v, want %v", file, name, harness)
+		}
+	}
+}
+
+/* Each rule is drawn as well formed SVG, with its parts in boxes and rule names linked to their rules. */
+func TestDiagrams(t *testing.T) {
+	for rules, parts := range map[string][]string{
+		"A = B 'x' | C\nB = [a-z]\n":  {`id="rule-A"`, `id="rule-B"`, `href="#rule-B"`, `<title>rule not defined</title>`, `>C</text>`, `>&#39;x&#39;</text>`},
+		"A = 'a'{2,3} 'b'* !'c'\n":    {"2 to 3 times", "not followed by"},
+		"A = d:<'a'+> =d &{ true }\n": {"capture d", ">=d</text>", "<title> true </title>"},
+	} {
+		dir, grammar := writeRules(t, rules)
+		defer os.RemoveAll(dir)
+		tree, err := parseGrammar(grammar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var page bytes.Buffer
+		if err := tree.WriteDiagrams(&page, "g.leg"); err != nil {
+			t.Fatal(err)
+		}
+		for _, part := range parts {
+			if !strings.Contains(page.String(), part) {
+				t.Errorf("%v: the diagrams have no %v:\n%v", rules, part, page.String())
+			}
+		}
+		for _, svg := range strings.Split(page.String(), "<svg ")[1:] {
+			svg = "<svg " + svg[:strings.Index(svg, "</svg>")+len("</svg>")]
+			decoder := xml.NewDecoder(strings.NewReader(svg))
+			for {
+				if _, err := decoder.Token(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Errorf("%v: the diagram is not well formed: %v\n%v", rules, err, svg)
+					break
+				}
+			}
+		}
+	}
+}
+
+/* The language server answers framed JSON-RPC requests about the grammars it has open. */
+func TestLanguageServer(t *testing.T) {
+	grammar := "package main\n\nYYSTYPE int\n\ntype G Peg {\n}\n\nA = B 'x' C\nB = [\\p{Nope}]\n"
+	var in, out bytes.Buffer
+	for _, request := range []string{
+		`{"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": {"textDocument": {"uri": "file:///g.leg", "text": ` + strconv.Quote(grammar) + `}}}`,
+		`{"jsonrpc": "2.0", "id": 1, "method": "textDocument/definition", "params": {"textDocument": {"uri": "file:///g.leg"}, "position": {"line": 7, "character": 4}}}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "textDocument/rename", "params": {"textDocument": {"uri": "file:///g.leg"}, "position": {"line": 7, "character": 4}, "newName": "9"}}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "workspace/symbol", "params": {}}`,
+		`{"jsonrpc": "2.0", "method": "$/cancelRequest", "params": {"id": 3}}`,
+		`{"jsonrpc": "2.0", "id": 4, "method": "shutdown"}`,
+	} {
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(request), request)
+	}
+	s := &languageServer{in: bufio.NewReader(&in), out: &out, documents: make(map[string]*grammarIndex)}
+	if err := s.serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	/* notifications get no reply, and a reply has either a result or an error */
+	replies := []string{
+		`{"jsonrpc": "2.0", "method": "textDocument/publishDiagnostics", "params": {"uri": "file:///g.leg", "diagnostics": [
+			{"range": {"start": {"line": 8, "character": 5}, "end": {"line": 8, "character": 13}}, "severity": 1, "source": "leg", "message": "unknown Unicode property Nope"},
+			{"range": {"start": {"line": 7, "character": 10}, "end": {"line": 7, "character": 11}}, "severity": 1, "source": "leg", "message": "rule 'C' used but not defined"}]}}`,
+		`{"jsonrpc": "2.0", "id": 1, "result": [{"uri": "file:///g.leg", "range": {"start": {"line": 8, "character": 0}, "end": {"line": 8, "character": 1}}}]}`,
+		`{"jsonrpc": "2.0", "id": 2, "error": {"code": -32602, "message": "'9' is not a valid rule name"}}`,
+		`{"jsonrpc": "2.0", "id": 3, "error": {"code": -32601, "message": "method not found: workspace/symbol"}}`,
+		`{"jsonrpc": "2.0", "id": 4, "result": null}`,
+	}
+	messages := regexp.MustCompile(`Content-Length: [0-9]+\r\n\r\n`).Split(out.String(), -1)[1:]
+	if len(messages) != len(replies) {
+		t.Fatalf("got %v messages, want %v:\n%v", len(messages), len(replies), out.String())
+	}
+	for i, message := range messages {
+		var got, want interface{}
+		if err := json.Unmarshal([]byte(message), &got); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(replies[i]), &want); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v\nwant %v", message, replies[i])
+		}
+	}
+}
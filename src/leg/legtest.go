@@ -0,0 +1,341 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/* A test of a .legtest file: an input, the rule to parse it with and what should happen. */
+type grammarTest struct {
+	name, rule string
+	line       int
+	input      []rune
+	accept     bool
+	at         string
+	tree       *parseNode
+}
+
+/* The tests of a .legtest file and the rules left out of their trees. */
+type grammarTests struct {
+	file   string
+	tests  []*grammarTest
+	ignore map[string]bool
+}
+
+/* Read a .legtest file, whose format is described in the README. */
+func readGrammarTests(file string) (*grammarTests, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tests := &grammarTests{file: file, ignore: make(map[string]bool)}
+	var test *grammarTest
+	rule := ""
+	scanner := bufio.NewScanner(f)
+	line := 0
+	errorf := func(format string, a ...interface{}) error {
+		return fmt.Errorf("%v:%v: %v", file, line, fmt.Sprintf(format, a...))
+	}
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		keyword, rest := text, ""
+		if i := strings.IndexAny(text, " \t"); i >= 0 {
+			keyword, rest = text[:i], strings.TrimSpace(text[i+1:])
+		}
+		if test == nil && keyword != "test" && keyword != "rule" && keyword != "ignore" {
+			return nil, errorf("%v outside of a test", keyword)
+		}
+		switch keyword {
+		case "test":
+			test = &grammarTest{name: rest, rule: rule, line: line, accept: true}
+			if test.name == "" {
+				test.name = fmt.Sprintf("test %v", len(tests.tests)+1)
+			}
+			tests.tests = append(tests.tests, test)
+		case "rule":
+			if test == nil {
+				rule = rest
+			} else {
+				test.rule = rest
+			}
+		case "ignore":
+			for _, name := range strings.Fields(rest) {
+				tests.ignore[strings.Replace(name, "-", "_", -1)] = true
+			}
+		case "input":
+			switch {
+			case strings.HasPrefix(rest, "<<"):
+				end, input := strings.TrimSpace(rest[2:]), ""
+				for {
+					if !scanner.Scan() {
+						return nil, errorf("input ends before %v", end)
+					}
+					line++
+					if scanner.Text() == end {
+						break
+					}
+					input += scanner.Text() + "\n"
+				}
+				test.input = []rune(input)
+			case strings.HasPrefix(rest, "\""):
+				input, err := strconv.Unquote(rest)
+				if err != nil {
+					return nil, errorf("bad input string %v", rest)
+				}
+				test.input = []rune(input)
+			default:
+				test.input = []rune(rest)
+			}
+		case "accept":
+			test.accept = true
+		case "reject":
+			test.accept = false
+			if strings.HasPrefix(rest, "at ") {
+				test.at = strings.TrimSpace(rest[3:])
+			} else if rest != "" {
+				return nil, errorf("expected reject or reject at POSITION")
+			}
+		case "tree":
+			/* the tree goes on over the following lines until its parentheses balance */
+			for strings.Count(rest, "(") > strings.Count(rest, ")") && scanner.Scan() {
+				line++
+				rest += " " + scanner.Text()
+			}
+			tree, err := parseShape(rest)
+			if err != nil {
+				return nil, errorf("%v", err)
+			}
+			test.tree, test.accept = tree, true
+		default:
+			return nil, errorf("unknown keyword %v", keyword)
+		}
+	}
+	return tests, scanner.Err()
+}
+
+/* Parse a tree shape: a rule name, or a parenthesized rule name followed by its children. */
+func parseShape(text string) (*parseNode, error) {
+	tokens := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(text))
+	var parse func() (*parseNode, error)
+	parse = func() (*parseNode, error) {
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("tree ends early")
+		}
+		token := tokens[0]
+		tokens = tokens[1:]
+		switch token {
+		case ")":
+			return nil, fmt.Errorf("unexpected ) in tree")
+		case "(":
+			if len(tokens) == 0 || tokens[0] == "(" || tokens[0] == ")" {
+				return nil, fmt.Errorf("expected a rule name after ( in tree")
+			}
+			n := &parseNode{rule: strings.Replace(tokens[0], "-", "_", -1)}
+			tokens = tokens[1:]
+			for len(tokens) > 0 && tokens[0] != ")" {
+				child, err := parse()
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, child)
+			}
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("missing ) in tree")
+			}
+			tokens = tokens[1:]
+			return n, nil
+		}
+		return &parseNode{rule: strings.Replace(token, "-", "_", -1)}, nil
+	}
+	n, err := parse()
+	if err == nil && len(tokens) > 0 {
+		err = fmt.Errorf("unexpected %v after tree", tokens[0])
+	}
+	return n, err
+}
+
+/* Drop the ignored rules from a tree, lifting their children. */
+func (n *parseNode) prune(ignore map[string]bool) []*parseNode {
+	var children []*parseNode
+	for _, child := range n.children {
+		children = append(children, child.prune(ignore)...)
+	}
+	if ignore[n.rule] {
+		return children
+	}
+	return []*parseNode{{rule: n.rule, begin: n.begin, end: n.end, children: children}}
+}
+
+/* The lines of a tree shape, one rule per line, indented by depth. */
+func (n *parseNode) shape(indent string, lines []string) []string {
+	lines = append(lines, indent+n.rule)
+	for _, child := range n.children {
+		lines = child.shape(indent+"  ", lines)
+	}
+	return lines
+}
+
+/* A line by line diff of expected and actual, through their longest common subsequence. */
+func diffLines(expected, actual []string) []string {
+	common := make([][]int, len(expected)+1)
+	for i := range common {
+		common[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	var diff []string
+	i, j := 0, 0
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case i < len(expected) && j < len(actual) && expected[i] == actual[j]:
+			diff, i, j = append(diff, "  "+expected[i]), i+1, j+1
+		case i < len(expected) && (j == len(actual) || common[i+1][j] >= common[i][j+1]):
+			diff, i = append(diff, "- "+expected[i]), i+1
+		default:
+			diff, j = append(diff, "+ "+actual[j]), j+1
+		}
+	}
+	return diff
+}
+
+/* The line and column of a position in the input. */
+func linePosition(input []rune, position int) string {
+	line, column := 1, 1
+	for _, c := range input[:position] {
+		if c == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return fmt.Sprintf("%v:%v", line, column)
+}
+
+/* Run a test, returning what went wrong, if anything. */
+func (c *coverage) runTest(test *grammarTest, ignore map[string]bool, t *Tree) []string {
+	var rule Node
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule && (test.rule == "" || n.String() == strings.Replace(test.rule, "-", "_", -1)) {
+			rule = n
+			break
+		}
+	}
+	if rule == nil {
+		return []string{fmt.Sprintf("no rule %v", test.rule)}
+	}
+	end, ok, err := c.run(rule, test.input, 0)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	accepted := ok && end == len(test.input)
+	stop := c.farthest
+	if ok && end > stop {
+		stop = end
+	}
+	switch {
+	case test.accept && !accepted && ok:
+		return []string{fmt.Sprintf("expected accept, but %v stopped at %v", rule, linePosition(test.input, end))}
+	case test.accept && !accepted:
+		return []string{fmt.Sprintf("expected accept, but %v rejected the input at %v", rule, linePosition(test.input, stop))}
+	case !test.accept && accepted:
+		return []string{fmt.Sprintf("expected reject, but %v accepted the input", rule)}
+	case !test.accept && test.at != "":
+		if at := linePosition(test.input, stop); test.at != at && test.at != strconv.Itoa(stop) {
+			return []string{fmt.Sprintf("expected reject at %v, but %v rejected the input at %v", test.at, rule, at)}
+		}
+	case test.tree != nil:
+		if len(c.nodes) != 1 {
+			return []string{"no tree"}
+		}
+		actual := c.nodes[0].prune(ignore)
+		var expected, got []string
+		expected = test.tree.shape("", expected)
+		for _, n := range actual {
+			got = n.shape("", got)
+		}
+		if strings.Join(expected, "\n") != strings.Join(got, "\n") {
+			return append([]string{"tree differs (- expected, + actual):"}, diffLines(expected, got)...)
+		}
+	}
+	return nil
+}
+
+func runGrammarTests(w io.Writer, t *Tree, tests *grammarTests, verbose bool) (failed int) {
+	c := newCoverage(t)
+	c.tree = true
+	for _, test := range tests.tests {
+		problems := c.runTest(test, tests.ignore, t)
+		if len(problems) > 0 {
+			failed++
+			fmt.Fprintf(w, "--- FAIL: %v (%v:%v)\n", test.name, tests.file, test.line)
+			for _, problem := range problems {
+				fmt.Fprintf(w, "    %v\n", problem)
+			}
+		} else if verbose {
+			fmt.Fprintf(w, "--- PASS: %v\n", test.name)
+		}
+	}
+	if c.predicates {
+		fmt.Fprintf(w, "predicates cannot be run and were taken to hold\n")
+	}
+	return
+}
+
+func testGrammar(arguments []string) {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	verbose := flags.Bool("v", false, "list the tests which pass too")
+	flags.Parse(arguments)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		log.Fatalf("FILE [TESTS...]: the leg file and its test files, by default the .legtest file beside it")
+	}
+
+	file := flags.Arg(0)
+	t, err := parseGrammar(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	files := flags.Args()[1:]
+	if len(files) == 0 {
+		files = []string{strings.TrimSuffix(file, ".leg") + ".legtest"}
+	}
+	failed, total := 0, 0
+	for _, name := range files {
+		tests, err := readGrammarTests(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		failed += runGrammarTests(os.Stdout, t, tests, *verbose)
+		total += len(tests.tests)
+	}
+	if failed > 0 {
+		fmt.Printf("FAIL: %v of %v tests failed\n", failed, total)
+		os.Exit(1)
+	}
+	fmt.Printf("PASS: %v tests\n", total)
+}
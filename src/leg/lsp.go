@@ -0,0 +1,505 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+/* The language server speaks JSON-RPC over stdio, see
+   https://microsoft.github.io/language-server-protocol/ */
+
+type lspRequest struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+/* A reply has a result, which may be null, or else an error, never both. */
+type lspResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result"`
+}
+
+type lspErrorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   *lspError        `json:"error"`
+}
+
+type lspNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	lspInvalidParams  = -32602
+	lspMethodNotFound = -32601
+	lspRequestFailed  = -32803
+)
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspTextDocumentPosition struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position lspPosition `json:"position"`
+}
+
+/* A span of runes in a grammar file. */
+type span struct {
+	begin, end int
+}
+
+/* Everything the language server knows about one open grammar file. */
+type grammarIndex struct {
+	uri, text   string
+	lines       [][]rune
+	definitions map[string][]span
+	references  map[string][]span
+	rules       map[string]string
+	diagnostics []lspDiagnostic
+}
+
+func newGrammarIndex(uri, text string) *grammarIndex {
+	g := &grammarIndex{uri: uri, text: text,
+		definitions: make(map[string][]span),
+		references:  make(map[string][]span),
+		rules:       make(map[string]string),
+		diagnostics: []lspDiagnostic{}}
+	for _, line := range strings.Split(text, "\n") {
+		g.lines = append(g.lines, []rune(line))
+	}
+	g.analyze()
+	return g
+}
+
+/* Rule names are looked up the way the Tree stores them. */
+func ruleKey(name string) string {
+	return strings.Replace(name, "-", "_", -1)
+}
+
+func (g *grammarIndex) position(offset int) lspPosition {
+	for line, runes := range g.lines {
+		if offset <= len(runes) {
+			return lspPosition{Line: line, Character: len(utf16.Encode(runes[:offset]))}
+		}
+		offset -= len(runes) + 1
+	}
+	last := len(g.lines) - 1
+	return lspPosition{Line: last, Character: len(utf16.Encode(g.lines[last]))}
+}
+
+func (g *grammarIndex) offset(p lspPosition) int {
+	offset := 0
+	for line, runes := range g.lines {
+		if line == p.Line {
+			for i, units := 0, 0; i < len(runes); i++ {
+				if units >= p.Character {
+					return offset + i
+				}
+				units += len(utf16.Encode(runes[i : i+1]))
+			}
+			return offset + len(runes)
+		}
+		offset += len(runes) + 1
+	}
+	return offset
+}
+
+/* The offset of a position the Tree recorded. */
+func (g *grammarIndex) at(p Position) int {
+	return g.offset(lspPosition{Line: p.Line - 1}) + p.Column - 1
+}
+
+func (g *grammarIndex) span(s span) lspRange {
+	return lspRange{Start: g.position(s.begin), End: g.position(s.end)}
+}
+
+func (g *grammarIndex) diagnose(s span, severity int, message string) {
+	g.diagnostics = append(g.diagnostics,
+		lspDiagnostic{Range: g.span(s), Severity: severity, Source: "leg", Message: message})
+}
+
+func (g *grammarIndex) analyze() {
+	p := &Leg{Tree: New(false, false), Buffer: g.text}
+	p.quiet = true
+	p.SetSource(strings.TrimPrefix(g.uri, "file://"), g.text)
+	p.Init()
+	if err := p.Parse(); err != nil {
+		/* the farthest token matched, even if later backtracked over, is where parsing broke down */
+		farthest := 0
+		for token := range p.TokenTree.Tokens() {
+			if end := int(token.end); end > farthest {
+				farthest = end
+			}
+		}
+		g.diagnose(span{farthest, farthest}, 1, "syntax error")
+		return
+	}
+
+	/* tokens are stored in post order, so children come before their parent */
+	var tokens []token32
+	for token := range p.TokenTree.Tokens() {
+		tokens = append(tokens, token)
+	}
+	parents, pending := make([]int, len(tokens)), make(map[int][]int)
+	for i, token := range tokens {
+		depth := int(token.next)
+		for _, child := range pending[depth+1] {
+			parents[child] = i
+		}
+		pending[depth+1], pending[depth] = nil, append(pending[depth], i)
+		parents[i] = -1
+	}
+	buffer := []rune(g.text)
+	for i, token := range tokens {
+		identifier := parents[i]
+		if token.Rule != RulePegText || identifier < 0 || tokens[identifier].Rule != RuleIdentifier && tokens[identifier].Rule != RuleCall || parents[identifier] < 0 {
+			continue
+		}
+		s := span{int(token.begin), int(token.end)}
+		name := ruleKey(string(buffer[s.begin:s.end]))
+		switch tokens[parents[identifier]].Rule {
+		case RuleDefinition:
+			g.definitions[name] = append(g.definitions[name], s)
+		case RulePrimary:
+			if end := int(tokens[identifier].end); end < len(buffer) && buffer[end] == ':' {
+				/* a semantic variable, not a rule */
+				continue
+			}
+			g.references[name] = append(g.references[name], s)
+		}
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			g.diagnose(span{0, 0}, 1, fmt.Sprintf("%v", e))
+		}
+	}()
+	p.Execute()
+	for _, e := range p.Errors {
+		s := span{g.at(e.Position), g.at(e.Position) + 1}
+		if e.End.Line > 0 {
+			s.end = g.at(e.End)
+		}
+		g.diagnose(s, 1, e.Message)
+	}
+	if strings.HasPrefix(g.uri, "file://") {
+		if err := loadImports(p.Tree, strings.TrimPrefix(g.uri, "file://")); err != nil {
+			g.diagnose(span{0, 0}, 1, err.Error())
+		}
+	}
+	if err := p.Tree.Expand(); err != nil {
+		g.diagnose(span{0, 0}, 1, err.Error())
+		return
+	}
+	for _, rule := range p.Slice() {
+		if rule.GetType() == TypeRule {
+			if _, ok := g.rules[rule.String()]; !ok {
+				var form bytes.Buffer
+				printRule(&form, rule)
+				g.rules[rule.String()] = form.String()
+			}
+		}
+	}
+
+	p.link()
+	p.check()
+	reported := make(map[Diagnostic]bool)
+	for _, d := range p.Diagnostics {
+		if reported[d] {
+			continue
+		}
+		reported[d] = true
+		if d.File != "" {
+			/* a problem of an imported rule belongs to its own file */
+			continue
+		}
+		if d.Position.Line > 0 {
+			severity := 1
+			if _, ok := g.definitions[d.Rule]; ok {
+				severity = 2
+			}
+			begin := g.at(d.Position)
+			g.diagnose(span{begin, begin + len([]rune(d.Rule))}, severity, d.Message)
+		} else if spans := g.definitions[d.Rule]; len(spans) > 0 {
+			for _, s := range spans {
+				g.diagnose(s, 2, d.Message)
+			}
+		} else {
+			for _, s := range g.references[d.Rule] {
+				g.diagnose(s, 1, d.Message)
+			}
+		}
+	}
+	for name, spans := range g.definitions {
+		for _, s := range spans[1:] {
+			g.diagnose(s, 1, fmt.Sprintf("rule '%v' redefined", name))
+		}
+	}
+}
+
+/* Find the rule name under the cursor. */
+func (g *grammarIndex) lookup(p lspPosition) (name string, at span, ok bool) {
+	offset := g.offset(p)
+	for _, spans := range []map[string][]span{g.definitions, g.references} {
+		for name, list := range spans {
+			for _, s := range list {
+				if s.begin <= offset && offset <= s.end {
+					return name, s, true
+				}
+			}
+		}
+	}
+	return
+}
+
+func (g *grammarIndex) locations(spans ...[]span) []lspLocation {
+	locations := []lspLocation{}
+	for _, list := range spans {
+		for _, s := range list {
+			locations = append(locations, lspLocation{URI: g.uri, Range: g.span(s)})
+		}
+	}
+	return locations
+}
+
+var ruleName = regexp.MustCompile(`^[-a-zA-Z_][-a-zA-Z_0-9]*$`)
+
+type languageServer struct {
+	in        *bufio.Reader
+	out       io.Writer
+	documents map[string]*grammarIndex
+	shutdown  bool
+}
+
+func (s *languageServer) read() (*lspRequest, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			if length, err = strconv.Atoi(strings.TrimSpace(line[len("content-length:"):])); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+	request := &lspRequest{}
+	return request, json.Unmarshal(body, request)
+}
+
+func (s *languageServer) write(message interface{}) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (s *languageServer) publish(g *grammarIndex) {
+	s.write(&lspNotification{JSONRPC: "2.0", Method: "textDocument/publishDiagnostics",
+		Params: map[string]interface{}{"uri": g.uri, "diagnostics": g.diagnostics}})
+}
+
+func (s *languageServer) handle(request *lspRequest) (result interface{}, e *lspError) {
+	switch request.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1,
+				"definitionProvider": true,
+				"referencesProvider": true,
+				"hoverProvider":      true,
+				"renameProvider":     true,
+			},
+			"serverInfo": map[string]string{"name": "leg"},
+		}, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "exit":
+		if s.shutdown {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	case "textDocument/didOpen":
+		var params struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+		}
+		if json.Unmarshal(request.Params, &params) == nil {
+			g := newGrammarIndex(params.TextDocument.URI, params.TextDocument.Text)
+			s.documents[g.uri] = g
+			s.publish(g)
+		}
+	case "textDocument/didChange":
+		var params struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if json.Unmarshal(request.Params, &params) == nil && len(params.ContentChanges) > 0 {
+			changes := params.ContentChanges
+			g := newGrammarIndex(params.TextDocument.URI, changes[len(changes)-1].Text)
+			s.documents[g.uri] = g
+			s.publish(g)
+		}
+	case "textDocument/didClose":
+		var params lspTextDocumentPosition
+		if json.Unmarshal(request.Params, &params) == nil {
+			uri := params.TextDocument.URI
+			delete(s.documents, uri)
+			s.write(&lspNotification{JSONRPC: "2.0", Method: "textDocument/publishDiagnostics",
+				Params: map[string]interface{}{"uri": uri, "diagnostics": []lspDiagnostic{}}})
+		}
+	case "textDocument/definition", "textDocument/references", "textDocument/hover", "textDocument/rename":
+		var params struct {
+			lspTextDocumentPosition
+			NewName string `json:"newName"`
+			Context struct {
+				IncludeDeclaration bool `json:"includeDeclaration"`
+			} `json:"context"`
+		}
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return nil, &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		g := s.documents[params.TextDocument.URI]
+		if g == nil {
+			return nil, nil
+		}
+		name, at, ok := g.lookup(params.Position)
+		if !ok {
+			return nil, nil
+		}
+		switch request.Method {
+		case "textDocument/definition":
+			return g.locations(g.definitions[name]), nil
+		case "textDocument/references":
+			if params.Context.IncludeDeclaration {
+				return g.locations(g.definitions[name], g.references[name]), nil
+			}
+			return g.locations(g.references[name]), nil
+		case "textDocument/hover":
+			form, ok := g.rules[name]
+			if !ok {
+				return nil, nil
+			}
+			return map[string]interface{}{
+				"contents": map[string]string{"kind": "markdown", "value": "```\n" + form + "\n```"},
+				"range":    g.span(at),
+			}, nil
+		case "textDocument/rename":
+			if !ruleName.MatchString(params.NewName) {
+				return nil, &lspError{Code: lspInvalidParams, Message: fmt.Sprintf("'%v' is not a valid rule name", params.NewName)}
+			}
+			if _, exists := g.definitions[ruleKey(params.NewName)]; exists && ruleKey(params.NewName) != name {
+				return nil, &lspError{Code: lspRequestFailed, Message: fmt.Sprintf("rule '%v' already exists", params.NewName)}
+			}
+			edits := []lspTextEdit{}
+			for _, location := range g.locations(g.definitions[name], g.references[name]) {
+				edits = append(edits, lspTextEdit{Range: location.Range, NewText: params.NewName})
+			}
+			return map[string]interface{}{"changes": map[string][]lspTextEdit{g.uri: edits}}, nil
+		}
+	default:
+		if request.ID != nil {
+			return nil, &lspError{Code: lspMethodNotFound, Message: "method not found: " + request.Method}
+		}
+	}
+	return nil, nil
+}
+
+func (s *languageServer) serve() error {
+	for {
+		request, err := s.read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		result, e := s.handle(request)
+		switch {
+		case request.ID == nil:
+		case e != nil:
+			s.write(&lspErrorResponse{JSONRPC: "2.0", ID: request.ID, Error: e})
+		default:
+			s.write(&lspResponse{JSONRPC: "2.0", ID: request.ID, Result: result})
+		}
+	}
+}
+
+/* leg lsp: serve the language server protocol for leg grammars over stdio */
+func lsp(arguments []string) {
+	flags := flag.NewFlagSet("lsp", flag.ExitOnError)
+	flags.Parse(arguments)
+
+	s := &languageServer{in: bufio.NewReader(os.Stdin), out: os.Stdout, documents: make(map[string]*grammarIndex)}
+	if err := s.serve(); err != nil {
+		log.Fatal(err)
+	}
+}
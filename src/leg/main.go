@@ -5,68 +5,64 @@
 package main
 
 import (
-	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
+	"os"
 	"runtime"
-	"time"
 )
 
-var (
-	inline = flag.Bool("inline", false, "parse rule inlining")
-	_switch = flag.Bool("switch", false, "replace if-else if-else like blocks with switch blocks")
-	syntax = flag.Bool("syntax", false, "print out the syntax tree")
-	highlight = flag.Bool("highlight", false, "test the syntax highlighter")
-	test = flag.Bool("test", false, "test the LEG parser performance")
-	print = flag.Bool("print", false, "directly dump the syntax tree")
-)
-
-func main() {
-	runtime.GOMAXPROCS(2)
-	flag.Parse()
-
-	if flag.NArg() != 1 {
-		flag.Usage()
-		log.Fatalf("FILE: the leg file to compile")
-	}
-	file := flag.Arg(0)
+/* Commands which take over the command line when named as its first argument. */
+var commands = map[string]func(arguments []string){
+	"lsp":         lsp,
+	"diagram":     diagram,
+	"export":      export,
+	"import":      importGrammar,
+	"cover":       cover,
+	"gen-samples": genSamples,
+	"test":        testGrammar,
+}
 
-	buffer, err := ioutil.ReadFile(file)
+/* Parse a grammar file into its Tree, with the rules it imports. */
+func parseGrammar(file string) (*Tree, error) {
+	t, err := parseFile(file)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	if err := loadImports(t, file); err != nil {
+		return nil, err
 	}
+	if err := t.Expand(); err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return t, nil
+}
 
-	if *test {
-		iterations, p := 1000, &Leg{Tree: New(*inline, *_switch), Buffer: string(buffer)}
-		p.Init()
-		start := time.Now()
-		for i := 0; i < iterations; i++ {
-			p.Parse()
-			p.Reset()
+func main() {
+	runtime.GOMAXPROCS(2)
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			command(os.Args[2:])
+			return
 		}
-		total := float64(time.Since(start).Nanoseconds()) / float64(1000)
-		fmt.Printf("time: %v us\n", total / float64(iterations))
-		return
 	}
-
-	p := &Leg{Tree: New(*inline, *_switch), Buffer: string(buffer)}
+	file, buffer := readGrammar("leg")
+	p := &Leg{Tree: New(*inline, *_switch), Buffer: buffer}
+	p.SetSource(file, p.Buffer)
 	p.Init()
 	if err := p.Parse(); err != nil {
 		log.Fatal(err)
 	}
 
 	p.Execute()
-
-	if *print {
-		p.Print()
+	if err := p.Err(); err != nil {
+		log.Fatal(err)
 	}
-	if *syntax {
-		p.PrintSyntaxTree()
+	if err := loadImports(p.Tree, file); err != nil {
+		log.Fatal(err)
 	}
-	if *highlight {
-		p.Highlighter()
+	if err := p.Tree.Expand(); err != nil {
+		log.Fatalf("%v: %v", file, err)
 	}
-	filename := file + ".go"
-	p.Compile(filename)
+
+	generate(p, p.Tree, file)
 }
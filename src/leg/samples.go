@@ -0,0 +1,358 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/* How often a sequence with lookaheads and a whole sample are generated again before giving up. */
+const (
+	sampleRetries  = 8
+	sampleAttempts = 100
+)
+
+/* The characters a dot stands for, printable ASCII with the odd tab and newline. */
+var sampleDot = []rune(" !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~\t\n")
+
+/* Generates random sentences of a grammar by walking its Tree. */
+type sampler struct {
+	rules      map[string]Node
+	cost       map[Node]int
+	random     *rand.Rand
+	maxDepth   int
+	check      *coverage
+	predicates bool
+	captures   map[string][]rune
+}
+
+/* The surrogates are code points but no characters, and do not survive being written out. */
+var surrogates = ranges{{0xD800, 0xDFFF}}
+
+/* A character of one of the ranges, taken at random. */
+func (s *sampler) pick(r ranges) []rune {
+	r = r.difference(surrogates)
+	if len(r) == 0 {
+		return nil
+	}
+	p := r[s.random.Intn(len(r))]
+	return []rune{p[0] + rune(s.random.Intn(int(p[1]-p[0])+1))}
+}
+
+func newSampler(t *Tree, maxDepth int, seed int64) *sampler {
+	s := &sampler{check: newCoverage(t), cost: make(map[Node]int), maxDepth: maxDepth, random: rand.New(rand.NewSource(seed))}
+	s.rules = s.check.rules
+	s.costs(t)
+	return s
+}
+
+/* Work out the fewest nested rules each rule needs to end, going round until nothing changes. */
+func (s *sampler) costs(t *Tree) {
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule {
+			s.cost[n] = math.MaxInt32
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, n := range t.Slice() {
+			if n.GetType() != TypeRule {
+				continue
+			}
+			if cost := s.costOf(n); cost < s.cost[n] {
+				s.cost[n], changed = cost, true
+			}
+		}
+	}
+}
+
+func (s *sampler) costOf(n Node) int {
+	switch n.GetType() {
+	case TypeRule:
+		if cost := s.costOf(n.Front()); cost < math.MaxInt32 {
+			return cost + 1
+		}
+		return math.MaxInt32
+	case TypeName:
+		if rule, ok := s.rules[n.String()]; ok {
+			return s.cost[rule]
+		}
+	case TypeAlternate, TypeUnorderedAlternate:
+		cost := math.MaxInt32
+		for _, element := range n.Slice() {
+			if c := s.costOf(element); c < cost {
+				cost = c
+			}
+		}
+		return cost
+	case TypeSequence:
+		cost := 0
+		for _, element := range n.Slice() {
+			if c := s.costOf(element); c > cost {
+				cost = c
+			}
+		}
+		return cost
+	case TypePlus, TypePush, TypeImplicitPush:
+		return s.costOf(n.Front())
+	case TypeRepeat:
+		if min, _ := repeatBounds(n.String()); min > 0 {
+			return s.costOf(n.Front())
+		}
+	}
+	return 0
+}
+
+/* Pick an alternative, weighing those which fit in the remaining depth by how much room they leave. */
+func (s *sampler) choose(alternatives []*node, depth int) Node {
+	remaining, total := s.maxDepth-depth, 0
+	weights := make([]int, len(alternatives))
+	for i, alternative := range alternatives {
+		if cost := s.costOf(alternative); cost <= remaining {
+			weights[i] = remaining - cost + 1
+			total += weights[i]
+		}
+	}
+	if total == 0 {
+		/* nothing fits, so take the way out that needs the fewest rules */
+		best := alternatives[0]
+		for _, alternative := range alternatives[1:] {
+			if s.costOf(alternative) < s.costOf(best) {
+				best = alternative
+			}
+		}
+		return best
+	}
+	pick := s.random.Intn(total)
+	for i, weight := range weights {
+		if pick < weight {
+			return alternatives[i]
+		}
+		pick -= weight
+	}
+	return alternatives[len(alternatives)-1]
+}
+
+/* The number of times to repeat a loop: geometric while there is depth left, else none. */
+func (s *sampler) repeat(n Node, depth int) int {
+	if depth+s.costOf(n.Front()) > s.maxDepth {
+		return 0
+	}
+	count := 0
+	for count < 8 && s.random.Intn(2) == 0 {
+		count++
+	}
+	return count
+}
+
+func (s *sampler) generate(n Node, depth int) []rune {
+	switch n.GetType() {
+	case TypeRule:
+		/* back references only see the captures of their own rule */
+		captures := s.captures
+		s.captures = make(map[string][]rune)
+		out := s.generate(n.Front(), depth+1)
+		s.captures = captures
+		return out
+	case TypeName:
+		if rule, ok := s.rules[n.String()]; ok {
+			return s.generate(rule, depth)
+		}
+	case TypeDot:
+		return []rune{sampleDot[s.random.Intn(len(sampleDot))]}
+	case TypeCharacter, TypeString:
+		return []rune(n.String())
+	case TypeRange:
+		lower, _ := character(n.Front())
+		upper, _ := character(n.Front().Next())
+		if upper < lower {
+			return nil
+		}
+		return s.pick(ranges{{lower, upper}})
+	case TypeClass:
+		r, _ := classRanges(n)
+		if len(r) == 0 {
+			return nil
+		}
+		/* a complement holds every code point, so keep to the characters of a dot where it can */
+		var dot []rune
+		for _, c := range sampleDot {
+			if r.contains(c) {
+				dot = append(dot, c)
+			}
+		}
+		if len(dot) > 0 && r.contains(unicode.MaxRune) {
+			return []rune{dot[s.random.Intn(len(dot))]}
+		}
+		return s.pick(r)
+	case TypePredicate:
+		s.predicates = true
+	case TypeAlternate, TypeUnorderedAlternate:
+		return s.generate(s.choose(n.Slice(), depth), depth)
+	case TypeSequence:
+		return s.sequence(n.Slice(), depth)
+	case TypeQuery:
+		if s.repeat(n, depth) > 0 {
+			return s.generate(n.Front(), depth)
+		}
+	case TypeStar, TypePlus:
+		count := s.repeat(n, depth)
+		if n.GetType() == TypePlus {
+			count++
+		}
+		var out []rune
+		for i := 0; i < count; i++ {
+			out = append(out, s.generate(n.Front(), depth)...)
+		}
+		return out
+	case TypeRepeat:
+		min, max := repeatBounds(n.String())
+		count := min
+		if max != min {
+			count += s.repeat(n, depth)
+		}
+		if max >= 0 && count > max {
+			count = max
+		}
+		var out []rune
+		for i := 0; i < count; i++ {
+			out = append(out, s.generate(n.Front(), depth)...)
+		}
+		return out
+	case TypePush, TypeImplicitPush:
+		out := s.generate(n.Front(), depth)
+		if n.GetType() == TypePush && n.String() != "" {
+			s.captures[n.String()] = out
+		}
+		return out
+	case TypeBackReference:
+		return append([]rune(nil), s.captures[n.String()]...)
+	}
+	return nil
+}
+
+/* Generate a sequence, trying again while one of its lookaheads does not hold for what follows it. */
+func (s *sampler) sequence(elements []*node, depth int) []rune {
+	var out []rune
+	for retry := 0; retry < sampleRetries; retry++ {
+		var lookaheads []int
+		out = nil
+		for _, element := range elements {
+			lookaheads = append(lookaheads, len(out))
+			out = append(out, s.generate(element, depth)...)
+		}
+		holds := true
+		for i, element := range elements {
+			switch element.GetType() {
+			case TypePeekFor, TypePeekNot:
+				_, ok, err := s.check.run(element.Front(), out, lookaheads[i])
+				if err != nil || ok != (element.GetType() == TypePeekFor) {
+					holds = false
+				}
+			}
+		}
+		if holds {
+			break
+		}
+	}
+	return out
+}
+
+/* A sentence the rule accepts in full, or false when none turned up. */
+func (s *sampler) sample(rule Node) ([]rune, bool) {
+	for attempt := 0; attempt < sampleAttempts; attempt++ {
+		out := s.generate(rule, 0)
+		if end, ok, err := s.check.run(rule, out, 0); err == nil && ok && end == len(out) {
+			return out, true
+		}
+	}
+	return nil, false
+}
+
+func genSamples(arguments []string) {
+	flags := flag.NewFlagSet("gen-samples", flag.ExitOnError)
+	start := flags.String("rule", "", "the rule to generate sentences of, by default the first")
+	count := flags.Int("n", 10, "the number of sentences to generate")
+	maxDepth := flags.Int("maxdepth", 12, "the depth of nested rules beyond which the shortest way out is taken")
+	seed := flags.Int64("seed", 0, "the random seed, by default the time; the seed used is written to stderr")
+	output := flags.String("o", "", "write each sentence to a file of its own in this directory instead of one per line to stdout")
+	quote := flags.Bool("quote", false, "write each sentence to stdout as a Go quoted string, so that one with newlines stays on its line")
+	/* flags may come before or after the grammar */
+	var file string
+	flags.Parse(arguments)
+	if flags.NArg() > 0 {
+		file = flags.Arg(0)
+		flags.Parse(flags.Args()[1:])
+	}
+	if file == "" || flags.NArg() != 0 {
+		flags.Usage()
+		log.Fatalf("FILE: the leg file to generate sentences of")
+	}
+	seeded := false
+	flags.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "seed" })
+	if !seeded {
+		*seed = time.Now().UnixNano()
+	}
+	/* so that the sentences can be generated again */
+	fmt.Fprintf(os.Stderr, "seed %v\n", *seed)
+
+	t, err := parseGrammar(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := newSampler(t, *maxDepth, *seed)
+	var rule Node
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule && (*start == "" || n.String() == strings.Replace(*start, "-", "_", -1)) {
+			rule = n
+			break
+		}
+	}
+	if rule == nil {
+		log.Fatalf("no rule %v in %v", *start, file)
+	} else if s.cost[rule] == math.MaxInt32 {
+		log.Fatalf("rule %v never ends", rule)
+	}
+	if *output != "" {
+		if err := os.MkdirAll(*output, 0755); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	failed := 0
+	for i := 0; i < *count; i++ {
+		sample, ok := s.sample(rule)
+		if !ok {
+			failed++
+			continue
+		}
+		if *output != "" {
+			if err := ioutil.WriteFile(filepath.Join(*output, fmt.Sprintf("sample-%04d", i)), []byte(string(sample)), 0644); err != nil {
+				log.Fatal(err)
+			}
+		} else if *quote {
+			fmt.Println(strconv.Quote(string(sample)))
+		} else {
+			fmt.Println(string(sample))
+		}
+	}
+	if s.predicates {
+		fmt.Fprintf(os.Stderr, "predicates cannot be run and were taken to hold\n")
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%v of %v sentences were not accepted after %v attempts each\n", failed, *count, sampleAttempts)
+	}
+}
@@ -0,0 +1,4554 @@
+// Code generated by leg. DO NOT EDIT.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const END_SYMBOL rune = 4
+
+/* The rule types inferred from the grammar are below. */
+type Rule uint8
+
+const (
+	RuleUnknown Rule = iota
+	RuleGrammar
+	RuleDefinition
+	RuleExpression
+	RuleSequence
+	RulePrefix
+	RuleSuffix
+	RulePrimary
+	RuleIdentifier
+	RuleIdentStart
+	RuleIdentCont
+	RuleLiteral
+	RuleClass
+	RuleClassSet
+	RuleRanges
+	RuleOperands
+	RuleOperand
+	RuleNestedSet
+	RuleDoubleRanges
+	RuleRange
+	RuleDoubleRange
+	RuleChar
+	RuleDoubleChar
+	RuleHex
+	RuleEscape
+	RuleLeftArrow
+	RuleSlash
+	RuleAnd
+	RuleNot
+	RuleQuestion
+	RuleStar
+	RulePlus
+	RuleRepeat
+	RuleOpen
+	RuleClose
+	RuleDot
+	RuleSpacing
+	RuleComment
+	RuleSpace
+	RuleEndOfLine
+	RuleEndOfFile
+	RuleAction
+	RuleBraces
+	RuleBegin
+	RuleEnd
+	RuleAction0
+	RuleAction1
+	RuleAction2
+	RuleAction3
+	RuleAction4
+	RuleAction5
+	RuleAction6
+	RuleAction7
+	RuleAction8
+	RuleAction9
+	RuleAction10
+	RuleAction11
+	RuleAction12
+	RuleAction13
+	RuleAction14
+	RuleAction15
+	RuleAction16
+	RuleAction17
+	RuleAction18
+	RuleAction19
+	RulePegText
+	RuleAction20
+	RuleAction21
+	RuleAction22
+	RuleAction23
+	RuleAction24
+	RuleAction25
+	RuleAction26
+	RuleAction27
+	RuleAction28
+	RuleAction29
+	RuleAction30
+	RuleAction31
+	RuleAction32
+	RuleAction33
+	RuleAction34
+	RuleAction35
+	RuleAction36
+	RuleAction37
+	RuleAction38
+	RuleAction39
+	RuleAction40
+	RuleAction41
+	RuleAction42
+	RuleAction43
+	RuleAction44
+	RuleAction45
+	RuleAction46
+	RuleAction47
+	RuleAction48
+	RuleAction49
+	RuleAction50
+	RuleAction51
+	RuleAction52
+	RuleAction53
+	RuleAction54
+	RuleAction55
+	RuleAction56
+	RuleAction57
+	RuleAction58
+	RuleAction59
+	RuleAction60
+
+	RuleActionPush
+	RuleActionPop
+	RuleActionSet
+	RulePre_
+	Rule_In_
+	Rule_Suf
+)
+
+var Rul3s = [...]string{
+	"Unknown",
+	"Grammar",
+	"Definition",
+	"Expression",
+	"Sequence",
+	"Prefix",
+	"Suffix",
+	"Primary",
+	"Identifier",
+	"IdentStart",
+	"IdentCont",
+	"Literal",
+	"Class",
+	"ClassSet",
+	"Ranges",
+	"Operands",
+	"Operand",
+	"NestedSet",
+	"DoubleRanges",
+	"Range",
+	"DoubleRange",
+	"Char",
+	"DoubleChar",
+	"Hex",
+	"Escape",
+	"LeftArrow",
+	"Slash",
+	"And",
+	"Not",
+	"Question",
+	"Star",
+	"Plus",
+	"Repeat",
+	"Open",
+	"Close",
+	"Dot",
+	"Spacing",
+	"Comment",
+	"Space",
+	"EndOfLine",
+	"EndOfFile",
+	"Action",
+	"Braces",
+	"Begin",
+	"End",
+	"Action0",
+	"Action1",
+	"Action2",
+	"Action3",
+	"Action4",
+	"Action5",
+	"Action6",
+	"Action7",
+	"Action8",
+	"Action9",
+	"Action10",
+	"Action11",
+	"Action12",
+	"Action13",
+	"Action14",
+	"Action15",
+	"Action16",
+	"Action17",
+	"Action18",
+	"Action19",
+	"PegText",
+	"Action20",
+	"Action21",
+	"Action22",
+	"Action23",
+	"Action24",
+	"Action25",
+	"Action26",
+	"Action27",
+	"Action28",
+	"Action29",
+	"Action30",
+	"Action31",
+	"Action32",
+	"Action33",
+	"Action34",
+	"Action35",
+	"Action36",
+	"Action37",
+	"Action38",
+	"Action39",
+	"Action40",
+	"Action41",
+	"Action42",
+	"Action43",
+	"Action44",
+	"Action45",
+	"Action46",
+	"Action47",
+	"Action48",
+	"Action49",
+	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
+	"Action60",
+
+	"RuleActionPush",
+	"RuleActionPop",
+	"RuleActionSet",
+	"Pre_",
+	"_In_",
+	"_Suf",
+}
+
+type TokenTree interface {
+	Print()
+	PrintSyntax()
+	PrintSyntaxTree(buffer string)
+	Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune))
+	ParseTree() *ParseNode
+	Add(rule Rule, begin, end, next, depth int)
+	Expand(index int) TokenTree
+	Tokens() <-chan token32
+	Error() []token32
+	trim(length int)
+	token(index int) token32
+}
+
+/* ${@} bit structure for abstract syntax tree */
+type token16 struct {
+	Rule
+	begin, end, next int16
+}
+
+func (t *token16) isZero() bool {
+	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
+}
+
+/* Semantic variable stack operations are not part of the syntax tree. */
+func (t *token16) isStackOperation() bool {
+	return t.Rule == RuleActionPush || t.Rule == RuleActionPop || t.Rule == RuleActionSet
+}
+
+func (t *token16) isParentOf(u token16) bool {
+	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
+}
+
+func (t *token16) GetToken32() token32 {
+	return token32{Rule: t.Rule, begin: int32(t.begin), end: int32(t.end), next: int32(t.next)}
+}
+
+func (t *token16) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v %v", Rul3s[t.Rule], t.begin, t.end, t.next)
+}
+
+type tokens16 struct {
+	tree    []token16
+	ordered [][]token16
+}
+
+func (t *tokens16) trim(length int) {
+	t.tree = t.tree[0:length]
+}
+
+func (t *tokens16) token(index int) token32 {
+	return t.tree[index].GetToken32()
+}
+
+func (t *tokens16) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens16) Order() [][]token16 {
+	if t.ordered != nil {
+		return t.ordered
+	}
+
+	depths := make([]int16, 1, math.MaxInt16)
+	for i, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			t.tree = t.tree[:i]
+			break
+		}
+		if token.isStackOperation() {
+			continue
+		}
+		depth := int(token.next)
+		if length := len(depths); depth >= length {
+			depths = depths[:depth+1]
+		}
+		depths[depth]++
+	}
+	depths = append(depths, 0)
+
+	ordered, pool := make([][]token16, len(depths)), make([]token16, len(t.tree)+len(depths))
+	for i, depth := range depths {
+		depth++
+		ordered[i], pool, depths[i] = pool[:depth], pool[depth:], 0
+	}
+
+	for i, token := range t.tree {
+		if token.isStackOperation() {
+			continue
+		}
+		depth := token.next
+		token.next = int16(i)
+		ordered[depth][depths[depth]] = token
+		depths[depth]++
+	}
+	t.ordered = ordered
+	return ordered
+}
+
+type State16 struct {
+	token16
+	depths []int16
+	leaf   bool
+}
+
+func (t *tokens16) PreOrder() (<-chan State16, [][]token16) {
+	s, ordered := make(chan State16, 6), t.Order()
+	go func() {
+		var states [8]State16
+		for i, _ := range states {
+			states[i].depths = make([]int16, len(ordered))
+		}
+		depths, state, depth := make([]int16, len(ordered)), 0, 1
+		write := func(t token16, leaf bool) {
+			S := states[state]
+			state, S.Rule, S.begin, S.end, S.next, S.leaf = (state+1)%8, t.Rule, t.begin, t.end, int16(depth), leaf
+			copy(S.depths, depths)
+			s <- S
+		}
+
+		states[state].token16 = ordered[0][0]
+		depths[0]++
+		state++
+		a, b := ordered[depth-1][depths[depth-1]-1], ordered[depth][depths[depth]]
+	depthFirstSearch:
+		for {
+			for {
+				if i := depths[depth]; i > 0 {
+					if c, j := ordered[depth][i-1], depths[depth-1]; a.isParentOf(c) &&
+						(j < 2 || !ordered[depth-1][j-2].isParentOf(c)) {
+						if c.end != b.begin {
+							write(token16{Rule: Rule_In_, begin: c.end, end: b.begin}, true)
+						}
+						break
+					}
+				}
+
+				if a.begin < b.begin {
+					write(token16{Rule: RulePre_, begin: a.begin, end: b.begin}, true)
+				}
+				break
+			}
+
+			next := depth + 1
+			if c := ordered[next][depths[next]]; c.Rule != RuleUnknown && b.isParentOf(c) {
+				write(b, false)
+				depths[depth]++
+				depth, a, b = next, b, c
+				continue
+			}
+
+			write(b, true)
+			depths[depth]++
+			c, parent := ordered[depth][depths[depth]], true
+			for {
+				if c.Rule != RuleUnknown && a.isParentOf(c) {
+					b = c
+					continue depthFirstSearch
+				} else if parent && b.end != a.end {
+					write(token16{Rule: Rule_Suf, begin: b.end, end: a.end}, true)
+				}
+
+				depth--
+				if depth > 0 {
+					a, b, c = ordered[depth-1][depths[depth-1]-1], a, ordered[depth][depths[depth]]
+					parent = a.isParentOf(b)
+					continue
+				}
+
+				break depthFirstSearch
+			}
+		}
+
+		close(s)
+	}()
+	return s, ordered
+}
+
+func (t *tokens16) PrintSyntax() {
+	tokens, ordered := t.PreOrder()
+	max := -1
+	for token := range tokens {
+		if !token.leaf {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[36m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[36m%v\x1B[m\n", Rul3s[token.Rule])
+		} else if token.begin == token.end {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[31m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[31m%v\x1B[m\n", Rul3s[token.Rule])
+		} else {
+			for c, end := token.begin, token.end; c < end; c++ {
+				if i := int(c); max+1 < i {
+					for j := max; j < i; j++ {
+						fmt.Printf("skip %v %v\n", j, token.String())
+					}
+					max = i
+				} else if i := int(c); i <= max {
+					for j := i; j <= max; j++ {
+						fmt.Printf("dupe %v %v\n", j, token.String())
+					}
+				} else {
+					max = int(c)
+				}
+				fmt.Printf("%v", c)
+				for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+					fmt.Printf(" \x1B[34m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+				}
+				fmt.Printf(" \x1B[34m%v\x1B[m\n", Rul3s[token.Rule])
+			}
+			fmt.Printf("\n")
+		}
+	}
+}
+
+func (t *tokens16) PrintSyntaxTree(buffer string) {
+	tokens, _ := t.PreOrder()
+	for token := range tokens {
+		for c := 0; c < int(token.next); c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", Rul3s[token.Rule], strconv.Quote(buffer[token.begin:token.end]))
+	}
+}
+
+/* Split the buffer into runs of text, each with the class of the innermost rule styled. */
+func (t *tokens16) Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune)) {
+	tokens, ordered := t.PreOrder()
+	position, mark, class := 0, 0, ""
+	run := func(c string, end int) {
+		if c != class {
+			if mark < position {
+				write(class, buffer[mark:position])
+			}
+			mark, class = position, c
+		}
+		position = end
+	}
+	for token := range tokens {
+		if !token.leaf {
+			continue
+		}
+		begin, end := int(token.begin), int(token.end)
+		if end > len(buffer) {
+			end = len(buffer)
+		}
+		if begin > position {
+			run("", begin)
+		}
+		if end <= position {
+			continue
+		}
+		c, ok := styles[token.Rule]
+		for i := int(token.next) - 1; !ok && i >= 0; i-- {
+			c, ok = styles[ordered[i][token.depths[i]-1].Rule]
+		}
+		run(c, end)
+	}
+	run("", len(buffer))
+	if mark < position {
+		write(class, buffer[mark:position])
+	}
+}
+
+/* Build the parse tree; tokens are stored in post order, so children come before their parent. */
+func (t *tokens16) ParseTree() *ParseNode {
+	var levels [][]*ParseNode
+	for _, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			break
+		}
+		if token.isStackOperation() {
+			continue
+		}
+		depth := int(token.next)
+		for len(levels) < depth+2 {
+			levels = append(levels, nil)
+		}
+		n := &ParseNode{Rule: token.Rule, Begin: int(token.begin), End: int(token.end), Children: levels[depth+1]}
+		levels[depth+1], levels[depth] = nil, append(levels[depth], n)
+	}
+	if len(levels) == 0 {
+		return &ParseNode{}
+	} else if roots := levels[0]; len(roots) == 1 {
+		return roots[0]
+	} else {
+		return &ParseNode{Begin: roots[0].Begin, End: roots[len(roots)-1].End, Children: roots}
+	}
+}
+
+func (t *tokens16) Add(rule Rule, begin, end, depth, index int) {
+	t.tree[index] = token16{Rule: rule, begin: int16(begin), end: int16(end), next: int16(depth)}
+}
+
+func (t *tokens16) Tokens() <-chan token32 {
+	s := make(chan token32, 16)
+	go func() {
+		for _, v := range t.tree {
+			s <- v.GetToken32()
+		}
+		close(s)
+	}()
+	return s
+}
+
+func (t *tokens16) Error() []token32 {
+	ordered := t.Order()
+	length := len(ordered)
+	tokens, length := make([]token32, length), length-1
+	for i, _ := range tokens {
+		o := ordered[length-i]
+		if len(o) > 1 {
+			tokens[i] = o[len(o)-2].GetToken32()
+		}
+	}
+	return tokens
+}
+
+/* ${@} bit structure for abstract syntax tree */
+type token32 struct {
+	Rule
+	begin, end, next int32
+}
+
+func (t *token32) isZero() bool {
+	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
+}
+
+/* Semantic variable stack operations are not part of the syntax tree. */
+func (t *token32) isStackOperation() bool {
+	return t.Rule == RuleActionPush || t.Rule == RuleActionPop || t.Rule == RuleActionSet
+}
+
+func (t *token32) isParentOf(u token32) bool {
+	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
+}
+
+func (t *token32) GetToken32() token32 {
+	return token32{Rule: t.Rule, begin: int32(t.begin), end: int32(t.end), next: int32(t.next)}
+}
+
+func (t *token32) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v %v", Rul3s[t.Rule], t.begin, t.end, t.next)
+}
+
+type tokens32 struct {
+	tree    []token32
+	ordered [][]token32
+}
+
+func (t *tokens32) trim(length int) {
+	t.tree = t.tree[0:length]
+}
+
+func (t *tokens32) token(index int) token32 {
+	return t.tree[index].GetToken32()
+}
+
+func (t *tokens32) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens32) Order() [][]token32 {
+	if t.ordered != nil {
+		return t.ordered
+	}
+
+	depths := make([]int32, 1, math.MaxInt16)
+	for i, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			t.tree = t.tree[:i]
+			break
+		}
+		if token.isStackOperation() {
+			continue
+		}
+		depth := int(token.next)
+		if length := len(depths); depth >= length {
+			depths = depths[:depth+1]
+		}
+		depths[depth]++
+	}
+	depths = append(depths, 0)
+
+	ordered, pool := make([][]token32, len(depths)), make([]token32, len(t.tree)+len(depths))
+	for i, depth := range depths {
+		depth++
+		ordered[i], pool, depths[i] = pool[:depth], pool[depth:], 0
+	}
+
+	for i, token := range t.tree {
+		if token.isStackOperation() {
+			continue
+		}
+		depth := token.next
+		token.next = int32(i)
+		ordered[depth][depths[depth]] = token
+		depths[depth]++
+	}
+	t.ordered = ordered
+	return ordered
+}
+
+type State32 struct {
+	token32
+	depths []int32
+	leaf   bool
+}
+
+func (t *tokens32) PreOrder() (<-chan State32, [][]token32) {
+	s, ordered := make(chan State32, 6), t.Order()
+	go func() {
+		var states [8]State32
+		for i, _ := range states {
+			states[i].depths = make([]int32, len(ordered))
+		}
+		depths, state, depth := make([]int32, len(ordered)), 0, 1
+		write := func(t token32, leaf bool) {
+			S := states[state]
+			state, S.Rule, S.begin, S.end, S.next, S.leaf = (state+1)%8, t.Rule, t.begin, t.end, int32(depth), leaf
+			copy(S.depths, depths)
+			s <- S
+		}
+
+		states[state].token32 = ordered[0][0]
+		depths[0]++
+		state++
+		a, b := ordered[depth-1][depths[depth-1]-1], ordered[depth][depths[depth]]
+	depthFirstSearch:
+		for {
+			for {
+				if i := depths[depth]; i > 0 {
+					if c, j := ordered[depth][i-1], depths[depth-1]; a.isParentOf(c) &&
+						(j < 2 || !ordered[depth-1][j-2].isParentOf(c)) {
+						if c.end != b.begin {
+							write(token32{Rule: Rule_In_, begin: c.end, end: b.begin}, true)
+						}
+						break
+					}
+				}
+
+				if a.begin < b.begin {
+					write(token32{Rule: RulePre_, begin: a.begin, end: b.begin}, true)
+				}
+				break
+			}
+
+			next := depth + 1
+			if c := ordered[next][depths[next]]; c.Rule != RuleUnknown && b.isParentOf(c) {
+				write(b, false)
+				depths[depth]++
+				depth, a, b = next, b, c
+				continue
+			}
+
+			write(b, true)
+			depths[depth]++
+			c, parent := ordered[depth][depths[depth]], true
+			for {
+				if c.Rule != RuleUnknown && a.isParentOf(c) {
+					b = c
+					continue depthFirstSearch
+				} else if parent && b.end != a.end {
+					write(token32{Rule: Rule_Suf, begin: b.end, end: a.end}, true)
+				}
+
+				depth--
+				if depth > 0 {
+					a, b, c = ordered[depth-1][depths[depth-1]-1], a, ordered[depth][depths[depth]]
+					parent = a.isParentOf(b)
+					continue
+				}
+
+				break depthFirstSearch
+			}
+		}
+
+		close(s)
+	}()
+	return s, ordered
+}
+
+func (t *tokens32) PrintSyntax() {
+	tokens, ordered := t.PreOrder()
+	max := -1
+	for token := range tokens {
+		if !token.leaf {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[36m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[36m%v\x1B[m\n", Rul3s[token.Rule])
+		} else if token.begin == token.end {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[31m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[31m%v\x1B[m\n", Rul3s[token.Rule])
+		} else {
+			for c, end := token.begin, token.end; c < end; c++ {
+				if i := int(c); max+1 < i {
+					for j := max; j < i; j++ {
+						fmt.Printf("skip %v %v\n", j, token.String())
+					}
+					max = i
+				} else if i := int(c); i <= max {
+					for j := i; j <= max; j++ {
+						fmt.Printf("dupe %v %v\n", j, token.String())
+					}
+				} else {
+					max = int(c)
+				}
+				fmt.Printf("%v", c)
+				for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+					fmt.Printf(" \x1B[34m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+				}
+				fmt.Printf(" \x1B[34m%v\x1B[m\n", Rul3s[token.Rule])
+			}
+			fmt.Printf("\n")
+		}
+	}
+}
+
+func (t *tokens32) PrintSyntaxTree(buffer string) {
+	tokens, _ := t.PreOrder()
+	for token := range tokens {
+		for c := 0; c < int(token.next); c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", Rul3s[token.Rule], strconv.Quote(buffer[token.begin:token.end]))
+	}
+}
+
+/* Split the buffer into runs of text, each with the class of the innermost rule styled. */
+func (t *tokens32) Highlight(buffer []rune, styles map[Rule]string, write func(class string, text []rune)) {
+	tokens, ordered := t.PreOrder()
+	position, mark, class := 0, 0, ""
+	run := func(c string, end int) {
+		if c != class {
+			if mark < position {
+				write(class, buffer[mark:position])
+			}
+			mark, class = position, c
+		}
+		position = end
+	}
+	for token := range tokens {
+		if !token.leaf {
+			continue
+		}
+		begin, end := int(token.begin), int(token.end)
+		if end > len(buffer) {
+			end = len(buffer)
+		}
+		if begin > position {
+			run("", begin)
+		}
+		if end <= position {
+			continue
+		}
+		c, ok := styles[token.Rule]
+		for i := int(token.next) - 1; !ok && i >= 0; i-- {
+			c, ok = styles[ordered[i][token.depths[i]-1].Rule]
+		}
+		run(c, end)
+	}
+	run("", len(buffer))
+	if mark < position {
+		write(class, buffer[mark:position])
+	}
+}
+
+/* Build the parse tree; tokens are stored in post order, so children come before their parent. */
+func (t *tokens32) ParseTree() *ParseNode {
+	var levels [][]*ParseNode
+	for _, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			break
+		}
+		if token.isStackOperation() {
+			continue
+		}
+		depth := int(token.next)
+		for len(levels) < depth+2 {
+			levels = append(levels, nil)
+		}
+		n := &ParseNode{Rule: token.Rule, Begin: int(token.begin), End: int(token.end), Children: levels[depth+1]}
+		levels[depth+1], levels[depth] = nil, append(levels[depth], n)
+	}
+	if len(levels) == 0 {
+		return &ParseNode{}
+	} else if roots := levels[0]; len(roots) == 1 {
+		return roots[0]
+	} else {
+		return &ParseNode{Begin: roots[0].Begin, End: roots[len(roots)-1].End, Children: roots}
+	}
+}
+
+func (t *tokens32) Add(rule Rule, begin, end, depth, index int) {
+	t.tree[index] = token32{Rule: rule, begin: int32(begin), end: int32(end), next: int32(depth)}
+}
+
+func (t *tokens32) Tokens() <-chan token32 {
+	s := make(chan token32, 16)
+	go func() {
+		for _, v := range t.tree {
+			s <- v.GetToken32()
+		}
+		close(s)
+	}()
+	return s
+}
+
+func (t *tokens32) Error() []token32 {
+	ordered := t.Order()
+	length := len(ordered)
+	tokens, length := make([]token32, length), length-1
+	for i, _ := range tokens {
+		o := ordered[length-i]
+		if len(o) > 1 {
+			tokens[i] = o[len(o)-2].GetToken32()
+		}
+	}
+	return tokens
+}
+
+func (t *tokens16) Expand(index int) TokenTree {
+	tree := t.tree
+	if index >= len(tree) {
+		expanded := make([]token32, 2*len(tree))
+		for i, v := range tree {
+			expanded[i] = v.GetToken32()
+		}
+		return &tokens32{tree: expanded}
+	}
+	return nil
+}
+
+func (t *tokens32) Expand(index int) TokenTree {
+	tree := t.tree
+	if index >= len(tree) {
+		expanded := make([]token32, 2*len(tree))
+		copy(expanded, tree)
+		t.tree = expanded
+	}
+	return nil
+}
+
+/* A node of the parse tree. */
+type ParseNode struct {
+	Rule
+	Begin, End int
+	Children   []*ParseNode
+}
+
+func (n *ParseNode) text(buffer []rune) string {
+	begin, end := n.Begin, n.End
+	if end > len(buffer) {
+		end = len(buffer)
+	}
+	if begin > end {
+		begin = end
+	}
+	return string(buffer[begin:end])
+}
+
+/* Write the parse tree as JSON objects with rule, begin, end, text and children. */
+func (n *ParseNode) WriteJSON(w io.Writer, buffer string) error {
+	var encode func(n *ParseNode)
+	runes, out := []rune(buffer), &bytes.Buffer{}
+	encode = func(n *ParseNode) {
+		text, _ := json.Marshal(n.text(runes))
+		fmt.Fprintf(out, "{\"rule\":\"%v\",\"begin\":%v,\"end\":%v,\"text\":%s,\"children\":[", Rul3s[n.Rule], n.Begin, n.End, text)
+		for i, child := range n.Children {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			encode(child)
+		}
+		out.WriteString("]}")
+	}
+	encode(n)
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, out.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteString("\n")
+	_, err := indented.WriteTo(w)
+	return err
+}
+
+/* Write the parse tree as S-expressions, with the matched text at the leaves. */
+func (n *ParseNode) WriteSExpression(w io.Writer, buffer string) error {
+	var write func(n *ParseNode, indent string) error
+	runes := []rune(buffer)
+	write = func(n *ParseNode, indent string) error {
+		if len(n.Children) == 0 {
+			_, err := fmt.Fprintf(w, "%v(%v %v %v %v)", indent, Rul3s[n.Rule], n.Begin, n.End, strconv.Quote(n.text(runes)))
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%v(%v %v %v", indent, Rul3s[n.Rule], n.Begin, n.End); err != nil {
+			return err
+		}
+		for _, child := range n.Children {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+			if err := write(child, indent+"  "); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, ")")
+		return err
+	}
+	if err := write(n, ""); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+/* Write the parse tree as a Graphviz DOT digraph. */
+func (n *ParseNode) WriteDOT(w io.Writer, buffer string) error {
+	var write func(n *ParseNode) (int, error)
+	runes, id := []rune(buffer), 0
+	quote := func(s string) string {
+		return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s)
+	}
+	write = func(n *ParseNode) (int, error) {
+		self := id
+		id++
+		if _, err := fmt.Fprintf(w, "  n%v [label=\"%v\\n%v\"];\n", self, quote(Rul3s[n.Rule]), quote(strconv.Quote(n.text(runes)))); err != nil {
+			return self, err
+		}
+		for _, child := range n.Children {
+			c, err := write(child)
+			if err != nil {
+				return self, err
+			}
+			if _, err := fmt.Fprintf(w, "  n%v -> n%v;\n", self, c); err != nil {
+				return self, err
+			}
+		}
+		return self, nil
+	}
+	if _, err := io.WriteString(w, "digraph Peg {\n  node [shape=box];\n"); err != nil {
+		return err
+	}
+	if _, err := write(n); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
+type Peg struct {
+	*Tree
+
+	Buffer string
+	buffer []rune
+	rules  [107]func() bool
+	Parse  func(rule ...int) error
+	Reset  func()
+	TokenTree
+}
+
+type textPosition struct {
+	line, symbol int
+}
+
+type textPositionMap map[int]textPosition
+
+func translatePositions(buffer string, positions []int) textPositionMap {
+	length, translations, j, line, symbol := len(positions), make(textPositionMap, len(positions)), 0, 1, 0
+	sort.Ints(positions)
+
+search:
+	for i, c := range buffer[0:] {
+		if c == '\n' {
+			line, symbol = line+1, 0
+		} else {
+			symbol++
+		}
+		if i == positions[j] {
+			translations[positions[j]] = textPosition{line, symbol}
+			for j++; j < length; j++ {
+				if i != positions[j] {
+					continue search
+				}
+			}
+			break search
+		}
+	}
+
+	return translations
+}
+
+type parseError struct {
+	p *Peg
+}
+
+func (e *parseError) Error() string {
+	tokens, error := e.p.TokenTree.Error(), "\n"
+	positions, p := make([]int, 2*len(tokens)), 0
+	for _, token := range tokens {
+		positions[p], p = int(token.begin), p+1
+		positions[p], p = int(token.end), p+1
+	}
+	translations := translatePositions(e.p.Buffer, positions)
+	for _, token := range tokens {
+		begin, end := int(token.begin), int(token.end)
+		error += fmt.Sprintf("parse error near \x1B[34m%v\x1B[m (line %v symbol %v - line %v symbol %v):\n%v\n",
+			Rul3s[token.Rule],
+			translations[begin].line, translations[begin].symbol,
+			translations[end].line, translations[end].symbol,
+			/*strconv.Quote(*/ e.p.Buffer[begin:end] /*)*/)
+	}
+
+	return error
+}
+
+func (p *Peg) PrintSyntaxTree() {
+	p.TokenTree.PrintSyntaxTree(p.Buffer)
+}
+
+func (p *Peg) WriteJSON(w io.Writer) error {
+	return p.ParseTree().WriteJSON(w, p.Buffer)
+}
+
+func (p *Peg) WriteSExpression(w io.Writer) error {
+	return p.ParseTree().WriteSExpression(w, p.Buffer)
+}
+
+func (p *Peg) WriteDOT(w io.Writer) error {
+	return p.ParseTree().WriteDOT(w, p.Buffer)
+}
+
+func (p *Peg) Highlighter() {
+	p.TokenTree.PrintSyntax()
+}
+
+/* The style classes given to rules with %highlight. */
+var Highlights = map[Rule]string{}
+
+/* The SGR parameters used for common style classes by HighlightANSI. */
+var HighlightColors = map[string]string{
+	"comment":    "32",
+	"string":     "33",
+	"number":     "35",
+	"keyword":    "1;34",
+	"identifier": "36",
+	"operator":   "1",
+	"action":     "2",
+	"error":      "31",
+}
+
+/* Write the buffer with ANSI colors; nil styles and colors default to Highlights and HighlightColors. */
+func (p *Peg) HighlightANSI(w io.Writer, styles map[Rule]string, colors map[string]string) (err error) {
+	if styles == nil {
+		styles = Highlights
+	}
+	if colors == nil {
+		colors = HighlightColors
+	}
+	p.TokenTree.Highlight([]rune(p.Buffer), styles, func(class string, text []rune) {
+		if err != nil {
+			return
+		}
+		if color, ok := colors[class]; ok {
+			_, err = fmt.Fprintf(w, "\x1B[%vm%v\x1B[m", color, string(text))
+		} else {
+			_, err = io.WriteString(w, string(text))
+		}
+	})
+	return
+}
+
+/* Write the buffer as HTML with styled text in spans of its class; nil styles default to Highlights. */
+func (p *Peg) HighlightHTML(w io.Writer, styles map[Rule]string) (err error) {
+	if styles == nil {
+		styles = Highlights
+	}
+	p.TokenTree.Highlight([]rune(p.Buffer), styles, func(class string, text []rune) {
+		if err != nil {
+			return
+		}
+		if class != "" {
+			_, err = fmt.Fprintf(w, "<span class=\"%v\">%v</span>", html.EscapeString(class), html.EscapeString(string(text)))
+		} else {
+			_, err = io.WriteString(w, html.EscapeString(string(text)))
+		}
+	})
+	return
+}
+
+func (p *Peg) Execute() {
+	buffer, begin, end := p.Buffer, 0, 0
+	/* tokens count runes, but begin and end index the bytes of buffer */
+	offset := func(i int) int { return i }
+	if strings.IndexFunc(buffer, func(c rune) bool { return c >= 0x80 }) >= 0 {
+		offsets := make([]int, 0, len(buffer)+1)
+		for i := range buffer {
+			offsets = append(offsets, i)
+		}
+		offsets = append(offsets, len(buffer))
+		offset = func(i int) int { return offsets[i] }
+	}
+
+	for token := range p.TokenTree.Tokens() {
+		switch token.Rule {
+		case RulePegText:
+			begin, end = offset(int(token.begin)), offset(int(token.end))
+		case RuleAction0:
+//line peg.peg:20:61
+			p.AddPackage(buffer[begin:end])
+//line bootstrap.peg.go:1180
+		case RuleAction1:
+//line peg.peg:21:61
+			p.AddLeg(buffer[begin:end])
+//line bootstrap.peg.go:1184
+		case RuleAction2:
+//line peg.peg:22:61
+			p.AddState(buffer[begin:end])
+//line bootstrap.peg.go:1188
+		case RuleAction3:
+//line peg.peg:24:48
+			p.At(begin)
+			p.AddRule(buffer[begin:end])
+//line bootstrap.peg.go:1193
+		case RuleAction4:
+//line peg.peg:25:48
+			p.AddExpression()
+//line bootstrap.peg.go:1197
+		case RuleAction5:
+//line peg.peg:26:48
+			p.AddAlternate()
+//line bootstrap.peg.go:1201
+		case RuleAction6:
+//line peg.peg:27:48
+			p.AddNil()
+			p.AddAlternate()
+//line bootstrap.peg.go:1206
+		case RuleAction7:
+//line peg.peg:29:48
+			p.AddNil()
+//line bootstrap.peg.go:1210
+		case RuleAction8:
+//line peg.peg:30:48
+			p.AddSequence()
+//line bootstrap.peg.go:1214
+		case RuleAction9:
+//line peg.peg:32:48
+			p.At(begin)
+			p.AddPredicate(buffer[begin:end])
+//line bootstrap.peg.go:1219
+		case RuleAction10:
+//line peg.peg:33:48
+			p.AddPeekFor()
+//line bootstrap.peg.go:1223
+		case RuleAction11:
+//line peg.peg:34:48
+			p.AddPeekNot()
+//line bootstrap.peg.go:1227
+		case RuleAction12:
+//line peg.peg:36:48
+			p.AddQuery()
+//line bootstrap.peg.go:1231
+		case RuleAction13:
+//line peg.peg:37:48
+			p.AddStar()
+//line bootstrap.peg.go:1235
+		case RuleAction14:
+//line peg.peg:38:48
+			p.AddPlus()
+//line bootstrap.peg.go:1239
+		case RuleAction15:
+//line peg.peg:39:48
+			p.Span(begin-1, end+1)
+			p.AddRepeat(buffer[begin:end])
+//line bootstrap.peg.go:1244
+		case RuleAction16:
+//line peg.peg:41:48
+			p.At(begin)
+			p.AddName(buffer[begin:end])
+//line bootstrap.peg.go:1249
+		case RuleAction17:
+//line peg.peg:45:48
+			p.AddDot()
+//line bootstrap.peg.go:1253
+		case RuleAction18:
+//line peg.peg:46:48
+			p.At(begin)
+			p.AddAction(buffer[begin:end])
+//line bootstrap.peg.go:1258
+		case RuleAction19:
+//line peg.peg:47:48
+			p.AddPush()
+//line bootstrap.peg.go:1262
+		case RuleAction20:
+//line peg.peg:53:62
+			p.AddSequence()
+//line bootstrap.peg.go:1266
+		case RuleAction21:
+//line peg.peg:55:62
+			p.AddSequence()
+//line bootstrap.peg.go:1270
+		case RuleAction22:
+//line peg.peg:57:63
+			p.AddPeekNot()
+			p.AddDot()
+			p.AddSequence()
+//line bootstrap.peg.go:1276
+		case RuleAction23:
+//line peg.peg:60:61
+			p.AddClassComplement()
+//line bootstrap.peg.go:1280
+		case RuleAction24:
+//line peg.peg:62:40
+			p.AddClassText(buffer[begin:end])
+//line bootstrap.peg.go:1284
+		case RuleAction25:
+//line peg.peg:64:44
+			p.AddClassDifference()
+//line bootstrap.peg.go:1288
+		case RuleAction26:
+//line peg.peg:65:43
+			p.AddClassIntersection()
+//line bootstrap.peg.go:1292
+		case RuleAction27:
+//line peg.peg:67:55
+			p.AddAlternate()
+//line bootstrap.peg.go:1296
+		case RuleAction28:
+//line peg.peg:69:59
+			p.AddAlternate()
+//line bootstrap.peg.go:1300
+		case RuleAction29:
+//line peg.peg:71:55
+			p.AddClassComplement()
+//line bootstrap.peg.go:1304
+		case RuleAction30:
+//line peg.peg:74:46
+			p.AddClassDifference()
+//line bootstrap.peg.go:1308
+		case RuleAction31:
+//line peg.peg:75:45
+			p.AddClassIntersection()
+//line bootstrap.peg.go:1312
+		case RuleAction32:
+//line peg.peg:77:57
+			p.AddAlternate()
+//line bootstrap.peg.go:1316
+		case RuleAction33:
+//line peg.peg:79:46
+			p.Span(begin-3, end+1)
+			p.AddProperty(buffer[begin:end])
+//line bootstrap.peg.go:1321
+		case RuleAction34:
+//line peg.peg:80:46
+			p.Span(begin-3, end+1)
+			p.AddProperty(buffer[begin:end])
+			p.AddClassComplement()
+//line bootstrap.peg.go:1327
+		case RuleAction35:
+//line peg.peg:81:46
+			p.AddRange()
+//line bootstrap.peg.go:1331
+		case RuleAction36:
+//line peg.peg:83:46
+			p.AddDoubleRange()
+//line bootstrap.peg.go:1335
+		case RuleAction37:
+//line peg.peg:86:46
+			p.At(begin)
+			p.AddCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1340
+		case RuleAction38:
+//line peg.peg:88:46
+			p.At(begin)
+			p.AddDoubleCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1345
+		case RuleAction39:
+//line peg.peg:89:46
+			p.At(begin)
+			p.AddCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1350
+		case RuleAction40:
+//line peg.peg:91:46
+			p.AddCharacter("\a")
+//line bootstrap.peg.go:1354
+		case RuleAction41:
+//line peg.peg:92:46
+			p.AddCharacter("\b")
+//line bootstrap.peg.go:1358
+		case RuleAction42:
+//line peg.peg:93:46
+			p.AddCharacter("\x1B")
+//line bootstrap.peg.go:1362
+		case RuleAction43:
+//line peg.peg:94:46
+			p.AddCharacter("\f")
+//line bootstrap.peg.go:1366
+		case RuleAction44:
+//line peg.peg:95:46
+			p.AddCharacter("\n")
+//line bootstrap.peg.go:1370
+		case RuleAction45:
+//line peg.peg:96:46
+			p.AddCharacter("\r")
+//line bootstrap.peg.go:1374
+		case RuleAction46:
+//line peg.peg:97:46
+			p.AddCharacter("\t")
+//line bootstrap.peg.go:1378
+		case RuleAction47:
+//line peg.peg:98:46
+			p.AddCharacter("\v")
+//line bootstrap.peg.go:1382
+		case RuleAction48:
+//line peg.peg:99:46
+			p.AddCharacter("'")
+//line bootstrap.peg.go:1386
+		case RuleAction49:
+//line peg.peg:100:46
+			p.AddCharacter("\"")
+//line bootstrap.peg.go:1390
+		case RuleAction50:
+//line peg.peg:101:46
+			p.AddCharacter("[")
+//line bootstrap.peg.go:1394
+		case RuleAction51:
+//line peg.peg:102:46
+			p.AddCharacter("]")
+//line bootstrap.peg.go:1398
+		case RuleAction52:
+//line peg.peg:103:46
+			p.AddCharacter("-")
+//line bootstrap.peg.go:1402
+		case RuleAction53:
+//line peg.peg:104:47
+			p.Span(begin-2, end)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1407
+		case RuleAction54:
+//line peg.peg:105:46
+			p.Span(begin-3, end+1)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1412
+		case RuleAction55:
+//line peg.peg:106:47
+			p.Span(begin-2, end)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1417
+		case RuleAction56:
+//line peg.peg:108:46
+			p.Span(begin-2, end)
+			p.AddHexCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1422
+		case RuleAction57:
+//line peg.peg:109:46
+			p.Span(begin-1, end)
+			p.AddOctalCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1427
+		case RuleAction58:
+//line peg.peg:110:46
+			p.Span(begin-1, end)
+			p.AddOctalCharacter(buffer[begin:end])
+//line bootstrap.peg.go:1432
+		case RuleAction59:
+//line peg.peg:111:46
+			p.AddCharacter("\\")
+//line bootstrap.peg.go:1436
+		case RuleAction60:
+//line peg.peg:112:45
+			p.Span(begin-1, end)
+			p.AddInvalidEscape(buffer[begin:end])
+//line bootstrap.peg.go:1441
+
+		}
+	}
+}
+
+func (p *Peg) Init() {
+	p.buffer = []rune(p.Buffer)
+	if len(p.buffer) == 0 || p.buffer[len(p.buffer)-1] != END_SYMBOL {
+		p.buffer = append(p.buffer, END_SYMBOL)
+	}
+
+	var tree TokenTree = &tokens16{tree: make([]token16, math.MaxInt16)}
+	position, depth, tokenIndex, buffer, rules := 0, 0, 0, p.buffer, p.rules
+
+	p.Parse = func(rule ...int) error {
+		r := 1
+		if len(rule) > 0 {
+			r = rule[0]
+		}
+		matches := p.rules[r]()
+		p.TokenTree = tree
+		if matches {
+			p.TokenTree.trim(tokenIndex)
+			return nil
+		}
+		return &parseError{p}
+	}
+
+	p.Reset = func() {
+		position, tokenIndex, depth = 0, 0, 0
+	}
+
+	add := func(rule Rule, begin int) {
+		if t := tree.Expand(tokenIndex); t != nil {
+			tree = t
+		}
+		tree.Add(rule, begin, position, depth, tokenIndex)
+		tokenIndex++
+	}
+
+	matchDot := func() bool {
+		if buffer[position] != END_SYMBOL {
+			position++
+			return true
+		}
+		return false
+	}
+
+	/*matchChar := func(c byte) bool {
+	    if buffer[position] == c {
+	        position++
+	        return true
+	    }
+	    return false
+	}*/
+
+	/*matchRange := func(lower byte, upper byte) bool {
+	    if c := buffer[position]; c >= lower && c <= upper {
+	        position++
+	        return true
+	    }
+	    return false
+	}*/
+
+	rules = [...]func() bool{
+		nil,
+		/* 0 Grammar <- <(Spacing ('p' 'a' 'c' 'k' 'a' 'g' 'e') Spacing Identifier Action0 ('t' 'y' 'p' 'e') Spacing Identifier Action1 ('P' 'e' 'g') Spacing Action Action2 Definition+ EndOfFile)> (peg.peg:20) */
+		func() bool {
+			position0, tokenIndex0, depth0 := position, tokenIndex, depth
+			{
+
+				position1 := position
+				depth++
+				if !rules[RuleSpacing]() {
+					goto l0
+				}
+				if buffer[position] != rune('p') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('a') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('c') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('k') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('a') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('g') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('e') {
+					goto l0
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l0
+				}
+				if !rules[RuleIdentifier]() {
+					goto l0
+				}
+				{
+
+					add(RuleAction0, position)
+				}
+				if buffer[position] != rune('t') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('y') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('p') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('e') {
+					goto l0
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l0
+				}
+				if !rules[RuleIdentifier]() {
+					goto l0
+				}
+				{
+
+					add(RuleAction1, position)
+				}
+				if buffer[position] != rune('P') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('e') {
+					goto l0
+				}
+				position++
+				if buffer[position] != rune('g') {
+					goto l0
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l0
+				}
+				if !rules[RuleAction]() {
+					goto l0
+				}
+				{
+
+					add(RuleAction2, position)
+				}
+				{
+
+					position7 := position
+					depth++
+					if !rules[RuleIdentifier]() {
+						goto l0
+					}
+					{
+
+						add(RuleAction3, position)
+					}
+					if !rules[RuleLeftArrow]() {
+						goto l0
+					}
+					if !rules[RuleExpression]() {
+						goto l0
+					}
+					{
+
+						add(RuleAction4, position)
+					}
+					{
+
+						position10, tokenIndex10, depth10 := position, tokenIndex, depth
+						{
+
+							position11, tokenIndex11, depth11 := position, tokenIndex, depth
+							if !rules[RuleIdentifier]() {
+								goto l12
+							}
+							if !rules[RuleLeftArrow]() {
+								goto l12
+							}
+							goto l11
+						l12:
+							position, tokenIndex, depth = position11, tokenIndex11, depth11
+							{
+
+								position13, tokenIndex13, depth13 := position, tokenIndex, depth
+								if !matchDot() {
+									goto l13
+								}
+								goto l0
+							l13:
+								position, tokenIndex, depth = position13, tokenIndex13, depth13
+							}
+						}
+					l11:
+						position, tokenIndex, depth = position10, tokenIndex10, depth10
+					}
+					depth--
+					add(RuleDefinition, position7)
+				}
+			l5:
+				{
+
+					position6, tokenIndex6, depth6 := position, tokenIndex, depth
+					{
+
+						position14 := position
+						depth++
+						if !rules[RuleIdentifier]() {
+							goto l6
+						}
+						{
+
+							add(RuleAction3, position)
+						}
+						if !rules[RuleLeftArrow]() {
+							goto l6
+						}
+						if !rules[RuleExpression]() {
+							goto l6
+						}
+						{
+
+							add(RuleAction4, position)
+						}
+						{
+
+							position17, tokenIndex17, depth17 := position, tokenIndex, depth
+							{
+
+								position18, tokenIndex18, depth18 := position, tokenIndex, depth
+								if !rules[RuleIdentifier]() {
+									goto l19
+								}
+								if !rules[RuleLeftArrow]() {
+									goto l19
+								}
+								goto l18
+							l19:
+								position, tokenIndex, depth = position18, tokenIndex18, depth18
+								{
+
+									position20, tokenIndex20, depth20 := position, tokenIndex, depth
+									if !matchDot() {
+										goto l20
+									}
+									goto l6
+								l20:
+									position, tokenIndex, depth = position20, tokenIndex20, depth20
+								}
+							}
+						l18:
+							position, tokenIndex, depth = position17, tokenIndex17, depth17
+						}
+						depth--
+						add(RuleDefinition, position14)
+					}
+					goto l5
+				l6:
+					position, tokenIndex, depth = position6, tokenIndex6, depth6
+				}
+				{
+
+					position21 := position
+					depth++
+					{
+
+						position22, tokenIndex22, depth22 := position, tokenIndex, depth
+						if !matchDot() {
+							goto l22
+						}
+						goto l0
+					l22:
+						position, tokenIndex, depth = position22, tokenIndex22, depth22
+					}
+					depth--
+					add(RuleEndOfFile, position21)
+				}
+				depth--
+				add(RuleGrammar, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex, depth = position0, tokenIndex0, depth0
+			return false
+		},
+		/* 1 Definition <- <(Identifier Action3 LeftArrow Expression Action4 &((Identifier LeftArrow) / !.))> (peg.peg:24) */
+		nil,
+		/* 2 Expression <- <((Sequence (Slash Sequence Action5)* (Slash Action6)?) / Action7)> (peg.peg:26) */
+		func() bool {
+			{
+
+				position25 := position
+				depth++
+				{
+
+					position26, tokenIndex26, depth26 := position, tokenIndex, depth
+					if !rules[RuleSequence]() {
+						goto l27
+					}
+				l28:
+					{
+
+						position29, tokenIndex29, depth29 := position, tokenIndex, depth
+						if !rules[RuleSlash]() {
+							goto l29
+						}
+						if !rules[RuleSequence]() {
+							goto l29
+						}
+						{
+
+							add(RuleAction5, position)
+						}
+						goto l28
+					l29:
+						position, tokenIndex, depth = position29, tokenIndex29, depth29
+					}
+					{
+
+						position31, tokenIndex31, depth31 := position, tokenIndex, depth
+						if !rules[RuleSlash]() {
+							goto l31
+						}
+						{
+
+							add(RuleAction6, position)
+						}
+						goto l32
+					l31:
+						position, tokenIndex, depth = position31, tokenIndex31, depth31
+					}
+				l32:
+					goto l26
+				l27:
+					position, tokenIndex, depth = position26, tokenIndex26, depth26
+					{
+
+						add(RuleAction7, position)
+					}
+				}
+			l26:
+				depth--
+				add(RuleExpression, position25)
+			}
+			return true
+		},
+		/* 3 Sequence <- <(Prefix (Prefix Action8)*)> (peg.peg:30) */
+		func() bool {
+			position35, tokenIndex35, depth35 := position, tokenIndex, depth
+			{
+
+				position36 := position
+				depth++
+				if !rules[RulePrefix]() {
+					goto l35
+				}
+			l37:
+				{
+
+					position38, tokenIndex38, depth38 := position, tokenIndex, depth
+					if !rules[RulePrefix]() {
+						goto l38
+					}
+					{
+
+						add(RuleAction8, position)
+					}
+					goto l37
+				l38:
+					position, tokenIndex, depth = position38, tokenIndex38, depth38
+				}
+				depth--
+				add(RuleSequence, position36)
+			}
+			return true
+		l35:
+			position, tokenIndex, depth = position35, tokenIndex35, depth35
+			return false
+		},
+		/* 4 Prefix <- <((And Action Action9) / ((&('!') (Not Suffix Action11)) | (&('&') (And Suffix Action10)) | (&('"' | '\'' | '(' | '.' | '<' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '[' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z' | '{') Suffix)))> (peg.peg:32) */
+		func() bool {
+			position40, tokenIndex40, depth40 := position, tokenIndex, depth
+			{
+
+				position41 := position
+				depth++
+				{
+
+					position42, tokenIndex42, depth42 := position, tokenIndex, depth
+					if !rules[RuleAnd]() {
+						goto l43
+					}
+					if !rules[RuleAction]() {
+						goto l43
+					}
+					{
+
+						add(RuleAction9, position)
+					}
+					goto l42
+				l43:
+					position, tokenIndex, depth = position42, tokenIndex42, depth42
+					{
+
+						switch buffer[position] {
+						case '!':
+							{
+
+								position46 := position
+								depth++
+								if buffer[position] != rune('!') {
+									goto l40
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l40
+								}
+								depth--
+								add(RuleNot, position46)
+							}
+							if !rules[RuleSuffix]() {
+								goto l40
+							}
+							{
+
+								add(RuleAction11, position)
+							}
+							break
+						case '&':
+							if !rules[RuleAnd]() {
+								goto l40
+							}
+							if !rules[RuleSuffix]() {
+								goto l40
+							}
+							{
+
+								add(RuleAction10, position)
+							}
+							break
+						default:
+							if !rules[RuleSuffix]() {
+								goto l40
+							}
+							break
+						}
+					}
+
+				}
+			l42:
+				depth--
+				add(RulePrefix, position41)
+			}
+			return true
+		l40:
+			position, tokenIndex, depth = position40, tokenIndex40, depth40
+			return false
+		},
+		/* 5 Suffix <- <(Primary ((&('{') (Repeat Action15)) | (&('+') (Plus Action14)) | (&('*') (Star Action13)) | (&('?') (Question Action12)))?)> (peg.peg:36) */
+		func() bool {
+			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			{
+
+				position50 := position
+				depth++
+				{
+
+					position51 := position
+					depth++
+					{
+
+						switch buffer[position] {
+						case '<':
+							{
+
+								position53 := position
+								depth++
+								if buffer[position] != rune('<') {
+									goto l49
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l49
+								}
+								depth--
+								add(RuleBegin, position53)
+							}
+							if !rules[RuleExpression]() {
+								goto l49
+							}
+							{
+
+								position54 := position
+								depth++
+								if buffer[position] != rune('>') {
+									goto l49
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l49
+								}
+								depth--
+								add(RuleEnd, position54)
+							}
+							{
+
+								add(RuleAction19, position)
+							}
+							break
+						case '{':
+							if !rules[RuleAction]() {
+								goto l49
+							}
+							{
+
+								add(RuleAction18, position)
+							}
+							break
+						case '.':
+							{
+
+								position57 := position
+								depth++
+								if buffer[position] != rune('.') {
+									goto l49
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l49
+								}
+								depth--
+								add(RuleDot, position57)
+							}
+							{
+
+								add(RuleAction17, position)
+							}
+							break
+						case '[':
+							{
+
+								position59 := position
+								depth++
+								{
+
+									position60 := position
+									depth++
+									{
+
+										position61, tokenIndex61, depth61 := position, tokenIndex, depth
+										if buffer[position] != rune('[') {
+											goto l62
+										}
+										position++
+										if buffer[position] != rune('[') {
+											goto l62
+										}
+										position++
+										{
+
+											position63, tokenIndex63, depth63 := position, tokenIndex, depth
+											{
+
+												position65, tokenIndex65, depth65 := position, tokenIndex, depth
+												{
+
+													position67, tokenIndex67, depth67 := position, tokenIndex, depth
+													if buffer[position] != rune('^') {
+														goto l68
+													}
+													position++
+													goto l67
+												l68:
+													position, tokenIndex, depth = position67, tokenIndex67, depth67
+													if buffer[position] != rune('~') {
+														goto l66
+													}
+													position++
+												}
+											l67:
+												if !rules[RuleDoubleRanges]() {
+													goto l66
+												}
+												{
+
+													add(RuleAction22, position)
+												}
+												goto l65
+											l66:
+												position, tokenIndex, depth = position65, tokenIndex65, depth65
+												if !rules[RuleDoubleRanges]() {
+													goto l63
+												}
+											}
+										l65:
+											goto l64
+										l63:
+											position, tokenIndex, depth = position63, tokenIndex63, depth63
+										}
+									l64:
+										if buffer[position] != rune(']') {
+											goto l62
+										}
+										position++
+										if buffer[position] != rune(']') {
+											goto l62
+										}
+										position++
+										goto l61
+									l62:
+										position, tokenIndex, depth = position61, tokenIndex61, depth61
+										if buffer[position] != rune('[') {
+											goto l49
+										}
+										position++
+										{
+
+											position70, tokenIndex70, depth70 := position, tokenIndex, depth
+											{
+
+												position72, tokenIndex72, depth72 := position, tokenIndex, depth
+												{
+
+													position74, tokenIndex74, depth74 := position, tokenIndex, depth
+													if buffer[position] != rune('^') {
+														goto l75
+													}
+													position++
+													goto l74
+												l75:
+													position, tokenIndex, depth = position74, tokenIndex74, depth74
+													if buffer[position] != rune('~') {
+														goto l73
+													}
+													position++
+												}
+											l74:
+												if !rules[RuleClassSet]() {
+													goto l73
+												}
+												{
+
+													add(RuleAction23, position)
+												}
+												goto l72
+											l73:
+												position, tokenIndex, depth = position72, tokenIndex72, depth72
+												if !rules[RuleClassSet]() {
+													goto l70
+												}
+											}
+										l72:
+											goto l71
+										l70:
+											position, tokenIndex, depth = position70, tokenIndex70, depth70
+										}
+									l71:
+										if buffer[position] != rune(']') {
+											goto l49
+										}
+										position++
+									}
+								l61:
+									depth--
+									add(RulePegText, position60)
+								}
+								{
+
+									add(RuleAction24, position)
+								}
+								if !rules[RuleSpacing]() {
+									goto l49
+								}
+								depth--
+								add(RuleClass, position59)
+							}
+							break
+						case '"', '\'':
+							{
+
+								position78 := position
+								depth++
+								{
+
+									position79, tokenIndex79, depth79 := position, tokenIndex, depth
+									if buffer[position] != rune('\'') {
+										goto l80
+									}
+									position++
+									{
+
+										position81, tokenIndex81, depth81 := position, tokenIndex, depth
+										{
+
+											position83, tokenIndex83, depth83 := position, tokenIndex, depth
+											if buffer[position] != rune('\'') {
+												goto l83
+											}
+											position++
+											goto l81
+										l83:
+											position, tokenIndex, depth = position83, tokenIndex83, depth83
+										}
+										if !rules[RuleChar]() {
+											goto l81
+										}
+										goto l82
+									l81:
+										position, tokenIndex, depth = position81, tokenIndex81, depth81
+									}
+								l82:
+								l84:
+									{
+
+										position85, tokenIndex85, depth85 := position, tokenIndex, depth
+										{
+
+											position86, tokenIndex86, depth86 := position, tokenIndex, depth
+											if buffer[position] != rune('\'') {
+												goto l86
+											}
+											position++
+											goto l85
+										l86:
+											position, tokenIndex, depth = position86, tokenIndex86, depth86
+										}
+										if !rules[RuleChar]() {
+											goto l85
+										}
+										{
+
+											add(RuleAction20, position)
+										}
+										goto l84
+									l85:
+										position, tokenIndex, depth = position85, tokenIndex85, depth85
+									}
+									if buffer[position] != rune('\'') {
+										goto l80
+									}
+									position++
+									if !rules[RuleSpacing]() {
+										goto l80
+									}
+									goto l79
+								l80:
+									position, tokenIndex, depth = position79, tokenIndex79, depth79
+									if buffer[position] != rune('"') {
+										goto l49
+									}
+									position++
+									{
+
+										position88, tokenIndex88, depth88 := position, tokenIndex, depth
+										{
+
+											position90, tokenIndex90, depth90 := position, tokenIndex, depth
+											if buffer[position] != rune('"') {
+												goto l90
+											}
+											position++
+											goto l88
+										l90:
+											position, tokenIndex, depth = position90, tokenIndex90, depth90
+										}
+										if !rules[RuleDoubleChar]() {
+											goto l88
+										}
+										goto l89
+									l88:
+										position, tokenIndex, depth = position88, tokenIndex88, depth88
+									}
+								l89:
+								l91:
+									{
+
+										position92, tokenIndex92, depth92 := position, tokenIndex, depth
+										{
+
+											position93, tokenIndex93, depth93 := position, tokenIndex, depth
+											if buffer[position] != rune('"') {
+												goto l93
+											}
+											position++
+											goto l92
+										l93:
+											position, tokenIndex, depth = position93, tokenIndex93, depth93
+										}
+										if !rules[RuleDoubleChar]() {
+											goto l92
+										}
+										{
+
+											add(RuleAction21, position)
+										}
+										goto l91
+									l92:
+										position, tokenIndex, depth = position92, tokenIndex92, depth92
+									}
+									if buffer[position] != rune('"') {
+										goto l49
+									}
+									position++
+									if !rules[RuleSpacing]() {
+										goto l49
+									}
+								}
+							l79:
+								depth--
+								add(RuleLiteral, position78)
+							}
+							break
+						case '(':
+							{
+
+								position95 := position
+								depth++
+								if buffer[position] != rune('(') {
+									goto l49
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l49
+								}
+								depth--
+								add(RuleOpen, position95)
+							}
+							if !rules[RuleExpression]() {
+								goto l49
+							}
+							{
+
+								position96 := position
+								depth++
+								if buffer[position] != rune(')') {
+									goto l49
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l49
+								}
+								depth--
+								add(RuleClose, position96)
+							}
+							break
+						default:
+							if !rules[RuleIdentifier]() {
+								goto l49
+							}
+							{
+
+								position97, tokenIndex97, depth97 := position, tokenIndex, depth
+								if !rules[RuleLeftArrow]() {
+									goto l97
+								}
+								goto l49
+							l97:
+								position, tokenIndex, depth = position97, tokenIndex97, depth97
+							}
+							{
+
+								add(RuleAction16, position)
+							}
+							break
+						}
+					}
+
+					depth--
+					add(RulePrimary, position51)
+				}
+				{
+
+					position99, tokenIndex99, depth99 := position, tokenIndex, depth
+					{
+
+						switch buffer[position] {
+						case '{':
+							{
+
+								position102 := position
+								depth++
+								if buffer[position] != rune('{') {
+									goto l99
+								}
+								position++
+								{
+
+									position103 := position
+									depth++
+								l104:
+									{
+
+										position105, tokenIndex105, depth105 := position, tokenIndex, depth
+										{
+
+											position106, tokenIndex106, depth106 := position, tokenIndex, depth
+											if buffer[position] != rune(' ') {
+												goto l107
+											}
+											position++
+											goto l106
+										l107:
+											position, tokenIndex, depth = position106, tokenIndex106, depth106
+											if buffer[position] != rune('\t') {
+												goto l105
+											}
+											position++
+										}
+									l106:
+										goto l104
+									l105:
+										position, tokenIndex, depth = position105, tokenIndex105, depth105
+									}
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l99
+									}
+									position++
+								l108:
+									{
+
+										position109, tokenIndex109, depth109 := position, tokenIndex, depth
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l109
+										}
+										position++
+										goto l108
+									l109:
+										position, tokenIndex, depth = position109, tokenIndex109, depth109
+									}
+								l110:
+									{
+
+										position111, tokenIndex111, depth111 := position, tokenIndex, depth
+										{
+
+											position112, tokenIndex112, depth112 := position, tokenIndex, depth
+											if buffer[position] != rune(' ') {
+												goto l113
+											}
+											position++
+											goto l112
+										l113:
+											position, tokenIndex, depth = position112, tokenIndex112, depth112
+											if buffer[position] != rune('\t') {
+												goto l111
+											}
+											position++
+										}
+									l112:
+										goto l110
+									l111:
+										position, tokenIndex, depth = position111, tokenIndex111, depth111
+									}
+									{
+
+										position114, tokenIndex114, depth114 := position, tokenIndex, depth
+										if buffer[position] != rune(',') {
+											goto l114
+										}
+										position++
+									l116:
+										{
+
+											position117, tokenIndex117, depth117 := position, tokenIndex, depth
+											{
+
+												position118, tokenIndex118, depth118 := position, tokenIndex, depth
+												if buffer[position] != rune(' ') {
+													goto l119
+												}
+												position++
+												goto l118
+											l119:
+												position, tokenIndex, depth = position118, tokenIndex118, depth118
+												if buffer[position] != rune('\t') {
+													goto l117
+												}
+												position++
+											}
+										l118:
+											goto l116
+										l117:
+											position, tokenIndex, depth = position117, tokenIndex117, depth117
+										}
+									l120:
+										{
+
+											position121, tokenIndex121, depth121 := position, tokenIndex, depth
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l121
+											}
+											position++
+											goto l120
+										l121:
+											position, tokenIndex, depth = position121, tokenIndex121, depth121
+										}
+									l122:
+										{
+
+											position123, tokenIndex123, depth123 := position, tokenIndex, depth
+											{
+
+												position124, tokenIndex124, depth124 := position, tokenIndex, depth
+												if buffer[position] != rune(' ') {
+													goto l125
+												}
+												position++
+												goto l124
+											l125:
+												position, tokenIndex, depth = position124, tokenIndex124, depth124
+												if buffer[position] != rune('\t') {
+													goto l123
+												}
+												position++
+											}
+										l124:
+											goto l122
+										l123:
+											position, tokenIndex, depth = position123, tokenIndex123, depth123
+										}
+										goto l115
+									l114:
+										position, tokenIndex, depth = position114, tokenIndex114, depth114
+									}
+								l115:
+									depth--
+									add(RulePegText, position103)
+								}
+								if buffer[position] != rune('}') {
+									goto l99
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l99
+								}
+								depth--
+								add(RuleRepeat, position102)
+							}
+							{
+
+								add(RuleAction15, position)
+							}
+							break
+						case '+':
+							{
+
+								position127 := position
+								depth++
+								if buffer[position] != rune('+') {
+									goto l99
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l99
+								}
+								depth--
+								add(RulePlus, position127)
+							}
+							{
+
+								add(RuleAction14, position)
+							}
+							break
+						case '*':
+							{
+
+								position129 := position
+								depth++
+								if buffer[position] != rune('*') {
+									goto l99
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l99
+								}
+								depth--
+								add(RuleStar, position129)
+							}
+							{
+
+								add(RuleAction13, position)
+							}
+							break
+						default:
+							{
+
+								position131 := position
+								depth++
+								if buffer[position] != rune('?') {
+									goto l99
+								}
+								position++
+								if !rules[RuleSpacing]() {
+									goto l99
+								}
+								depth--
+								add(RuleQuestion, position131)
+							}
+							{
+
+								add(RuleAction12, position)
+							}
+							break
+						}
+					}
+
+					goto l100
+				l99:
+					position, tokenIndex, depth = position99, tokenIndex99, depth99
+				}
+			l100:
+				depth--
+				add(RuleSuffix, position50)
+			}
+			return true
+		l49:
+			position, tokenIndex, depth = position49, tokenIndex49, depth49
+			return false
+		},
+		/* 6 Primary <- <((&('<') (Begin Expression End Action19)) | (&('{') (Action Action18)) | (&('.') (Dot Action17)) | (&('[') Class) | (&('"' | '\'') Literal) | (&('(') (Open Expression Close)) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') (Identifier !LeftArrow Action16)))> (peg.peg:41) */
+		nil,
+		/* 7 Identifier <- <(<(IdentStart IdentCont*)> Spacing)> (peg.peg:50) */
+		func() bool {
+			position134, tokenIndex134, depth134 := position, tokenIndex, depth
+			{
+
+				position135 := position
+				depth++
+				{
+
+					position136 := position
+					depth++
+					if !rules[RuleIdentStart]() {
+						goto l134
+					}
+				l137:
+					{
+
+						position138, tokenIndex138, depth138 := position, tokenIndex, depth
+						{
+
+							position139 := position
+							depth++
+							{
+
+								position140, tokenIndex140, depth140 := position, tokenIndex, depth
+								if !rules[RuleIdentStart]() {
+									goto l141
+								}
+								goto l140
+							l141:
+								position, tokenIndex, depth = position140, tokenIndex140, depth140
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l138
+								}
+								position++
+							}
+						l140:
+							depth--
+							add(RuleIdentCont, position139)
+						}
+						goto l137
+					l138:
+						position, tokenIndex, depth = position138, tokenIndex138, depth138
+					}
+					depth--
+					add(RulePegText, position136)
+				}
+				if !rules[RuleSpacing]() {
+					goto l134
+				}
+				depth--
+				add(RuleIdentifier, position135)
+			}
+			return true
+		l134:
+			position, tokenIndex, depth = position134, tokenIndex134, depth134
+			return false
+		},
+		/* 8 IdentStart <- <((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))> (peg.peg:51) */
+		func() bool {
+			position142, tokenIndex142, depth142 := position, tokenIndex, depth
+			{
+
+				position143 := position
+				depth++
+				{
+
+					switch buffer[position] {
+					case '_':
+						if buffer[position] != rune('_') {
+							goto l142
+						}
+						position++
+						break
+					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l142
+						}
+						position++
+						break
+					default:
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l142
+						}
+						position++
+						break
+					}
+				}
+
+				depth--
+				add(RuleIdentStart, position143)
+			}
+			return true
+		l142:
+			position, tokenIndex, depth = position142, tokenIndex142, depth142
+			return false
+		},
+		/* 9 IdentCont <- <(IdentStart / [0-9])> (peg.peg:52) */
+		nil,
+		/* 10 Literal <- <(('\'' (!'\'' Char)? (!'\'' Char Action20)* '\'' Spacing) / ('"' (!'"' DoubleChar)? (!'"' DoubleChar Action21)* '"' Spacing))> (peg.peg:53) */
+		nil,
+		/* 11 Class <- <(<(('[' '[' ((('^' / '~') DoubleRanges Action22) / DoubleRanges)? (']' ']')) / ('[' ((('^' / '~') ClassSet Action23) / ClassSet)? ']'))> Action24 Spacing)> (peg.peg:57) */
+		nil,
+		/* 12 ClassSet <- <(Ranges (('-' '-' Operands Action25) / ('&' '&' Operands Action26))*)> (peg.peg:64) */
+		func() bool {
+			position148, tokenIndex148, depth148 := position, tokenIndex, depth
+			{
+
+				position149 := position
+				depth++
+				{
+
+					position150 := position
+					depth++
+					{
+
+						position151, tokenIndex151, depth151 := position, tokenIndex, depth
+						if buffer[position] != rune(']') {
+							goto l151
+						}
+						position++
+						goto l148
+					l151:
+						position, tokenIndex, depth = position151, tokenIndex151, depth151
+					}
+					if !rules[RuleRange]() {
+						goto l148
+					}
+				l152:
+					{
+
+						position153, tokenIndex153, depth153 := position, tokenIndex, depth
+						{
+
+							position154, tokenIndex154, depth154 := position, tokenIndex, depth
+							if buffer[position] != rune(']') {
+								goto l154
+							}
+							position++
+							goto l153
+						l154:
+							position, tokenIndex, depth = position154, tokenIndex154, depth154
+						}
+						{
+
+							position155, tokenIndex155, depth155 := position, tokenIndex, depth
+							if buffer[position] != rune('-') {
+								goto l155
+							}
+							position++
+							if buffer[position] != rune('-') {
+								goto l155
+							}
+							position++
+							goto l153
+						l155:
+							position, tokenIndex, depth = position155, tokenIndex155, depth155
+						}
+						{
+
+							position156, tokenIndex156, depth156 := position, tokenIndex, depth
+							if buffer[position] != rune('&') {
+								goto l156
+							}
+							position++
+							if buffer[position] != rune('&') {
+								goto l156
+							}
+							position++
+							goto l153
+						l156:
+							position, tokenIndex, depth = position156, tokenIndex156, depth156
+						}
+						if !rules[RuleRange]() {
+							goto l153
+						}
+						{
+
+							add(RuleAction27, position)
+						}
+						goto l152
+					l153:
+						position, tokenIndex, depth = position153, tokenIndex153, depth153
+					}
+					depth--
+					add(RuleRanges, position150)
+				}
+			l158:
+				{
+
+					position159, tokenIndex159, depth159 := position, tokenIndex, depth
+					{
+
+						position160, tokenIndex160, depth160 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l161
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l161
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l161
+						}
+						{
+
+							add(RuleAction25, position)
+						}
+						goto l160
+					l161:
+						position, tokenIndex, depth = position160, tokenIndex160, depth160
+						if buffer[position] != rune('&') {
+							goto l159
+						}
+						position++
+						if buffer[position] != rune('&') {
+							goto l159
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l159
+						}
+						{
+
+							add(RuleAction26, position)
+						}
+					}
+				l160:
+					goto l158
+				l159:
+					position, tokenIndex, depth = position159, tokenIndex159, depth159
+				}
+				depth--
+				add(RuleClassSet, position149)
+			}
+			return true
+		l148:
+			position, tokenIndex, depth = position148, tokenIndex148, depth148
+			return false
+		},
+		/* 13 Ranges <- <(!']' Range (!']' !('-' '-') !('&' '&') Range Action27)*)> (peg.peg:67) */
+		nil,
+		/* 14 Operands <- <(!']' Operand (!']' !('-' '-') !('&' '&') Operand Action28)*)> (peg.peg:69) */
+		func() bool {
+			position165, tokenIndex165, depth165 := position, tokenIndex, depth
+			{
+
+				position166 := position
+				depth++
+				{
+
+					position167, tokenIndex167, depth167 := position, tokenIndex, depth
+					if buffer[position] != rune(']') {
+						goto l167
+					}
+					position++
+					goto l165
+				l167:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
+				}
+				if !rules[RuleOperand]() {
+					goto l165
+				}
+			l168:
+				{
+
+					position169, tokenIndex169, depth169 := position, tokenIndex, depth
+					{
+
+						position170, tokenIndex170, depth170 := position, tokenIndex, depth
+						if buffer[position] != rune(']') {
+							goto l170
+						}
+						position++
+						goto l169
+					l170:
+						position, tokenIndex, depth = position170, tokenIndex170, depth170
+					}
+					{
+
+						position171, tokenIndex171, depth171 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l171
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l171
+						}
+						position++
+						goto l169
+					l171:
+						position, tokenIndex, depth = position171, tokenIndex171, depth171
+					}
+					{
+
+						position172, tokenIndex172, depth172 := position, tokenIndex, depth
+						if buffer[position] != rune('&') {
+							goto l172
+						}
+						position++
+						if buffer[position] != rune('&') {
+							goto l172
+						}
+						position++
+						goto l169
+					l172:
+						position, tokenIndex, depth = position172, tokenIndex172, depth172
+					}
+					if !rules[RuleOperand]() {
+						goto l169
+					}
+					{
+
+						add(RuleAction28, position)
+					}
+					goto l168
+				l169:
+					position, tokenIndex, depth = position169, tokenIndex169, depth169
+				}
+				depth--
+				add(RuleOperands, position166)
+			}
+			return true
+		l165:
+			position, tokenIndex, depth = position165, tokenIndex165, depth165
+			return false
+		},
+		/* 15 Operand <- <(('[' ((('^' / '~') NestedSet Action29) / NestedSet) ']') / Range)> (peg.peg:71) */
+		func() bool {
+			position174, tokenIndex174, depth174 := position, tokenIndex, depth
+			{
+
+				position175 := position
+				depth++
+				{
+
+					position176, tokenIndex176, depth176 := position, tokenIndex, depth
+					if buffer[position] != rune('[') {
+						goto l177
+					}
+					position++
+					{
+
+						position178, tokenIndex178, depth178 := position, tokenIndex, depth
+						{
+
+							position180, tokenIndex180, depth180 := position, tokenIndex, depth
+							if buffer[position] != rune('^') {
+								goto l181
+							}
+							position++
+							goto l180
+						l181:
+							position, tokenIndex, depth = position180, tokenIndex180, depth180
+							if buffer[position] != rune('~') {
+								goto l179
+							}
+							position++
+						}
+					l180:
+						if !rules[RuleNestedSet]() {
+							goto l179
+						}
+						{
+
+							add(RuleAction29, position)
+						}
+						goto l178
+					l179:
+						position, tokenIndex, depth = position178, tokenIndex178, depth178
+						if !rules[RuleNestedSet]() {
+							goto l177
+						}
+					}
+				l178:
+					if buffer[position] != rune(']') {
+						goto l177
+					}
+					position++
+					goto l176
+				l177:
+					position, tokenIndex, depth = position176, tokenIndex176, depth176
+					if !rules[RuleRange]() {
+						goto l174
+					}
+				}
+			l176:
+				depth--
+				add(RuleOperand, position175)
+			}
+			return true
+		l174:
+			position, tokenIndex, depth = position174, tokenIndex174, depth174
+			return false
+		},
+		/* 16 NestedSet <- <(Operands (('-' '-' Operands Action30) / ('&' '&' Operands Action31))*)> (peg.peg:74) */
+		func() bool {
+			position183, tokenIndex183, depth183 := position, tokenIndex, depth
+			{
+
+				position184 := position
+				depth++
+				if !rules[RuleOperands]() {
+					goto l183
+				}
+			l185:
+				{
+
+					position186, tokenIndex186, depth186 := position, tokenIndex, depth
+					{
+
+						position187, tokenIndex187, depth187 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l188
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l188
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l188
+						}
+						{
+
+							add(RuleAction30, position)
+						}
+						goto l187
+					l188:
+						position, tokenIndex, depth = position187, tokenIndex187, depth187
+						if buffer[position] != rune('&') {
+							goto l186
+						}
+						position++
+						if buffer[position] != rune('&') {
+							goto l186
+						}
+						position++
+						if !rules[RuleOperands]() {
+							goto l186
+						}
+						{
+
+							add(RuleAction31, position)
+						}
+					}
+				l187:
+					goto l185
+				l186:
+					position, tokenIndex, depth = position186, tokenIndex186, depth186
+				}
+				depth--
+				add(RuleNestedSet, position184)
+			}
+			return true
+		l183:
+			position, tokenIndex, depth = position183, tokenIndex183, depth183
+			return false
+		},
+		/* 17 DoubleRanges <- <(!(']' ']') DoubleRange (!(']' ']') DoubleRange Action32)*)> (peg.peg:77) */
+		func() bool {
+			position191, tokenIndex191, depth191 := position, tokenIndex, depth
+			{
+
+				position192 := position
+				depth++
+				{
+
+					position193, tokenIndex193, depth193 := position, tokenIndex, depth
+					if buffer[position] != rune(']') {
+						goto l193
+					}
+					position++
+					if buffer[position] != rune(']') {
+						goto l193
+					}
+					position++
+					goto l191
+				l193:
+					position, tokenIndex, depth = position193, tokenIndex193, depth193
+				}
+				if !rules[RuleDoubleRange]() {
+					goto l191
+				}
+			l194:
+				{
+
+					position195, tokenIndex195, depth195 := position, tokenIndex, depth
+					{
+
+						position196, tokenIndex196, depth196 := position, tokenIndex, depth
+						if buffer[position] != rune(']') {
+							goto l196
+						}
+						position++
+						if buffer[position] != rune(']') {
+							goto l196
+						}
+						position++
+						goto l195
+					l196:
+						position, tokenIndex, depth = position196, tokenIndex196, depth196
+					}
+					if !rules[RuleDoubleRange]() {
+						goto l195
+					}
+					{
+
+						add(RuleAction32, position)
+					}
+					goto l194
+				l195:
+					position, tokenIndex, depth = position195, tokenIndex195, depth195
+				}
+				depth--
+				add(RuleDoubleRanges, position192)
+			}
+			return true
+		l191:
+			position, tokenIndex, depth = position191, tokenIndex191, depth191
+			return false
+		},
+		/* 18 Range <- <(('\\' 'p' '{' <((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> '}' Action33) / ('\\' 'P' '{' <((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> '}' Action34) / (Char !('-' '-') '-' Char Action35) / Char)> (peg.peg:79) */
+		func() bool {
+			position198, tokenIndex198, depth198 := position, tokenIndex, depth
+			{
+
+				position199 := position
+				depth++
+				{
+
+					position200, tokenIndex200, depth200 := position, tokenIndex, depth
+					if buffer[position] != rune('\\') {
+						goto l201
+					}
+					position++
+					if buffer[position] != rune('p') {
+						goto l201
+					}
+					position++
+					if buffer[position] != rune('{') {
+						goto l201
+					}
+					position++
+					{
+
+						position202 := position
+						depth++
+						{
+
+							switch buffer[position] {
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l201
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l201
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l201
+								}
+								position++
+								break
+							}
+						}
+
+					l203:
+						{
+
+							position204, tokenIndex204, depth204 := position, tokenIndex, depth
+							{
+
+								switch buffer[position] {
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l204
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l204
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l204
+									}
+									position++
+									break
+								}
+							}
+
+							goto l203
+						l204:
+							position, tokenIndex, depth = position204, tokenIndex204, depth204
+						}
+						depth--
+						add(RulePegText, position202)
+					}
+					if buffer[position] != rune('}') {
+						goto l201
+					}
+					position++
+					{
+
+						add(RuleAction33, position)
+					}
+					goto l200
+				l201:
+					position, tokenIndex, depth = position200, tokenIndex200, depth200
+					if buffer[position] != rune('\\') {
+						goto l208
+					}
+					position++
+					if buffer[position] != rune('P') {
+						goto l208
+					}
+					position++
+					if buffer[position] != rune('{') {
+						goto l208
+					}
+					position++
+					{
+
+						position209 := position
+						depth++
+						{
+
+							switch buffer[position] {
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l208
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l208
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l208
+								}
+								position++
+								break
+							}
+						}
+
+					l210:
+						{
+
+							position211, tokenIndex211, depth211 := position, tokenIndex, depth
+							{
+
+								switch buffer[position] {
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l211
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l211
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l211
+									}
+									position++
+									break
+								}
+							}
+
+							goto l210
+						l211:
+							position, tokenIndex, depth = position211, tokenIndex211, depth211
+						}
+						depth--
+						add(RulePegText, position209)
+					}
+					if buffer[position] != rune('}') {
+						goto l208
+					}
+					position++
+					{
+
+						add(RuleAction34, position)
+					}
+					goto l200
+				l208:
+					position, tokenIndex, depth = position200, tokenIndex200, depth200
+					if !rules[RuleChar]() {
+						goto l215
+					}
+					{
+
+						position216, tokenIndex216, depth216 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l216
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l216
+						}
+						position++
+						goto l215
+					l216:
+						position, tokenIndex, depth = position216, tokenIndex216, depth216
+					}
+					if buffer[position] != rune('-') {
+						goto l215
+					}
+					position++
+					if !rules[RuleChar]() {
+						goto l215
+					}
+					{
+
+						add(RuleAction35, position)
+					}
+					goto l200
+				l215:
+					position, tokenIndex, depth = position200, tokenIndex200, depth200
+					if !rules[RuleChar]() {
+						goto l198
+					}
+				}
+			l200:
+				depth--
+				add(RuleRange, position199)
+			}
+			return true
+		l198:
+			position, tokenIndex, depth = position198, tokenIndex198, depth198
+			return false
+		},
+		/* 19 DoubleRange <- <((Char '-' Char Action36) / DoubleChar)> (peg.peg:83) */
+		func() bool {
+			position218, tokenIndex218, depth218 := position, tokenIndex, depth
+			{
+
+				position219 := position
+				depth++
+				{
+
+					position220, tokenIndex220, depth220 := position, tokenIndex, depth
+					if !rules[RuleChar]() {
+						goto l221
+					}
+					if buffer[position] != rune('-') {
+						goto l221
+					}
+					position++
+					if !rules[RuleChar]() {
+						goto l221
+					}
+					{
+
+						add(RuleAction36, position)
+					}
+					goto l220
+				l221:
+					position, tokenIndex, depth = position220, tokenIndex220, depth220
+					if !rules[RuleDoubleChar]() {
+						goto l218
+					}
+				}
+			l220:
+				depth--
+				add(RuleDoubleRange, position219)
+			}
+			return true
+		l218:
+			position, tokenIndex, depth = position218, tokenIndex218, depth218
+			return false
+		},
+		/* 20 Char <- <(Escape / (!'\\' <.> Action37))> (peg.peg:85) */
+		func() bool {
+			position223, tokenIndex223, depth223 := position, tokenIndex, depth
+			{
+
+				position224 := position
+				depth++
+				{
+
+					position225, tokenIndex225, depth225 := position, tokenIndex, depth
+					if !rules[RuleEscape]() {
+						goto l226
+					}
+					goto l225
+				l226:
+					position, tokenIndex, depth = position225, tokenIndex225, depth225
+					{
+
+						position227, tokenIndex227, depth227 := position, tokenIndex, depth
+						if buffer[position] != rune('\\') {
+							goto l227
+						}
+						position++
+						goto l223
+					l227:
+						position, tokenIndex, depth = position227, tokenIndex227, depth227
+					}
+					{
+
+						position228 := position
+						depth++
+						if !matchDot() {
+							goto l223
+						}
+						depth--
+						add(RulePegText, position228)
+					}
+					{
+
+						add(RuleAction37, position)
+					}
+				}
+			l225:
+				depth--
+				add(RuleChar, position224)
+			}
+			return true
+		l223:
+			position, tokenIndex, depth = position223, tokenIndex223, depth223
+			return false
+		},
+		/* 21 DoubleChar <- <(Escape / (<([a-z] / [A-Z])> Action38) / (!'\\' <.> Action39))> (peg.peg:87) */
+		func() bool {
+			position230, tokenIndex230, depth230 := position, tokenIndex, depth
+			{
+
+				position231 := position
+				depth++
+				{
+
+					position232, tokenIndex232, depth232 := position, tokenIndex, depth
+					if !rules[RuleEscape]() {
+						goto l233
+					}
+					goto l232
+				l233:
+					position, tokenIndex, depth = position232, tokenIndex232, depth232
+					{
+
+						position235 := position
+						depth++
+						{
+
+							position236, tokenIndex236, depth236 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l237
+							}
+							position++
+							goto l236
+						l237:
+							position, tokenIndex, depth = position236, tokenIndex236, depth236
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l234
+							}
+							position++
+						}
+					l236:
+						depth--
+						add(RulePegText, position235)
+					}
+					{
+
+						add(RuleAction38, position)
+					}
+					goto l232
+				l234:
+					position, tokenIndex, depth = position232, tokenIndex232, depth232
+					{
+
+						position239, tokenIndex239, depth239 := position, tokenIndex, depth
+						if buffer[position] != rune('\\') {
+							goto l239
+						}
+						position++
+						goto l230
+					l239:
+						position, tokenIndex, depth = position239, tokenIndex239, depth239
+					}
+					{
+
+						position240 := position
+						depth++
+						if !matchDot() {
+							goto l230
+						}
+						depth--
+						add(RulePegText, position240)
+					}
+					{
+
+						add(RuleAction39, position)
+					}
+				}
+			l232:
+				depth--
+				add(RuleDoubleChar, position231)
+			}
+			return true
+		l230:
+			position, tokenIndex, depth = position230, tokenIndex230, depth230
+			return false
+		},
+		/* 22 Hex <- <((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]))> (peg.peg:90) */
+		func() bool {
+			position242, tokenIndex242, depth242 := position, tokenIndex, depth
+			{
+
+				position243 := position
+				depth++
+				{
+
+					switch buffer[position] {
+					case 'A', 'B', 'C', 'D', 'E', 'F':
+						if c := buffer[position]; c < rune('A') || c > rune('F') {
+							goto l242
+						}
+						position++
+						break
+					case 'a', 'b', 'c', 'd', 'e', 'f':
+						if c := buffer[position]; c < rune('a') || c > rune('f') {
+							goto l242
+						}
+						position++
+						break
+					default:
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l242
+						}
+						position++
+						break
+					}
+				}
+
+				depth--
+				add(RuleHex, position243)
+			}
+			return true
+		l242:
+			position, tokenIndex, depth = position242, tokenIndex242, depth242
+			return false
+		},
+		/* 23 Escape <- <(('\\' ('a' / 'A') Action40) / ('\\' ('b' / 'B') Action41) / ('\\' ('e' / 'E') Action42) / ('\\' ('f' / 'F') Action43) / ('\\' ('n' / 'N') Action44) / ('\\' ('r' / 'R') Action45) / ('\\' ('t' / 'T') Action46) / ('\\' ('v' / 'V') Action47) / ('\\' '\'' Action48) / ('\\' '"' Action49) / ('\\' '[' Action50) / ('\\' ']' Action51) / ('\\' '-' Action52) / ('\\' 'x' <(Hex Hex)> Action53) / ('\\' 'u' '{' <Hex+> '}' Action54) / ('\\' 'u' <(Hex Hex Hex Hex)> Action55) / ('\\' 'U' <(Hex Hex Hex Hex Hex Hex Hex Hex)> Action56) / ('\\' <([0-3] [0-7] [0-7])> Action57) / ('\\' <([0-7] [0-7]?)> Action58) / ('\\' '\\' Action59) / ('\\' <.> Action60))> (peg.peg:91) */
+		func() bool {
+			position245, tokenIndex245, depth245 := position, tokenIndex, depth
+			{
+
+				position246 := position
+				depth++
+				{
+
+					position247, tokenIndex247, depth247 := position, tokenIndex, depth
+					if buffer[position] != rune('\\') {
+						goto l248
+					}
+					position++
+					{
+
+						position249, tokenIndex249, depth249 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l250
+						}
+						position++
+						goto l249
+					l250:
+						position, tokenIndex, depth = position249, tokenIndex249, depth249
+						if buffer[position] != rune('A') {
+							goto l248
+						}
+						position++
+					}
+				l249:
+					{
+
+						add(RuleAction40, position)
+					}
+					goto l247
+				l248:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l252
+					}
+					position++
+					{
+
+						position253, tokenIndex253, depth253 := position, tokenIndex, depth
+						if buffer[position] != rune('b') {
+							goto l254
+						}
+						position++
+						goto l253
+					l254:
+						position, tokenIndex, depth = position253, tokenIndex253, depth253
+						if buffer[position] != rune('B') {
+							goto l252
+						}
+						position++
+					}
+				l253:
+					{
+
+						add(RuleAction41, position)
+					}
+					goto l247
+				l252:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l256
+					}
+					position++
+					{
+
+						position257, tokenIndex257, depth257 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l258
+						}
+						position++
+						goto l257
+					l258:
+						position, tokenIndex, depth = position257, tokenIndex257, depth257
+						if buffer[position] != rune('E') {
+							goto l256
+						}
+						position++
+					}
+				l257:
+					{
+
+						add(RuleAction42, position)
+					}
+					goto l247
+				l256:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l260
+					}
+					position++
+					{
+
+						position261, tokenIndex261, depth261 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l262
+						}
+						position++
+						goto l261
+					l262:
+						position, tokenIndex, depth = position261, tokenIndex261, depth261
+						if buffer[position] != rune('F') {
+							goto l260
+						}
+						position++
+					}
+				l261:
+					{
+
+						add(RuleAction43, position)
+					}
+					goto l247
+				l260:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l264
+					}
+					position++
+					{
+
+						position265, tokenIndex265, depth265 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l266
+						}
+						position++
+						goto l265
+					l266:
+						position, tokenIndex, depth = position265, tokenIndex265, depth265
+						if buffer[position] != rune('N') {
+							goto l264
+						}
+						position++
+					}
+				l265:
+					{
+
+						add(RuleAction44, position)
+					}
+					goto l247
+				l264:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l268
+					}
+					position++
+					{
+
+						position269, tokenIndex269, depth269 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l270
+						}
+						position++
+						goto l269
+					l270:
+						position, tokenIndex, depth = position269, tokenIndex269, depth269
+						if buffer[position] != rune('R') {
+							goto l268
+						}
+						position++
+					}
+				l269:
+					{
+
+						add(RuleAction45, position)
+					}
+					goto l247
+				l268:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l272
+					}
+					position++
+					{
+
+						position273, tokenIndex273, depth273 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l274
+						}
+						position++
+						goto l273
+					l274:
+						position, tokenIndex, depth = position273, tokenIndex273, depth273
+						if buffer[position] != rune('T') {
+							goto l272
+						}
+						position++
+					}
+				l273:
+					{
+
+						add(RuleAction46, position)
+					}
+					goto l247
+				l272:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l276
+					}
+					position++
+					{
+
+						position277, tokenIndex277, depth277 := position, tokenIndex, depth
+						if buffer[position] != rune('v') {
+							goto l278
+						}
+						position++
+						goto l277
+					l278:
+						position, tokenIndex, depth = position277, tokenIndex277, depth277
+						if buffer[position] != rune('V') {
+							goto l276
+						}
+						position++
+					}
+				l277:
+					{
+
+						add(RuleAction47, position)
+					}
+					goto l247
+				l276:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l280
+					}
+					position++
+					if buffer[position] != rune('\'') {
+						goto l280
+					}
+					position++
+					{
+
+						add(RuleAction48, position)
+					}
+					goto l247
+				l280:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l282
+					}
+					position++
+					if buffer[position] != rune('"') {
+						goto l282
+					}
+					position++
+					{
+
+						add(RuleAction49, position)
+					}
+					goto l247
+				l282:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l284
+					}
+					position++
+					if buffer[position] != rune('[') {
+						goto l284
+					}
+					position++
+					{
+
+						add(RuleAction50, position)
+					}
+					goto l247
+				l284:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l286
+					}
+					position++
+					if buffer[position] != rune(']') {
+						goto l286
+					}
+					position++
+					{
+
+						add(RuleAction51, position)
+					}
+					goto l247
+				l286:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l288
+					}
+					position++
+					if buffer[position] != rune('-') {
+						goto l288
+					}
+					position++
+					{
+
+						add(RuleAction52, position)
+					}
+					goto l247
+				l288:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l290
+					}
+					position++
+					if buffer[position] != rune('x') {
+						goto l290
+					}
+					position++
+					{
+
+						position291 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l290
+						}
+						if !rules[RuleHex]() {
+							goto l290
+						}
+						depth--
+						add(RulePegText, position291)
+					}
+					{
+
+						add(RuleAction53, position)
+					}
+					goto l247
+				l290:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l293
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l293
+					}
+					position++
+					if buffer[position] != rune('{') {
+						goto l293
+					}
+					position++
+					{
+
+						position294 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l293
+						}
+					l295:
+						{
+
+							position296, tokenIndex296, depth296 := position, tokenIndex, depth
+							if !rules[RuleHex]() {
+								goto l296
+							}
+							goto l295
+						l296:
+							position, tokenIndex, depth = position296, tokenIndex296, depth296
+						}
+						depth--
+						add(RulePegText, position294)
+					}
+					if buffer[position] != rune('}') {
+						goto l293
+					}
+					position++
+					{
+
+						add(RuleAction54, position)
+					}
+					goto l247
+				l293:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l298
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l298
+					}
+					position++
+					{
+
+						position299 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l298
+						}
+						if !rules[RuleHex]() {
+							goto l298
+						}
+						if !rules[RuleHex]() {
+							goto l298
+						}
+						if !rules[RuleHex]() {
+							goto l298
+						}
+						depth--
+						add(RulePegText, position299)
+					}
+					{
+
+						add(RuleAction55, position)
+					}
+					goto l247
+				l298:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l301
+					}
+					position++
+					if buffer[position] != rune('U') {
+						goto l301
+					}
+					position++
+					{
+
+						position302 := position
+						depth++
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						if !rules[RuleHex]() {
+							goto l301
+						}
+						depth--
+						add(RulePegText, position302)
+					}
+					{
+
+						add(RuleAction56, position)
+					}
+					goto l247
+				l301:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l304
+					}
+					position++
+					{
+
+						position305 := position
+						depth++
+						if c := buffer[position]; c < rune('0') || c > rune('3') {
+							goto l304
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('7') {
+							goto l304
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('7') {
+							goto l304
+						}
+						position++
+						depth--
+						add(RulePegText, position305)
+					}
+					{
+
+						add(RuleAction57, position)
+					}
+					goto l247
+				l304:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l307
+					}
+					position++
+					{
+
+						position308 := position
+						depth++
+						if c := buffer[position]; c < rune('0') || c > rune('7') {
+							goto l307
+						}
+						position++
+						{
+
+							position309, tokenIndex309, depth309 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('7') {
+								goto l309
+							}
+							position++
+							goto l310
+						l309:
+							position, tokenIndex, depth = position309, tokenIndex309, depth309
+						}
+					l310:
+						depth--
+						add(RulePegText, position308)
+					}
+					{
+
+						add(RuleAction58, position)
+					}
+					goto l247
+				l307:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l312
+					}
+					position++
+					if buffer[position] != rune('\\') {
+						goto l312
+					}
+					position++
+					{
+
+						add(RuleAction59, position)
+					}
+					goto l247
+				l312:
+					position, tokenIndex, depth = position247, tokenIndex247, depth247
+					if buffer[position] != rune('\\') {
+						goto l245
+					}
+					position++
+					{
+
+						position314 := position
+						depth++
+						if !matchDot() {
+							goto l245
+						}
+						depth--
+						add(RulePegText, position314)
+					}
+					{
+
+						add(RuleAction60, position)
+					}
+				}
+			l247:
+				depth--
+				add(RuleEscape, position246)
+			}
+			return true
+		l245:
+			position, tokenIndex, depth = position245, tokenIndex245, depth245
+			return false
+		},
+		/* 24 LeftArrow <- <('<' '-' Spacing)> (peg.peg:113) */
+		func() bool {
+			position316, tokenIndex316, depth316 := position, tokenIndex, depth
+			{
+
+				position317 := position
+				depth++
+				if buffer[position] != rune('<') {
+					goto l316
+				}
+				position++
+				if buffer[position] != rune('-') {
+					goto l316
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l316
+				}
+				depth--
+				add(RuleLeftArrow, position317)
+			}
+			return true
+		l316:
+			position, tokenIndex, depth = position316, tokenIndex316, depth316
+			return false
+		},
+		/* 25 Slash <- <('/' Spacing)> (peg.peg:114) */
+		func() bool {
+			position318, tokenIndex318, depth318 := position, tokenIndex, depth
+			{
+
+				position319 := position
+				depth++
+				if buffer[position] != rune('/') {
+					goto l318
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l318
+				}
+				depth--
+				add(RuleSlash, position319)
+			}
+			return true
+		l318:
+			position, tokenIndex, depth = position318, tokenIndex318, depth318
+			return false
+		},
+		/* 26 And <- <('&' Spacing)> (peg.peg:115) */
+		func() bool {
+			position320, tokenIndex320, depth320 := position, tokenIndex, depth
+			{
+
+				position321 := position
+				depth++
+				if buffer[position] != rune('&') {
+					goto l320
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l320
+				}
+				depth--
+				add(RuleAnd, position321)
+			}
+			return true
+		l320:
+			position, tokenIndex, depth = position320, tokenIndex320, depth320
+			return false
+		},
+		/* 27 Not <- <('!' Spacing)> (peg.peg:116) */
+		nil,
+		/* 28 Question <- <('?' Spacing)> (peg.peg:117) */
+		nil,
+		/* 29 Star <- <('*' Spacing)> (peg.peg:118) */
+		nil,
+		/* 30 Plus <- <('+' Spacing)> (peg.peg:119) */
+		nil,
+		/* 31 Repeat <- <('{' <((' ' / '\t')* [0-9]+ (' ' / '\t')* (',' (' ' / '\t')* [0-9]* (' ' / '\t')*)?)> '}' Spacing)> (peg.peg:120) */
+		nil,
+		/* 32 Open <- <('(' Spacing)> (peg.peg:121) */
+		nil,
+		/* 33 Close <- <(')' Spacing)> (peg.peg:122) */
+		nil,
+		/* 34 Dot <- <('.' Spacing)> (peg.peg:123) */
+		nil,
+		/* 35 Spacing <- <(Space / Comment)*> (peg.peg:124) */
+		func() bool {
+			{
+
+				position331 := position
+				depth++
+			l332:
+				{
+
+					position333, tokenIndex333, depth333 := position, tokenIndex, depth
+					{
+
+						position334, tokenIndex334, depth334 := position, tokenIndex, depth
+						{
+
+							position336 := position
+							depth++
+							{
+
+								switch buffer[position] {
+								case '\t':
+									if buffer[position] != rune('\t') {
+										goto l335
+									}
+									position++
+									break
+								case ' ':
+									if buffer[position] != rune(' ') {
+										goto l335
+									}
+									position++
+									break
+								default:
+									if !rules[RuleEndOfLine]() {
+										goto l335
+									}
+									break
+								}
+							}
+
+							depth--
+							add(RuleSpace, position336)
+						}
+						goto l334
+					l335:
+						position, tokenIndex, depth = position334, tokenIndex334, depth334
+						{
+
+							position338 := position
+							depth++
+							if buffer[position] != rune('#') {
+								goto l333
+							}
+							position++
+						l339:
+							{
+
+								position340, tokenIndex340, depth340 := position, tokenIndex, depth
+								{
+
+									position341, tokenIndex341, depth341 := position, tokenIndex, depth
+									if !rules[RuleEndOfLine]() {
+										goto l341
+									}
+									goto l340
+								l341:
+									position, tokenIndex, depth = position341, tokenIndex341, depth341
+								}
+								if !matchDot() {
+									goto l340
+								}
+								goto l339
+							l340:
+								position, tokenIndex, depth = position340, tokenIndex340, depth340
+							}
+							if !rules[RuleEndOfLine]() {
+								goto l333
+							}
+							depth--
+							add(RuleComment, position338)
+						}
+					}
+				l334:
+					goto l332
+				l333:
+					position, tokenIndex, depth = position333, tokenIndex333, depth333
+				}
+				depth--
+				add(RuleSpacing, position331)
+			}
+			return true
+		},
+		/* 36 Comment <- <('#' (!EndOfLine .)* EndOfLine)> (peg.peg:125) */
+		nil,
+		/* 37 Space <- <((&('\t') '\t') | (&(' ') ' ') | (&('\n' | '\r') EndOfLine))> (peg.peg:126) */
+		nil,
+		/* 38 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> (peg.peg:127) */
+		func() bool {
+			position344, tokenIndex344, depth344 := position, tokenIndex, depth
+			{
+
+				position345 := position
+				depth++
+				{
+
+					position346, tokenIndex346, depth346 := position, tokenIndex, depth
+					if buffer[position] != rune('\r') {
+						goto l347
+					}
+					position++
+					if buffer[position] != rune('\n') {
+						goto l347
+					}
+					position++
+					goto l346
+				l347:
+					position, tokenIndex, depth = position346, tokenIndex346, depth346
+					if buffer[position] != rune('\n') {
+						goto l348
+					}
+					position++
+					goto l346
+				l348:
+					position, tokenIndex, depth = position346, tokenIndex346, depth346
+					if buffer[position] != rune('\r') {
+						goto l344
+					}
+					position++
+				}
+			l346:
+				depth--
+				add(RuleEndOfLine, position345)
+			}
+			return true
+		l344:
+			position, tokenIndex, depth = position344, tokenIndex344, depth344
+			return false
+		},
+		/* 39 EndOfFile <- <!.> (peg.peg:128) */
+		nil,
+		/* 40 Action <- <('{' <Braces*> '}' Spacing)> (peg.peg:129) */
+		func() bool {
+			position350, tokenIndex350, depth350 := position, tokenIndex, depth
+			{
+
+				position351 := position
+				depth++
+				if buffer[position] != rune('{') {
+					goto l350
+				}
+				position++
+				{
+
+					position352 := position
+					depth++
+				l353:
+					{
+
+						position354, tokenIndex354, depth354 := position, tokenIndex, depth
+						if !rules[RuleBraces]() {
+							goto l354
+						}
+						goto l353
+					l354:
+						position, tokenIndex, depth = position354, tokenIndex354, depth354
+					}
+					depth--
+					add(RulePegText, position352)
+				}
+				if buffer[position] != rune('}') {
+					goto l350
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l350
+				}
+				depth--
+				add(RuleAction, position351)
+			}
+			return true
+		l350:
+			position, tokenIndex, depth = position350, tokenIndex350, depth350
+			return false
+		},
+		/* 41 Braces <- <(('{' Braces* '}') / (!'}' .))> (peg.peg:130) */
+		func() bool {
+			position355, tokenIndex355, depth355 := position, tokenIndex, depth
+			{
+
+				position356 := position
+				depth++
+				{
+
+					position357, tokenIndex357, depth357 := position, tokenIndex, depth
+					if buffer[position] != rune('{') {
+						goto l358
+					}
+					position++
+				l359:
+					{
+
+						position360, tokenIndex360, depth360 := position, tokenIndex, depth
+						if !rules[RuleBraces]() {
+							goto l360
+						}
+						goto l359
+					l360:
+						position, tokenIndex, depth = position360, tokenIndex360, depth360
+					}
+					if buffer[position] != rune('}') {
+						goto l358
+					}
+					position++
+					goto l357
+				l358:
+					position, tokenIndex, depth = position357, tokenIndex357, depth357
+					{
+
+						position361, tokenIndex361, depth361 := position, tokenIndex, depth
+						if buffer[position] != rune('}') {
+							goto l361
+						}
+						position++
+						goto l355
+					l361:
+						position, tokenIndex, depth = position361, tokenIndex361, depth361
+					}
+					if !matchDot() {
+						goto l355
+					}
+				}
+			l357:
+				depth--
+				add(RuleBraces, position356)
+			}
+			return true
+		l355:
+			position, tokenIndex, depth = position355, tokenIndex355, depth355
+			return false
+		},
+		/* 42 Begin <- <('<' Spacing)> (peg.peg:131) */
+		nil,
+		/* 43 End <- <('>' Spacing)> (peg.peg:132) */
+		nil,
+		/* 45 Action0 <- <{ p.AddPackage(buffer[begin:end]) }> (peg.peg:20) */
+		nil,
+		/* 46 Action1 <- <{ p.AddLeg(buffer[begin:end]) }> (peg.peg:21) */
+		nil,
+		/* 47 Action2 <- <{ p.AddState(buffer[begin:end]) }> (peg.peg:22) */
+		nil,
+		/* 48 Action3 <- <{ p.At(begin); p.AddRule(buffer[begin:end]) }> (peg.peg:24) */
+		nil,
+		/* 49 Action4 <- <{ p.AddExpression() }> (peg.peg:25) */
+		nil,
+		/* 50 Action5 <- <{ p.AddAlternate() }> (peg.peg:26) */
+		nil,
+		/* 51 Action6 <- <{ p.AddNil(); p.AddAlternate() }> (peg.peg:27) */
+		nil,
+		/* 52 Action7 <- <{ p.AddNil() }> (peg.peg:29) */
+		nil,
+		/* 53 Action8 <- <{ p.AddSequence() }> (peg.peg:30) */
+		nil,
+		/* 54 Action9 <- <{ p.At(begin); p.AddPredicate(buffer[begin:end]) }> (peg.peg:32) */
+		nil,
+		/* 55 Action10 <- <{ p.AddPeekFor() }> (peg.peg:33) */
+		nil,
+		/* 56 Action11 <- <{ p.AddPeekNot() }> (peg.peg:34) */
+		nil,
+		/* 57 Action12 <- <{ p.AddQuery() }> (peg.peg:36) */
+		nil,
+		/* 58 Action13 <- <{ p.AddStar() }> (peg.peg:37) */
+		nil,
+		/* 59 Action14 <- <{ p.AddPlus() }> (peg.peg:38) */
+		nil,
+		/* 60 Action15 <- <{ p.Span(begin-1, end+1); p.AddRepeat(buffer[begin:end]) }> (peg.peg:39) */
+		nil,
+		/* 61 Action16 <- <{ p.At(begin); p.AddName(buffer[begin:end]) }> (peg.peg:41) */
+		nil,
+		/* 62 Action17 <- <{ p.AddDot() }> (peg.peg:45) */
+		nil,
+		/* 63 Action18 <- <{ p.At(begin); p.AddAction(buffer[begin:end]) }> (peg.peg:46) */
+		nil,
+		/* 64 Action19 <- <{ p.AddPush() }> (peg.peg:47) */
+		nil,
+		nil,
+		/* 66 Action20 <- <{ p.AddSequence() }> (peg.peg:53) */
+		nil,
+		/* 67 Action21 <- <{ p.AddSequence() }> (peg.peg:55) */
+		nil,
+		/* 68 Action22 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> (peg.peg:57) */
+		nil,
+		/* 69 Action23 <- <{ p.AddClassComplement() }> (peg.peg:60) */
+		nil,
+		/* 70 Action24 <- <{ p.AddClassText(buffer[begin:end]) }> (peg.peg:62) */
+		nil,
+		/* 71 Action25 <- <{ p.AddClassDifference() }> (peg.peg:64) */
+		nil,
+		/* 72 Action26 <- <{ p.AddClassIntersection() }> (peg.peg:65) */
+		nil,
+		/* 73 Action27 <- <{ p.AddAlternate() }> (peg.peg:67) */
+		nil,
+		/* 74 Action28 <- <{ p.AddAlternate() }> (peg.peg:69) */
+		nil,
+		/* 75 Action29 <- <{ p.AddClassComplement() }> (peg.peg:71) */
+		nil,
+		/* 76 Action30 <- <{ p.AddClassDifference() }> (peg.peg:74) */
+		nil,
+		/* 77 Action31 <- <{ p.AddClassIntersection() }> (peg.peg:75) */
+		nil,
+		/* 78 Action32 <- <{ p.AddAlternate() }> (peg.peg:77) */
+		nil,
+		/* 79 Action33 <- <{ p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]) }> (peg.peg:79) */
+		nil,
+		/* 80 Action34 <- <{ p.Span(begin-3, end+1); p.AddProperty(buffer[begin:end]); p.AddClassComplement() }> (peg.peg:80) */
+		nil,
+		/* 81 Action35 <- <{ p.AddRange() }> (peg.peg:81) */
+		nil,
+		/* 82 Action36 <- <{ p.AddDoubleRange() }> (peg.peg:83) */
+		nil,
+		/* 83 Action37 <- <{ p.At(begin); p.AddCharacter(buffer[begin:end]) }> (peg.peg:86) */
+		nil,
+		/* 84 Action38 <- <{ p.At(begin); p.AddDoubleCharacter(buffer[begin:end]) }> (peg.peg:88) */
+		nil,
+		/* 85 Action39 <- <{ p.At(begin); p.AddCharacter(buffer[begin:end]) }> (peg.peg:89) */
+		nil,
+		/* 86 Action40 <- <{ p.AddCharacter("\a") }> (peg.peg:91) */
+		nil,
+		/* 87 Action41 <- <{ p.AddCharacter("\b") }> (peg.peg:92) */
+		nil,
+		/* 88 Action42 <- <{ p.AddCharacter("\x1B") }> (peg.peg:93) */
+		nil,
+		/* 89 Action43 <- <{ p.AddCharacter("\f") }> (peg.peg:94) */
+		nil,
+		/* 90 Action44 <- <{ p.AddCharacter("\n") }> (peg.peg:95) */
+		nil,
+		/* 91 Action45 <- <{ p.AddCharacter("\r") }> (peg.peg:96) */
+		nil,
+		/* 92 Action46 <- <{ p.AddCharacter("\t") }> (peg.peg:97) */
+		nil,
+		/* 93 Action47 <- <{ p.AddCharacter("\v") }> (peg.peg:98) */
+		nil,
+		/* 94 Action48 <- <{ p.AddCharacter("'") }> (peg.peg:99) */
+		nil,
+		/* 95 Action49 <- <{ p.AddCharacter("\"") }> (peg.peg:100) */
+		nil,
+		/* 96 Action50 <- <{ p.AddCharacter("[") }> (peg.peg:101) */
+		nil,
+		/* 97 Action51 <- <{ p.AddCharacter("]") }> (peg.peg:102) */
+		nil,
+		/* 98 Action52 <- <{ p.AddCharacter("-") }> (peg.peg:103) */
+		nil,
+		/* 99 Action53 <- <{ p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }> (peg.peg:104) */
+		nil,
+		/* 100 Action54 <- <{ p.Span(begin-3, end+1); p.AddHexCharacter(buffer[begin:end]) }> (peg.peg:105) */
+		nil,
+		/* 101 Action55 <- <{ p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }> (peg.peg:106) */
+		nil,
+		/* 102 Action56 <- <{ p.Span(begin-2, end); p.AddHexCharacter(buffer[begin:end]) }> (peg.peg:108) */
+		nil,
+		/* 103 Action57 <- <{ p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }> (peg.peg:109) */
+		nil,
+		/* 104 Action58 <- <{ p.Span(begin-1, end); p.AddOctalCharacter(buffer[begin:end]) }> (peg.peg:110) */
+		nil,
+		/* 105 Action59 <- <{ p.AddCharacter("\\") }> (peg.peg:111) */
+		nil,
+		/* 106 Action60 <- <{ p.Span(begin-1, end); p.AddInvalidEscape(buffer[begin:end]) }> (peg.peg:112) */
+		nil,
+	}
+	p.rules = rules
+}
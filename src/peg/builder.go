@@ -0,0 +1 @@
+../leg/builder.go
\ No newline at end of file
@@ -0,0 +1 @@
+../leg/generate.go
\ No newline at end of file
@@ -0,0 +1,27 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"log"
+	"runtime"
+)
+
+func main() {
+	runtime.GOMAXPROCS(2)
+	file, buffer := readGrammar("peg")
+	p := &Peg{Tree: New(*inline, *_switch), Buffer: buffer}
+	p.SetSource(file, p.Buffer)
+	p.Init()
+	if err := p.Parse(); err != nil {
+		log.Fatal(err)
+	}
+
+	p.Execute()
+	if err := p.Err(); err != nil {
+		log.Fatal(err)
+	}
+	generate(p, p.Tree, file)
+}
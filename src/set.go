@@ -1,5 +1,10 @@
 package main
 
+import (
+	"sort"
+	"unicode"
+)
+
 /* Used to represent character classes. */
 type set struct {
 	predicates node
@@ -55,3 +60,51 @@ func (s *set) len() (length int) {
 	}
 	return
 }
+
+/* A class as a sorted list of inclusive character ranges. */
+type ranges [][2]rune
+
+func (r ranges) Len() int           { return len(r) }
+func (r ranges) Less(i, j int) bool { return r[i][0] < r[j][0] }
+func (r ranges) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+func (r ranges) complement() ranges {
+	sorted := append(ranges{}, r...)
+	sort.Sort(sorted)
+	var c ranges
+	next := rune(0)
+	for _, s := range sorted {
+		if s[0] > next {
+			c = append(c, [2]rune{next, s[0] - 1})
+		}
+		if s[1]+1 > next {
+			next = s[1] + 1
+		}
+	}
+	if next <= unicode.MaxRune {
+		c = append(c, [2]rune{next, unicode.MaxRune})
+	}
+	return c
+}
+
+/* The same class with its ranges sorted, and merged where they overlap or touch. */
+func (r ranges) normalize() ranges {
+	return r.complement().complement()
+}
+
+func (r ranges) union(s ranges) ranges {
+	return append(append(ranges{}, r...), s...).normalize()
+}
+
+func (r ranges) intersection(s ranges) ranges {
+	return append(r.complement(), s.complement()...).complement()
+}
+
+func (r ranges) difference(s ranges) ranges {
+	return append(r.complement(), s...).complement()
+}
+
+func (r ranges) contains(c rune) bool {
+	i := sort.Search(len(r), func(i int) bool { return r[i][1] >= c })
+	return i < len(r) && r[i][0] <= c
+}